@@ -19,6 +19,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/zap"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -92,6 +93,34 @@ type GruleV3ParserListener struct {
 	StopParse     bool
 	ErrorCallback *pkg.GruleErrorReporter
 	KnowledgeBase *ast.KnowledgeBase
+
+	// ruleName is the name of the rule entry being parsed, and locals the local variables it declared so far.
+	ruleName string
+	locals   map[string]bool
+	// itemNames are the item variables of the collection expressions and for statements being parsed, they hide the local variables.
+	itemNames []string
+}
+
+// addError will report an error located at the GRL text of the parser rule context.
+func (thisListener *GruleV3ParserListener) addError(ctx antlr.ParserRuleContext, err error) {
+	thisListener.ErrorCallback.AddErrorAt(err, ctx.GetStart(), ctx.GetStop())
+}
+
+// positionOf locates the production in the GRL of the resource being parsed.
+func (thisListener *GruleV3ParserListener) positionOf(ctx antlr.ParserRuleContext) ast.Position {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if stop == nil {
+		stop = start
+	}
+	endLine, endColumn := pkg.TokenEnd(stop)
+
+	return ast.Position{
+		Resource:    thisListener.ErrorCallback.Resource,
+		StartLine:   start.GetLine(),
+		StartColumn: start.GetColumn(),
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+	}
 }
 
 // VisitTerminal is called when a terminal node is visited.
@@ -135,14 +164,80 @@ func (thisListener *GruleV3ParserListener) ExitGrl(ctx *grulev3.GrlContext) {
 
 		return
 	}
+	ruleEntryContexts := make(map[string]grulev3.IRuleEntryContext)
+	for _, ruleEntryCtx := range ctx.AllRuleEntry() {
+		if ruleEntryCtx.RuleName() != nil {
+			ruleEntryContexts[ruleEntryCtx.RuleName().GetText()] = ruleEntryCtx
+		}
+	}
+	thisListener.resolveExtends(ruleEntryContexts)
 	for _, re := range thisListener.Grl.RuleEntries {
 		err := thisListener.KnowledgeBase.AddRuleEntry(re)
 		if err != nil {
-			thisListener.ErrorCallback.AddError(err)
+			if ruleEntryCtx, ok := ruleEntryContexts[re.RuleName]; ok {
+				thisListener.addError(ruleEntryCtx.RuleName(), err)
+			} else {
+				thisListener.ErrorCallback.AddError(err)
+			}
 		}
 	}
 }
 
+// resolveExtends makes the when scope of the rule entries extending another rule entry the AND of the when scope
+// of their parent and their own. A parent is looked up in the GRL being parsed, then in the knowledge base.
+// The rule entries extending an unknown rule entry, or extending themselves, are reported and not added.
+func (thisListener *GruleV3ParserListener) resolveExtends(ruleEntryContexts map[string]grulev3.IRuleEntryContext) {
+	resolved := make(map[string]bool)
+	var resolve func(entry *ast.RuleEntry, chain []string) error
+	resolve = func(entry *ast.RuleEntry, chain []string) error {
+		if len(entry.Extends) == 0 || resolved[entry.RuleName] {
+
+			return nil
+		}
+		chain = append(chain, entry.RuleName)
+		for i, name := range chain[:len(chain)-1] {
+			if name == entry.RuleName {
+
+				return fmt.Errorf("cyclic inheritance %s", strings.Join(chain[i:], " extends "))
+			}
+		}
+		parent, ok := thisListener.Grl.RuleEntries[entry.Extends]
+		if ok {
+			if err := resolve(parent, chain); err != nil {
+
+				return err
+			}
+		} else if parent, ok = thisListener.KnowledgeBase.RuleEntries[entry.Extends]; !ok {
+
+			return fmt.Errorf("rule %s extends unknown rule %s", entry.RuleName, entry.Extends)
+		}
+		entry.Inherit(parent, thisListener.KnowledgeBase.WorkingMemory)
+		resolved[entry.RuleName] = true
+
+		return nil
+	}
+
+	names := make([]string, 0, len(thisListener.Grl.RuleEntries))
+	for name := range thisListener.Grl.RuleEntries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	failed := make([]string, 0)
+	for _, name := range names {
+		if err := resolve(thisListener.Grl.RuleEntries[name], nil); err != nil {
+			if ruleEntryCtx, ok := ruleEntryContexts[name]; ok {
+				thisListener.addError(ruleEntryCtx.RuleName(), err)
+			} else {
+				thisListener.ErrorCallback.AddError(err)
+			}
+			failed = append(failed, name)
+		}
+	}
+	for _, name := range failed {
+		delete(thisListener.Grl.RuleEntries, name)
+	}
+}
+
 // EnterRuleEntry is called when production ruleEntry is entered.
 func (thisListener *GruleV3ParserListener) EnterRuleEntry(ctx *grulev3.RuleEntryContext) {
 	if thisListener.StopParse {
@@ -151,6 +246,12 @@ func (thisListener *GruleV3ParserListener) EnterRuleEntry(ctx *grulev3.RuleEntry
 	}
 	entry := ast.NewRuleEntry()
 	entry.GrlText = ctx.GetText()
+	entry.Position = thisListener.positionOf(ctx)
+	if ctx.RuleName() != nil {
+		thisListener.ruleName = ctx.RuleName().GetText()
+		entry.NamePosition = thisListener.positionOf(ctx.RuleName())
+	}
+	thisListener.locals = make(map[string]bool)
 	thisListener.Stack.Push(entry)
 }
 
@@ -183,7 +284,7 @@ func (thisListener *GruleV3ParserListener) ExitRuleEntry(ctx *grulev3.RuleEntryC
 	}
 	err := entryReceiver.ReceiveRuleEntry(entry)
 	if err != nil {
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	} else {
 		LoggerV3.Debugf("Added RuleEntry : %thisListener", entry.RuleName)
 	}
@@ -216,8 +317,193 @@ func (thisListener *GruleV3ParserListener) ExitSalience(ctx *grulev3.SalienceCon
 	err := salienceReceiver.AcceptSalience(salience)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterRuleExtends is called when production ruleExtends is entered.
+func (thisListener *GruleV3ParserListener) EnterRuleExtends(ctx *grulev3.RuleExtendsContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	entry, popOk := thisListener.Stack.Peek().(*ast.RuleEntry)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	entry.Extends = ctx.SIMPLENAME().GetText()
+}
+
+// EnterDateEffective is called when production dateEffective is entered.
+func (thisListener *GruleV3ParserListener) EnterDateEffective(ctx *grulev3.DateEffectiveContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thisListener.Stack.Push(ast.NewRuleDate(ast.DateEffective))
+}
+
+// ExitDateEffective is called when production dateEffective is exited.
+func (thisListener *GruleV3ParserListener) ExitDateEffective(ctx *grulev3.DateEffectiveContext) {
+	thisListener.exitRuleDate(ctx)
+}
+
+// EnterDateExpires is called when production dateExpires is entered.
+func (thisListener *GruleV3ParserListener) EnterDateExpires(ctx *grulev3.DateExpiresContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thisListener.Stack.Push(ast.NewRuleDate(ast.DateExpires))
+}
+
+// ExitDateExpires is called when production dateExpires is exited.
+func (thisListener *GruleV3ParserListener) ExitDateExpires(ctx *grulev3.DateExpiresContext) {
+	thisListener.exitRuleDate(ctx)
+}
+
+// exitRuleDate gives the rule date on top of the stack to the rule entry.
+func (thisListener *GruleV3ParserListener) exitRuleDate(ctx antlr.ParserRuleContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	ruleDate, popOk := thisListener.Stack.Pop().(*ast.RuleDate)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	ruleDateReceiver, popOk := thisListener.Stack.Peek().(ast.RuleDateReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := ruleDateReceiver.AcceptRuleDate(ruleDate)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterRuleAnnotation is called when production ruleAnnotation is entered.
+func (thisListener *GruleV3ParserListener) EnterRuleAnnotation(ctx *grulev3.RuleAnnotationContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thisListener.Stack.Push(ast.NewAnnotation(ctx.SIMPLENAME().GetText()))
+}
+
+// ExitRuleAnnotation is called when production ruleAnnotation is exited.
+func (thisListener *GruleV3ParserListener) ExitRuleAnnotation(ctx *grulev3.RuleAnnotationContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	annotation, popOk := thisListener.Stack.Pop().(*ast.Annotation)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	annotationReceiver, popOk := thisListener.Stack.Peek().(ast.AnnotationReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := annotationReceiver.AcceptAnnotation(annotation)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterRuleAttribute is called when production ruleAttribute is entered.
+func (thisListener *GruleV3ParserListener) EnterRuleAttribute(ctx *grulev3.RuleAttributeContext) {}
+
+// ExitRuleAttribute is called when production ruleAttribute is exited.
+func (thisListener *GruleV3ParserListener) ExitRuleAttribute(ctx *grulev3.RuleAttributeContext) {}
+
+// EnterAgendaGroup is called when production agendaGroup is entered.
+func (thisListener *GruleV3ParserListener) EnterAgendaGroup(ctx *grulev3.AgendaGroupContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	agendaGroup := ast.NewAgendaGroup()
+	thisListener.Stack.Push(agendaGroup)
+}
+
+// ExitAgendaGroup is called when production agendaGroup is exited.
+func (thisListener *GruleV3ParserListener) ExitAgendaGroup(ctx *grulev3.AgendaGroupContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	agendaGroup, popOk := thisListener.Stack.Pop().(*ast.AgendaGroup)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	if len(agendaGroup.GroupName) == 0 {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("agenda-group name can not be empty"))
+
+		return
+	}
+	agendaGroupReceiver, popOk := thisListener.Stack.Peek().(ast.AgendaGroupReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := agendaGroupReceiver.AcceptAgendaGroup(agendaGroup)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterNoLoop is called when production noLoop is entered.
+func (thisListener *GruleV3ParserListener) EnterNoLoop(ctx *grulev3.NoLoopContext) {}
+
+// ExitNoLoop is called when production noLoop is exited.
+func (thisListener *GruleV3ParserListener) ExitNoLoop(ctx *grulev3.NoLoopContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	entry, popOk := thisListener.Stack.Peek().(*ast.RuleEntry)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
 	}
+	entry.NoLoop = true
+}
+
+// EnterLockOnActive is called when production lockOnActive is entered.
+func (thisListener *GruleV3ParserListener) EnterLockOnActive(ctx *grulev3.LockOnActiveContext) {}
+
+// ExitLockOnActive is called when production lockOnActive is exited.
+func (thisListener *GruleV3ParserListener) ExitLockOnActive(ctx *grulev3.LockOnActiveContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	entry, popOk := thisListener.Stack.Peek().(*ast.RuleEntry)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	entry.LockOnActive = true
 }
 
 // EnterWhenScope is called when production whenScope is entered.
@@ -252,7 +538,7 @@ func (thisListener *GruleV3ParserListener) ExitWhenScope(ctx *grulev3.WhenScopeC
 	err := receiver.AcceptWhenScope(when)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -288,7 +574,7 @@ func (thisListener *GruleV3ParserListener) ExitThenScope(ctx *grulev3.ThenScopeC
 	err := receiver.AcceptThenScope(then)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -324,7 +610,7 @@ func (thisListener *GruleV3ParserListener) ExitThenExpressionList(ctx *grulev3.T
 	err := receiver.AcceptThenExpressionList(thenExpList)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -360,7 +646,185 @@ func (thisListener *GruleV3ParserListener) ExitThenExpression(ctx *grulev3.ThenE
 	err := receiver.AcceptThenExpression(thenExpr)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterThenStatement is called when production thenStatement is entered.
+func (thisListener *GruleV3ParserListener) EnterThenStatement(ctx *grulev3.ThenStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thenExpr := ast.NewThenExpression()
+	thenExpr.GrlText = ctx.GetText()
+	thisListener.Stack.Push(thenExpr)
+}
+
+// ExitThenStatement is called when production thenStatement is exited.
+func (thisListener *GruleV3ParserListener) ExitThenStatement(ctx *grulev3.ThenStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thenExpr, popOk := thisListener.Stack.Pop().(*ast.ThenExpression)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	receiver, popOk := thisListener.Stack.Peek().(ast.ThenExpressionReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := receiver.AcceptThenExpression(thenExpr)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterIfStatement is called when production ifStatement is entered.
+func (thisListener *GruleV3ParserListener) EnterIfStatement(ctx *grulev3.IfStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	if keyword := ctx.SIMPLENAME().GetText(); !strings.EqualFold(keyword, "if") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting if, got %s", keyword))
+
+		return
+	}
+	statement := ast.NewIfStatement()
+	statement.GrlText = ctx.GetText()
+	thisListener.Stack.Push(statement)
+}
+
+// ExitIfStatement is called when production ifStatement is exited.
+func (thisListener *GruleV3ParserListener) ExitIfStatement(ctx *grulev3.IfStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	statement, popOk := thisListener.Stack.Pop().(*ast.IfStatement)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	receiver, popOk := thisListener.Stack.Peek().(ast.IfStatementReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := receiver.AcceptIfStatement(statement)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterElseStatement is called when production elseStatement is entered.
+func (thisListener *GruleV3ParserListener) EnterElseStatement(ctx *grulev3.ElseStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	if keyword := ctx.SIMPLENAME().GetText(); !strings.EqualFold(keyword, "else") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting else, got %s", keyword))
+	}
+}
+
+// EnterForStatement is called when production forStatement is entered.
+func (thisListener *GruleV3ParserListener) EnterForStatement(ctx *grulev3.ForStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	names := ctx.AllSIMPLENAME()
+	if !strings.EqualFold(names[0].GetText(), "for") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting for, got %s", names[0].GetText()))
+
+		return
+	}
+	if !strings.EqualFold(names[2].GetText(), "in") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting in after for %s, got %s", names[1].GetText(), names[2].GetText()))
+
+		return
+	}
+	statement := ast.NewForStatement()
+	statement.GrlText = ctx.GetText()
+	statement.VariableName = names[1].GetText()
+	thisListener.itemNames = append(thisListener.itemNames, statement.VariableName)
+	thisListener.Stack.Push(statement)
+}
+
+// ExitForStatement is called when production forStatement is exited.
+func (thisListener *GruleV3ParserListener) ExitForStatement(ctx *grulev3.ForStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	statement, popOk := thisListener.Stack.Pop().(*ast.ForStatement)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	thisListener.itemNames = thisListener.itemNames[:len(thisListener.itemNames)-1]
+	receiver, popOk := thisListener.Stack.Peek().(ast.ForStatementReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := receiver.AcceptForStatement(statement)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterThenBlock is called when production thenBlock is entered.
+func (thisListener *GruleV3ParserListener) EnterThenBlock(ctx *grulev3.ThenBlockContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thenExpList := ast.NewThenExpressionList()
+	thenExpList.GrlText = ctx.GetText()
+	thisListener.Stack.Push(thenExpList)
+}
+
+// ExitThenBlock is called when production thenBlock is exited.
+func (thisListener *GruleV3ParserListener) ExitThenBlock(ctx *grulev3.ThenBlockContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	thenExpList, popOk := thisListener.Stack.Pop().(*ast.ThenExpressionList)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	receiver, popOk := thisListener.Stack.Peek().(ast.ThenExpressionListReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := receiver.AcceptThenExpressionList(thenExpList)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -372,6 +836,7 @@ func (thisListener *GruleV3ParserListener) EnterAssignment(ctx *grulev3.Assignme
 	}
 	assign := ast.NewAssignment()
 	assign.GrlText = ctx.GetText()
+	assign.Position = thisListener.positionOf(ctx)
 	thisListener.Stack.Push(assign)
 }
 
@@ -402,7 +867,67 @@ func (thisListener *GruleV3ParserListener) ExitAssignment(ctx *grulev3.Assignmen
 	err := receiver.AcceptAssignment(assign)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterLetStatement is called when production letStatement is entered.
+func (thisListener *GruleV3ParserListener) EnterLetStatement(ctx *grulev3.LetStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	if keyword := ctx.SIMPLENAME(0).GetText(); !strings.EqualFold(keyword, "let") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting let to declare local variable %s, got %s", ctx.SIMPLENAME(1).GetText(), keyword))
+
+		return
+	}
+	assign := ast.NewAssignment()
+	assign.GrlText = ctx.GetText()
+	assign.Position = thisListener.positionOf(ctx)
+	assign.IsLet = true
+	assign.IsAssign = true
+	thisListener.Stack.Push(assign)
+}
+
+// ExitLetStatement is called when production letStatement is exited.
+func (thisListener *GruleV3ParserListener) ExitLetStatement(ctx *grulev3.LetStatementContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	assign, okPop := thisListener.Stack.Pop().(*ast.Assignment)
+	if !okPop {
+		thisListener.StopParse = true
+
+		return
+	}
+	receiver, okPop := thisListener.Stack.Peek().(ast.AssignmentReceiver)
+	if !okPop {
+		thisListener.StopParse = true
+
+		return
+	}
+	name := ctx.SIMPLENAME(1).GetText()
+	if thisListener.locals[name] {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("local variable %s is already declared in rule %s", name, thisListener.ruleName))
+
+		return
+	}
+	// the variable is declared after its expression, so the expression still sees a fact with the same name.
+	thisListener.locals[name] = true
+	vari := ast.NewVariable()
+	vari.Name = name
+	vari.GrlText = name
+	vari.LocalOf = thisListener.ruleName
+	assign.Variable = thisListener.KnowledgeBase.WorkingMemory.AddVariable(vari)
+
+	err := receiver.AcceptAssignment(assign)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -414,6 +939,7 @@ func (thisListener *GruleV3ParserListener) EnterExpression(ctx *grulev3.Expressi
 	}
 	expr := ast.NewExpression()
 	expr.GrlText = ctx.GetText()
+	expr.Position = thisListener.positionOf(ctx)
 	thisListener.Stack.Push(expr)
 }
 
@@ -443,7 +969,7 @@ func (thisListener *GruleV3ParserListener) ExitExpression(ctx *grulev3.Expressio
 	err := exprRec.AcceptExpression(thisListener.KnowledgeBase.WorkingMemory.AddExpression(expr))
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -577,6 +1103,7 @@ func (thisListener *GruleV3ParserListener) EnterExpressionAtom(ctx *grulev3.Expr
 	}
 	atm := ast.NewExpressionAtom()
 	atm.GrlText = ctx.GetText()
+	atm.Position = thisListener.positionOf(ctx)
 	thisListener.Stack.Push(atm)
 }
 
@@ -599,12 +1126,123 @@ func (thisListener *GruleV3ParserListener) ExitExpressionAtom(ctx *grulev3.Expre
 		return
 	}
 	expressionAtm.Negated = ctx.NEGATION() != nil
+	thisListener.checkFunctionArity(ctx, expressionAtm)
 
 	err := expr.AcceptExpressionAtom(thisListener.KnowledgeBase.WorkingMemory.AddExpressionAtom(expressionAtm))
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
+	}
+}
+
+// checkFunctionArity reports a call to a function of the knowledge base's FunctionRegistry with the wrong number of arguments.
+func (thisListener *GruleV3ParserListener) checkFunctionArity(ctx antlr.ParserRuleContext, expressionAtm *ast.ExpressionAtom) {
+	if expressionAtm.FunctionCall == nil {
+
+		return
+	}
+	name := expressionAtm.FunctionCall.FunctionName
+	if expressionAtm.ExpressionAtom != nil {
+		namespace := expressionAtm.ExpressionAtom.Variable
+		if namespace == nil || namespace.Variable != nil || len(namespace.Name) == 0 {
+
+			return
+		}
+		name = namespace.Name + "." + name
+	}
+	function, ok := thisListener.KnowledgeBase.FunctionRegistry.GetFunction(name)
+	if !ok {
+
+		return
+	}
+	argumentCount := 0
+	if expressionAtm.FunctionCall.ArgumentList != nil {
+		argumentCount = len(expressionAtm.FunctionCall.ArgumentList.Arguments)
+	}
+	if err := function.CheckArity(argumentCount); err != nil {
+		thisListener.addError(ctx, err)
+	}
+}
+
+// EnterCollectionExpression is called when production collectionExpression is entered.
+func (thisListener *GruleV3ParserListener) EnterCollectionExpression(ctx *grulev3.CollectionExpressionContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	names := ctx.AllSIMPLENAME()
+	operatorName := names[0].GetText()
+	operator, ok := ast.GetCollectionOperator(operatorName)
+	if !ok {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("unknown collection operator %s, expecting exists, any, forall, all, count, sum, min or max", operatorName))
+
+		return
+	}
+	if !strings.EqualFold(names[2].GetText(), "in") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting in after %s(%s, got %s", operatorName, names[1].GetText(), names[2].GetText()))
+
+		return
+	}
+	if len(names) > 3 && !strings.EqualFold(names[3].GetText(), "where") {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("expecting where in %s, got %s", operatorName, names[3].GetText()))
+
+		return
+	}
+	if operator == ast.CollectionForall && ctx.COLON() == nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, fmt.Errorf("%s requires a predicate after ':'", operatorName))
+
+		return
+	}
+	collection := ast.NewCollectionExpression()
+	collection.GrlText = ctx.GetText()
+	collection.Position = thisListener.positionOf(ctx)
+	collection.Operator = operator
+	collection.VariableName = names[1].GetText()
+	collection.HasFilter = len(names) > 3
+	thisListener.itemNames = append(thisListener.itemNames, collection.VariableName)
+	thisListener.Stack.Push(collection)
+}
+
+// ExitCollectionExpression is called when production collectionExpression is exited.
+func (thisListener *GruleV3ParserListener) ExitCollectionExpression(ctx *grulev3.CollectionExpressionContext) {
+	if thisListener.StopParse {
+
+		return
+	}
+	collection, popOk := thisListener.Stack.Pop().(*ast.CollectionExpression)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	thisListener.itemNames = thisListener.itemNames[:len(thisListener.itemNames)-1]
+	receiver, popOk := thisListener.Stack.Peek().(ast.CollectionExpressionReceiver)
+	if !popOk {
+		thisListener.StopParse = true
+
+		return
+	}
+	err := receiver.AcceptCollectionExpression(collection)
+	if err != nil {
+		thisListener.StopParse = true
+		thisListener.addError(ctx, err)
+	}
+}
+
+// isItemName checks if the name is the item variable of a collection expression or a for statement being parsed.
+func (thisListener *GruleV3ParserListener) isItemName(name string) bool {
+	for _, itemName := range thisListener.itemNames {
+		if itemName == name {
+
+			return true
+		}
 	}
+
+	return false
 }
 
 // EnterArrayMapSelector is called when production arrayMapSelector is entered.
@@ -615,6 +1253,7 @@ func (thisListener *GruleV3ParserListener) EnterArrayMapSelector(ctx *grulev3.Ar
 	}
 	sel := ast.NewArrayMapSelector()
 	sel.GrlText = ctx.GetText()
+	sel.Position = thisListener.positionOf(ctx)
 	thisListener.Stack.Push(sel)
 }
 
@@ -639,7 +1278,7 @@ func (thisListener *GruleV3ParserListener) ExitArrayMapSelector(ctx *grulev3.Arr
 	err := receiver.AcceptArrayMapSelector(sel)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -650,6 +1289,8 @@ func (thisListener *GruleV3ParserListener) EnterFunctionCall(ctx *grulev3.Functi
 		return
 	}
 	fun := ast.NewFunctionCall()
+	fun.GrlText = ctx.GetText()
+	fun.Position = thisListener.positionOf(ctx)
 	fun.FunctionName = ctx.SIMPLENAME().GetText()
 	thisListener.Stack.Push(fun)
 }
@@ -675,7 +1316,7 @@ func (thisListener *GruleV3ParserListener) ExitFunctionCall(ctx *grulev3.Functio
 	err := metRec.AcceptFunctionCall(fun)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -712,7 +1353,7 @@ func (thisListener *GruleV3ParserListener) ExitArgumentList(ctx *grulev3.Argumen
 	err := argListRec.AcceptArgumentList(argList)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -730,6 +1371,7 @@ func (thisListener *GruleV3ParserListener) EnterVariable(ctx *grulev3.VariableCo
 		vari.Name = ctx.MemberVariable().GetText()[1:]
 	}
 	vari.GrlText = ctx.GetText()
+	vari.Position = thisListener.positionOf(ctx)
 	thisListener.Stack.Push(vari)
 }
 
@@ -751,11 +1393,14 @@ func (thisListener *GruleV3ParserListener) ExitVariable(ctx *grulev3.VariableCon
 
 		return
 	}
+	if vari.Variable == nil && thisListener.locals[vari.Name] && !thisListener.isItemName(vari.Name) {
+		vari.LocalOf = thisListener.ruleName
+	}
 
 	err := variRec.AcceptVariable(thisListener.KnowledgeBase.WorkingMemory.AddVariable(vari))
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -808,7 +1453,7 @@ func (thisListener *GruleV3ParserListener) ExitConstant(ctx *grulev3.ConstantCon
 	err := conRec.AcceptConstant(cons)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	}
 }
 
@@ -824,7 +1469,7 @@ func (thisListener *GruleV3ParserListener) ExitStringLiteral(ctx *grulev3.String
 	}
 	dec, err := unquoteString(ctx.GetText())
 	if err != nil {
-		thisListener.ErrorCallback.AddError(fmt.Errorf("error parsing quoted string (%s): %s", ctx.GetText(), err.Error()))
+		thisListener.addError(ctx, fmt.Errorf("error parsing quoted string (%s): %s", ctx.GetText(), err.Error()))
 
 		return
 	}
@@ -872,7 +1517,7 @@ func (thisListener *GruleV3ParserListener) ExitIntegerLiteral(ctx *grulev3.Integ
 	i, err := strconv.ParseInt(ctx.GetText(), 0, 64)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	} else {
 		lit.Integer = i
 	}
@@ -894,7 +1539,7 @@ func (thisListener *GruleV3ParserListener) ExitFloatLiteral(ctx *grulev3.FloatLi
 	i, err := strconv.ParseFloat(ctx.GetText(), 64)
 	if err != nil {
 		thisListener.StopParse = true
-		thisListener.ErrorCallback.AddError(err)
+		thisListener.addError(ctx, err)
 	} else {
 		lit.Float = i
 	}
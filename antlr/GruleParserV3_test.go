@@ -363,7 +363,7 @@ rule RuleOne "RuleOneDesc" salience 123 {
 		DataContext:   dctx,
 	})
 
-	assert.False(t, kb.RuleEntries["RuleOne"].Retracted)
+	assert.False(t, kb.IsRuleRetracted("RuleOne"))
 	assert.NotNil(t, kb.RuleEntries["RuleOne"].ThenScope)
 	assert.NotNil(t, kb.RuleEntries["RuleOne"].ThenScope.ThenExpressionList)
 	assert.NotNil(t, kb.RuleEntries["RuleOne"].ThenScope.ThenExpressionList.ThenExpressions)
@@ -375,9 +375,9 @@ rule RuleOne "RuleOneDesc" salience 123 {
 		t.Log(err)
 		t.FailNow()
 	}
-	assert.True(t, kb.RuleEntries["RuleOne"].Retracted)
+	assert.True(t, kb.IsRuleRetracted("RuleOne"))
 	kb.Reset()
-	assert.False(t, kb.RuleEntries["RuleOne"].Retracted)
+	assert.False(t, kb.IsRuleRetracted("RuleOne"))
 }
 
 func TestV3RuleAssignment(t *testing.T) {
@@ -406,7 +406,7 @@ rule RuleOne "RuleOneDesc" salience 123 {
 	err = dctx.Add("Person", p)
 
 	assert.NoError(t, err)
-	assert.False(t, kb.RuleEntries["RuleOne"].Retracted)
+	assert.False(t, kb.IsRuleRetracted("RuleOne"))
 	assert.NotNil(t, kb.RuleEntries["RuleOne"].ThenScope)
 	assert.NotNil(t, kb.RuleEntries["RuleOne"].ThenScope.ThenExpressionList)
 	assert.NotNil(t, kb.RuleEntries["RuleOne"].ThenScope.ThenExpressionList.ThenExpressions)
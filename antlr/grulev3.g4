@@ -6,13 +6,54 @@ grl
     ;
 
 ruleEntry
-    : RULE ruleName ruleDescription? salience? LR_BRACE whenScope thenScope RR_BRACE
+    : ruleAnnotation* RULE ruleName ruleExtends? ruleDescription? salience? ruleAttribute* LR_BRACE whenScope thenScope RR_BRACE
+    ;
+
+ruleExtends
+    : EXTENDS SIMPLENAME
+    ;
+
+ruleAnnotation
+    : AT SIMPLENAME (LR_BRACKET (stringLiteral (',' stringLiteral)*)? RR_BRACKET)?
     ;
 
 salience
     : SALIENCE integerLiteral
     ;
 
+ruleAttribute
+    : agendaGroup
+    | noLoop
+    | lockOnActive
+    | dateEffective
+    | dateExpires
+    | ruleEnabled
+    ;
+
+agendaGroup
+    : AGENDA_GROUP stringLiteral
+    ;
+
+noLoop
+    : NO_LOOP
+    ;
+
+lockOnActive
+    : LOCK_ON_ACTIVE
+    ;
+
+dateEffective
+    : DATE_EFFECTIVE stringLiteral
+    ;
+
+dateExpires
+    : DATE_EXPIRES stringLiteral
+    ;
+
+ruleEnabled
+    : ENABLED expression
+    ;
+
 ruleName
     : SIMPLENAME
     ;
@@ -22,7 +63,7 @@ ruleDescription
     ;
 
 whenScope
-    : WHEN  expression
+    : WHEN (letStatement SEMICOLON)* expression
     ;
 
 thenScope
@@ -30,11 +71,33 @@ thenScope
     ;
 
 thenExpressionList
-    : (thenExpression SEMICOLON)+
+    : (thenExpression SEMICOLON | thenStatement)+
+    ;
+
+thenStatement
+    : ifStatement
+    | forStatement
+    ;
+
+ifStatement
+    : SIMPLENAME expression thenBlock elseStatement?
+    ;
+
+elseStatement
+    : SIMPLENAME (ifStatement | thenBlock)
+    ;
+
+forStatement
+    : SIMPLENAME SIMPLENAME SIMPLENAME expressionAtom thenBlock
+    ;
+
+thenBlock
+    : LR_BRACE (thenExpression SEMICOLON | thenStatement)* RR_BRACE
     ;
 
 thenExpression
     : assignment
+    | letStatement
     | expressionAtom
     ;
 
@@ -42,6 +105,10 @@ assignment
     : variable (ASSIGN | PLUS_ASIGN | MINUS_ASIGN | DIV_ASIGN | MUL_ASIGN) expression
     ;
 
+letStatement
+    : SIMPLENAME SIMPLENAME ASSIGN expression
+    ;
+
 expression
     : expression mulDivOperators expression
     | expression addMinusOperators expression
@@ -75,6 +142,7 @@ orLogicOperator
 expressionAtom
     : constant
     | variable
+    | collectionExpression
     | functionCall
     | expressionAtom methodCall
     | expressionAtom memberVariable
@@ -82,6 +150,10 @@ expressionAtom
     | NEGATION expressionAtom
     ;
 
+collectionExpression
+    : SIMPLENAME LR_BRACKET SIMPLENAME SIMPLENAME expressionAtom (SIMPLENAME expression)? (COLON expression)? RR_BRACKET
+    ;
+
 constant
     : stringLiteral
     | integerLiteral
@@ -213,6 +285,8 @@ MUL                         : '*' ;
 MOD                         : '%' ;
 DOT                         : '.' ;
 SEMICOLON                   : ';' ;
+COLON                       : ':' ;
+AT                          : '@' ;
 
 LR_BRACE                    : '{';
 RR_BRACE                    : '}';
@@ -231,6 +305,13 @@ FALSE                       : F A L S E ;
 NIL_LITERAL                 : N I L ;
 NEGATION                    : '!' ;
 SALIENCE                    : S A L I E N C E ;
+AGENDA_GROUP                : A G E N D A '-' G R O U P ;
+NO_LOOP                     : N O '-' L O O P ;
+LOCK_ON_ACTIVE              : L O C K '-' O N '-' A C T I V E ;
+DATE_EFFECTIVE              : D A T E '-' E F F E C T I V E ;
+DATE_EXPIRES                : D A T E '-' E X P I R E S ;
+EXTENDS                     : E X T E N D S ;
+ENABLED                     : E N A B L E D ;
 
 EQUALS                      : '==' ;
 ASSIGN                      : '=' ;
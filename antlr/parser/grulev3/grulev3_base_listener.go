@@ -32,12 +32,66 @@ func (s *Basegrulev3Listener) EnterRuleEntry(ctx *RuleEntryContext) {}
 // ExitRuleEntry is called when production ruleEntry is exited.
 func (s *Basegrulev3Listener) ExitRuleEntry(ctx *RuleEntryContext) {}
 
+// EnterRuleExtends is called when production ruleExtends is entered.
+func (s *Basegrulev3Listener) EnterRuleExtends(ctx *RuleExtendsContext) {}
+
+// ExitRuleExtends is called when production ruleExtends is exited.
+func (s *Basegrulev3Listener) ExitRuleExtends(ctx *RuleExtendsContext) {}
+
+// EnterRuleAnnotation is called when production ruleAnnotation is entered.
+func (s *Basegrulev3Listener) EnterRuleAnnotation(ctx *RuleAnnotationContext) {}
+
+// ExitRuleAnnotation is called when production ruleAnnotation is exited.
+func (s *Basegrulev3Listener) ExitRuleAnnotation(ctx *RuleAnnotationContext) {}
+
 // EnterSalience is called when production salience is entered.
 func (s *Basegrulev3Listener) EnterSalience(ctx *SalienceContext) {}
 
 // ExitSalience is called when production salience is exited.
 func (s *Basegrulev3Listener) ExitSalience(ctx *SalienceContext) {}
 
+// EnterRuleAttribute is called when production ruleAttribute is entered.
+func (s *Basegrulev3Listener) EnterRuleAttribute(ctx *RuleAttributeContext) {}
+
+// ExitRuleAttribute is called when production ruleAttribute is exited.
+func (s *Basegrulev3Listener) ExitRuleAttribute(ctx *RuleAttributeContext) {}
+
+// EnterAgendaGroup is called when production agendaGroup is entered.
+func (s *Basegrulev3Listener) EnterAgendaGroup(ctx *AgendaGroupContext) {}
+
+// ExitAgendaGroup is called when production agendaGroup is exited.
+func (s *Basegrulev3Listener) ExitAgendaGroup(ctx *AgendaGroupContext) {}
+
+// EnterNoLoop is called when production noLoop is entered.
+func (s *Basegrulev3Listener) EnterNoLoop(ctx *NoLoopContext) {}
+
+// ExitNoLoop is called when production noLoop is exited.
+func (s *Basegrulev3Listener) ExitNoLoop(ctx *NoLoopContext) {}
+
+// EnterLockOnActive is called when production lockOnActive is entered.
+func (s *Basegrulev3Listener) EnterLockOnActive(ctx *LockOnActiveContext) {}
+
+// ExitLockOnActive is called when production lockOnActive is exited.
+func (s *Basegrulev3Listener) ExitLockOnActive(ctx *LockOnActiveContext) {}
+
+// EnterDateEffective is called when production dateEffective is entered.
+func (s *Basegrulev3Listener) EnterDateEffective(ctx *DateEffectiveContext) {}
+
+// ExitDateEffective is called when production dateEffective is exited.
+func (s *Basegrulev3Listener) ExitDateEffective(ctx *DateEffectiveContext) {}
+
+// EnterDateExpires is called when production dateExpires is entered.
+func (s *Basegrulev3Listener) EnterDateExpires(ctx *DateExpiresContext) {}
+
+// ExitDateExpires is called when production dateExpires is exited.
+func (s *Basegrulev3Listener) ExitDateExpires(ctx *DateExpiresContext) {}
+
+// EnterRuleEnabled is called when production ruleEnabled is entered.
+func (s *Basegrulev3Listener) EnterRuleEnabled(ctx *RuleEnabledContext) {}
+
+// ExitRuleEnabled is called when production ruleEnabled is exited.
+func (s *Basegrulev3Listener) ExitRuleEnabled(ctx *RuleEnabledContext) {}
+
 // EnterRuleName is called when production ruleName is entered.
 func (s *Basegrulev3Listener) EnterRuleName(ctx *RuleNameContext) {}
 
@@ -68,6 +122,36 @@ func (s *Basegrulev3Listener) EnterThenExpressionList(ctx *ThenExpressionListCon
 // ExitThenExpressionList is called when production thenExpressionList is exited.
 func (s *Basegrulev3Listener) ExitThenExpressionList(ctx *ThenExpressionListContext) {}
 
+// EnterThenStatement is called when production thenStatement is entered.
+func (s *Basegrulev3Listener) EnterThenStatement(ctx *ThenStatementContext) {}
+
+// ExitThenStatement is called when production thenStatement is exited.
+func (s *Basegrulev3Listener) ExitThenStatement(ctx *ThenStatementContext) {}
+
+// EnterIfStatement is called when production ifStatement is entered.
+func (s *Basegrulev3Listener) EnterIfStatement(ctx *IfStatementContext) {}
+
+// ExitIfStatement is called when production ifStatement is exited.
+func (s *Basegrulev3Listener) ExitIfStatement(ctx *IfStatementContext) {}
+
+// EnterElseStatement is called when production elseStatement is entered.
+func (s *Basegrulev3Listener) EnterElseStatement(ctx *ElseStatementContext) {}
+
+// ExitElseStatement is called when production elseStatement is exited.
+func (s *Basegrulev3Listener) ExitElseStatement(ctx *ElseStatementContext) {}
+
+// EnterForStatement is called when production forStatement is entered.
+func (s *Basegrulev3Listener) EnterForStatement(ctx *ForStatementContext) {}
+
+// ExitForStatement is called when production forStatement is exited.
+func (s *Basegrulev3Listener) ExitForStatement(ctx *ForStatementContext) {}
+
+// EnterThenBlock is called when production thenBlock is entered.
+func (s *Basegrulev3Listener) EnterThenBlock(ctx *ThenBlockContext) {}
+
+// ExitThenBlock is called when production thenBlock is exited.
+func (s *Basegrulev3Listener) ExitThenBlock(ctx *ThenBlockContext) {}
+
 // EnterThenExpression is called when production thenExpression is entered.
 func (s *Basegrulev3Listener) EnterThenExpression(ctx *ThenExpressionContext) {}
 
@@ -80,6 +164,12 @@ func (s *Basegrulev3Listener) EnterAssignment(ctx *AssignmentContext) {}
 // ExitAssignment is called when production assignment is exited.
 func (s *Basegrulev3Listener) ExitAssignment(ctx *AssignmentContext) {}
 
+// EnterLetStatement is called when production letStatement is entered.
+func (s *Basegrulev3Listener) EnterLetStatement(ctx *LetStatementContext) {}
+
+// ExitLetStatement is called when production letStatement is exited.
+func (s *Basegrulev3Listener) ExitLetStatement(ctx *LetStatementContext) {}
+
 // EnterExpression is called when production expression is entered.
 func (s *Basegrulev3Listener) EnterExpression(ctx *ExpressionContext) {}
 
@@ -122,6 +212,12 @@ func (s *Basegrulev3Listener) EnterExpressionAtom(ctx *ExpressionAtomContext) {}
 // ExitExpressionAtom is called when production expressionAtom is exited.
 func (s *Basegrulev3Listener) ExitExpressionAtom(ctx *ExpressionAtomContext) {}
 
+// EnterCollectionExpression is called when production collectionExpression is entered.
+func (s *Basegrulev3Listener) EnterCollectionExpression(ctx *CollectionExpressionContext) {}
+
+// ExitCollectionExpression is called when production collectionExpression is exited.
+func (s *Basegrulev3Listener) ExitCollectionExpression(ctx *CollectionExpressionContext) {}
+
 // EnterConstant is called when production constant is entered.
 func (s *Basegrulev3Listener) EnterConstant(ctx *ConstantContext) {}
 
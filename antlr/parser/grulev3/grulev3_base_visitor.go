@@ -15,10 +15,46 @@ func (v *Basegrulev3Visitor) VisitRuleEntry(ctx *RuleEntryContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *Basegrulev3Visitor) VisitRuleExtends(ctx *RuleExtendsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitRuleAnnotation(ctx *RuleAnnotationContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *Basegrulev3Visitor) VisitSalience(ctx *SalienceContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *Basegrulev3Visitor) VisitRuleAttribute(ctx *RuleAttributeContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitAgendaGroup(ctx *AgendaGroupContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitNoLoop(ctx *NoLoopContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitLockOnActive(ctx *LockOnActiveContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitDateEffective(ctx *DateEffectiveContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitDateExpires(ctx *DateExpiresContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitRuleEnabled(ctx *RuleEnabledContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *Basegrulev3Visitor) VisitRuleName(ctx *RuleNameContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -39,6 +75,26 @@ func (v *Basegrulev3Visitor) VisitThenExpressionList(ctx *ThenExpressionListCont
 	return v.VisitChildren(ctx)
 }
 
+func (v *Basegrulev3Visitor) VisitThenStatement(ctx *ThenStatementContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitIfStatement(ctx *IfStatementContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitElseStatement(ctx *ElseStatementContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitForStatement(ctx *ForStatementContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *Basegrulev3Visitor) VisitThenBlock(ctx *ThenBlockContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *Basegrulev3Visitor) VisitThenExpression(ctx *ThenExpressionContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -47,6 +103,10 @@ func (v *Basegrulev3Visitor) VisitAssignment(ctx *AssignmentContext) interface{}
 	return v.VisitChildren(ctx)
 }
 
+func (v *Basegrulev3Visitor) VisitLetStatement(ctx *LetStatementContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *Basegrulev3Visitor) VisitExpression(ctx *ExpressionContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -75,6 +135,10 @@ func (v *Basegrulev3Visitor) VisitExpressionAtom(ctx *ExpressionAtomContext) int
 	return v.VisitChildren(ctx)
 }
 
+func (v *Basegrulev3Visitor) VisitCollectionExpression(ctx *CollectionExpressionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *Basegrulev3Visitor) VisitConstant(ctx *ConstantContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -13,9 +13,36 @@ type grulev3Listener interface {
 	// EnterRuleEntry is called when entering the ruleEntry production.
 	EnterRuleEntry(c *RuleEntryContext)
 
+	// EnterRuleExtends is called when entering the ruleExtends production.
+	EnterRuleExtends(c *RuleExtendsContext)
+
+	// EnterRuleAnnotation is called when entering the ruleAnnotation production.
+	EnterRuleAnnotation(c *RuleAnnotationContext)
+
 	// EnterSalience is called when entering the salience production.
 	EnterSalience(c *SalienceContext)
 
+	// EnterRuleAttribute is called when entering the ruleAttribute production.
+	EnterRuleAttribute(c *RuleAttributeContext)
+
+	// EnterAgendaGroup is called when entering the agendaGroup production.
+	EnterAgendaGroup(c *AgendaGroupContext)
+
+	// EnterNoLoop is called when entering the noLoop production.
+	EnterNoLoop(c *NoLoopContext)
+
+	// EnterLockOnActive is called when entering the lockOnActive production.
+	EnterLockOnActive(c *LockOnActiveContext)
+
+	// EnterDateEffective is called when entering the dateEffective production.
+	EnterDateEffective(c *DateEffectiveContext)
+
+	// EnterDateExpires is called when entering the dateExpires production.
+	EnterDateExpires(c *DateExpiresContext)
+
+	// EnterRuleEnabled is called when entering the ruleEnabled production.
+	EnterRuleEnabled(c *RuleEnabledContext)
+
 	// EnterRuleName is called when entering the ruleName production.
 	EnterRuleName(c *RuleNameContext)
 
@@ -31,12 +58,30 @@ type grulev3Listener interface {
 	// EnterThenExpressionList is called when entering the thenExpressionList production.
 	EnterThenExpressionList(c *ThenExpressionListContext)
 
+	// EnterThenStatement is called when entering the thenStatement production.
+	EnterThenStatement(c *ThenStatementContext)
+
+	// EnterIfStatement is called when entering the ifStatement production.
+	EnterIfStatement(c *IfStatementContext)
+
+	// EnterElseStatement is called when entering the elseStatement production.
+	EnterElseStatement(c *ElseStatementContext)
+
+	// EnterForStatement is called when entering the forStatement production.
+	EnterForStatement(c *ForStatementContext)
+
+	// EnterThenBlock is called when entering the thenBlock production.
+	EnterThenBlock(c *ThenBlockContext)
+
 	// EnterThenExpression is called when entering the thenExpression production.
 	EnterThenExpression(c *ThenExpressionContext)
 
 	// EnterAssignment is called when entering the assignment production.
 	EnterAssignment(c *AssignmentContext)
 
+	// EnterLetStatement is called when entering the letStatement production.
+	EnterLetStatement(c *LetStatementContext)
+
 	// EnterExpression is called when entering the expression production.
 	EnterExpression(c *ExpressionContext)
 
@@ -58,6 +103,9 @@ type grulev3Listener interface {
 	// EnterExpressionAtom is called when entering the expressionAtom production.
 	EnterExpressionAtom(c *ExpressionAtomContext)
 
+	// EnterCollectionExpression is called when entering the collectionExpression production.
+	EnterCollectionExpression(c *CollectionExpressionContext)
+
 	// EnterConstant is called when entering the constant production.
 	EnterConstant(c *ConstantContext)
 
@@ -112,9 +160,36 @@ type grulev3Listener interface {
 	// ExitRuleEntry is called when exiting the ruleEntry production.
 	ExitRuleEntry(c *RuleEntryContext)
 
+	// ExitRuleExtends is called when exiting the ruleExtends production.
+	ExitRuleExtends(c *RuleExtendsContext)
+
+	// ExitRuleAnnotation is called when exiting the ruleAnnotation production.
+	ExitRuleAnnotation(c *RuleAnnotationContext)
+
 	// ExitSalience is called when exiting the salience production.
 	ExitSalience(c *SalienceContext)
 
+	// ExitRuleAttribute is called when exiting the ruleAttribute production.
+	ExitRuleAttribute(c *RuleAttributeContext)
+
+	// ExitAgendaGroup is called when exiting the agendaGroup production.
+	ExitAgendaGroup(c *AgendaGroupContext)
+
+	// ExitNoLoop is called when exiting the noLoop production.
+	ExitNoLoop(c *NoLoopContext)
+
+	// ExitLockOnActive is called when exiting the lockOnActive production.
+	ExitLockOnActive(c *LockOnActiveContext)
+
+	// ExitDateEffective is called when exiting the dateEffective production.
+	ExitDateEffective(c *DateEffectiveContext)
+
+	// ExitDateExpires is called when exiting the dateExpires production.
+	ExitDateExpires(c *DateExpiresContext)
+
+	// ExitRuleEnabled is called when exiting the ruleEnabled production.
+	ExitRuleEnabled(c *RuleEnabledContext)
+
 	// ExitRuleName is called when exiting the ruleName production.
 	ExitRuleName(c *RuleNameContext)
 
@@ -130,12 +205,30 @@ type grulev3Listener interface {
 	// ExitThenExpressionList is called when exiting the thenExpressionList production.
 	ExitThenExpressionList(c *ThenExpressionListContext)
 
+	// ExitThenStatement is called when exiting the thenStatement production.
+	ExitThenStatement(c *ThenStatementContext)
+
+	// ExitIfStatement is called when exiting the ifStatement production.
+	ExitIfStatement(c *IfStatementContext)
+
+	// ExitElseStatement is called when exiting the elseStatement production.
+	ExitElseStatement(c *ElseStatementContext)
+
+	// ExitForStatement is called when exiting the forStatement production.
+	ExitForStatement(c *ForStatementContext)
+
+	// ExitThenBlock is called when exiting the thenBlock production.
+	ExitThenBlock(c *ThenBlockContext)
+
 	// ExitThenExpression is called when exiting the thenExpression production.
 	ExitThenExpression(c *ThenExpressionContext)
 
 	// ExitAssignment is called when exiting the assignment production.
 	ExitAssignment(c *AssignmentContext)
 
+	// ExitLetStatement is called when exiting the letStatement production.
+	ExitLetStatement(c *LetStatementContext)
+
 	// ExitExpression is called when exiting the expression production.
 	ExitExpression(c *ExpressionContext)
 
@@ -157,6 +250,9 @@ type grulev3Listener interface {
 	// ExitExpressionAtom is called when exiting the expressionAtom production.
 	ExitExpressionAtom(c *ExpressionAtomContext)
 
+	// ExitCollectionExpression is called when exiting the collectionExpression production.
+	ExitCollectionExpression(c *CollectionExpressionContext)
+
 	// ExitConstant is called when exiting the constant production.
 	ExitConstant(c *ConstantContext)
 
@@ -32,145 +32,219 @@ var Grulev3ParserStaticData struct {
 func grulev3ParserInit() {
 	staticData := &Grulev3ParserStaticData
 	staticData.LiteralNames = []string{
-		"", "','", "'+'", "'-'", "'/'", "'*'", "'%'", "'.'", "';'", "'{'", "'}'",
-		"'('", "')'", "'['", "']'", "", "", "", "'&&'", "'||'", "", "", "",
-		"'!'", "", "'=='", "'='", "'+='", "'-='", "'/='", "'*='", "'>'", "'<'",
-		"'>='", "'<='", "'!='", "'&'", "'|'",
+		"", "','", "'+'", "'-'", "'/'", "'*'", "'%'", "'.'", "';'", "':'", "'@'",
+		"'{'", "'}'", "'('", "')'", "'['", "']'", "", "", "", "'&&'", "'||'",
+		"", "", "", "'!'", "", "", "", "", "", "", "", "", "'=='", "'='", "'+='",
+		"'-='", "'/='", "'*='", "'>'", "'<'", "'>='", "'<='", "'!='", "'&'",
+		"'|'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "", "PLUS", "MINUS", "DIV", "MUL", "MOD", "DOT", "SEMICOLON", "LR_BRACE",
-		"RR_BRACE", "LR_BRACKET", "RR_BRACKET", "LS_BRACKET", "RS_BRACKET",
-		"RULE", "WHEN", "THEN", "AND", "OR", "TRUE", "FALSE", "NIL_LITERAL",
-		"NEGATION", "SALIENCE", "EQUALS", "ASSIGN", "PLUS_ASIGN", "MINUS_ASIGN",
-		"DIV_ASIGN", "MUL_ASIGN", "GT", "LT", "GTE", "LTE", "NOTEQUALS", "BITAND",
-		"BITOR", "SIMPLENAME", "DQUOTA_STRING", "SQUOTA_STRING", "DECIMAL_FLOAT_LIT",
-		"DECIMAL_EXPONENT", "HEX_FLOAT_LIT", "HEX_EXPONENT", "DEC_LIT", "HEX_LIT",
-		"OCT_LIT", "SPACE", "COMMENT", "LINE_COMMENT",
+		"", "", "PLUS", "MINUS", "DIV", "MUL", "MOD", "DOT", "SEMICOLON", "COLON",
+		"AT", "LR_BRACE", "RR_BRACE", "LR_BRACKET", "RR_BRACKET", "LS_BRACKET",
+		"RS_BRACKET", "RULE", "WHEN", "THEN", "AND", "OR", "TRUE", "FALSE",
+		"NIL_LITERAL", "NEGATION", "SALIENCE", "AGENDA_GROUP", "NO_LOOP", "LOCK_ON_ACTIVE",
+		"DATE_EFFECTIVE", "DATE_EXPIRES", "EXTENDS", "ENABLED", "EQUALS", "ASSIGN",
+		"PLUS_ASIGN", "MINUS_ASIGN", "DIV_ASIGN", "MUL_ASIGN", "GT", "LT", "GTE",
+		"LTE", "NOTEQUALS", "BITAND", "BITOR", "SIMPLENAME", "DQUOTA_STRING",
+		"SQUOTA_STRING", "DECIMAL_FLOAT_LIT", "DECIMAL_EXPONENT", "HEX_FLOAT_LIT",
+		"HEX_EXPONENT", "DEC_LIT", "HEX_LIT", "OCT_LIT", "SPACE", "COMMENT",
+		"LINE_COMMENT",
 	}
 	staticData.RuleNames = []string{
-		"grl", "ruleEntry", "salience", "ruleName", "ruleDescription", "whenScope",
-		"thenScope", "thenExpressionList", "thenExpression", "assignment", "expression",
-		"mulDivOperators", "addMinusOperators", "comparisonOperator", "andLogicOperator",
-		"orLogicOperator", "expressionAtom", "constant", "variable", "arrayMapSelector",
-		"memberVariable", "functionCall", "methodCall", "argumentList", "floatLiteral",
-		"decimalFloatLiteral", "hexadecimalFloatLiteral", "integerLiteral",
-		"decimalLiteral", "hexadecimalLiteral", "octalLiteral", "stringLiteral",
-		"booleanLiteral",
+		"grl", "ruleEntry", "ruleExtends", "ruleAnnotation", "salience", "ruleAttribute",
+		"agendaGroup", "noLoop", "lockOnActive", "dateEffective", "dateExpires",
+		"ruleEnabled", "ruleName", "ruleDescription", "whenScope", "thenScope",
+		"thenExpressionList", "thenStatement", "ifStatement", "elseStatement",
+		"forStatement", "thenBlock", "thenExpression", "assignment", "letStatement",
+		"expression", "mulDivOperators", "addMinusOperators", "comparisonOperator",
+		"andLogicOperator", "orLogicOperator", "expressionAtom", "collectionExpression",
+		"constant", "variable", "arrayMapSelector", "memberVariable", "functionCall",
+		"methodCall", "argumentList", "floatLiteral", "decimalFloatLiteral",
+		"hexadecimalFloatLiteral", "integerLiteral", "decimalLiteral", "hexadecimalLiteral",
+		"octalLiteral", "stringLiteral", "booleanLiteral",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 50, 263, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 59, 417, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
 		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
 		21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25, 2, 26,
 		7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2, 31, 7,
-		31, 2, 32, 7, 32, 1, 0, 5, 0, 68, 8, 0, 10, 0, 12, 0, 71, 9, 0, 1, 0, 1,
-		0, 1, 1, 1, 1, 1, 1, 3, 1, 78, 8, 1, 1, 1, 3, 1, 81, 8, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 5, 1, 5,
-		1, 5, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 4, 7, 104, 8, 7, 11, 7, 12, 7,
-		105, 1, 8, 1, 8, 3, 8, 110, 8, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10,
-		3, 10, 118, 8, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 3, 10, 125, 8, 10,
-		1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1,
-		10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 5, 10,
-		147, 8, 10, 10, 10, 12, 10, 150, 9, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1,
-		13, 1, 13, 1, 14, 1, 14, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16,
-		1, 16, 3, 16, 168, 8, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 5,
-		16, 176, 8, 16, 10, 16, 12, 16, 179, 9, 16, 1, 17, 1, 17, 1, 17, 1, 17,
-		1, 17, 3, 17, 186, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1,
-		18, 5, 18, 195, 8, 18, 10, 18, 12, 18, 198, 9, 18, 1, 19, 1, 19, 1, 19,
-		1, 19, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 3, 21, 210, 8, 21, 1,
-		21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 5, 23, 220, 8, 23,
-		10, 23, 12, 23, 223, 9, 23, 1, 24, 1, 24, 3, 24, 227, 8, 24, 1, 25, 3,
-		25, 230, 8, 25, 1, 25, 1, 25, 1, 26, 3, 26, 235, 8, 26, 1, 26, 1, 26, 1,
-		27, 1, 27, 1, 27, 3, 27, 242, 8, 27, 1, 28, 3, 28, 245, 8, 28, 1, 28, 1,
-		28, 1, 29, 3, 29, 250, 8, 29, 1, 29, 1, 29, 1, 30, 3, 30, 255, 8, 30, 1,
-		30, 1, 30, 1, 31, 1, 31, 1, 32, 1, 32, 1, 32, 0, 3, 20, 32, 36, 33, 0,
-		2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38,
-		40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 0, 6, 1, 0, 39, 40,
-		1, 0, 26, 30, 1, 0, 4, 6, 2, 0, 2, 3, 36, 37, 2, 0, 25, 25, 31, 35, 1,
-		0, 20, 21, 263, 0, 69, 1, 0, 0, 0, 2, 74, 1, 0, 0, 0, 4, 87, 1, 0, 0, 0,
-		6, 90, 1, 0, 0, 0, 8, 92, 1, 0, 0, 0, 10, 94, 1, 0, 0, 0, 12, 97, 1, 0,
-		0, 0, 14, 103, 1, 0, 0, 0, 16, 109, 1, 0, 0, 0, 18, 111, 1, 0, 0, 0, 20,
-		124, 1, 0, 0, 0, 22, 151, 1, 0, 0, 0, 24, 153, 1, 0, 0, 0, 26, 155, 1,
-		0, 0, 0, 28, 157, 1, 0, 0, 0, 30, 159, 1, 0, 0, 0, 32, 167, 1, 0, 0, 0,
-		34, 185, 1, 0, 0, 0, 36, 187, 1, 0, 0, 0, 38, 199, 1, 0, 0, 0, 40, 203,
-		1, 0, 0, 0, 42, 206, 1, 0, 0, 0, 44, 213, 1, 0, 0, 0, 46, 216, 1, 0, 0,
-		0, 48, 226, 1, 0, 0, 0, 50, 229, 1, 0, 0, 0, 52, 234, 1, 0, 0, 0, 54, 241,
-		1, 0, 0, 0, 56, 244, 1, 0, 0, 0, 58, 249, 1, 0, 0, 0, 60, 254, 1, 0, 0,
-		0, 62, 258, 1, 0, 0, 0, 64, 260, 1, 0, 0, 0, 66, 68, 3, 2, 1, 0, 67, 66,
-		1, 0, 0, 0, 68, 71, 1, 0, 0, 0, 69, 67, 1, 0, 0, 0, 69, 70, 1, 0, 0, 0,
-		70, 72, 1, 0, 0, 0, 71, 69, 1, 0, 0, 0, 72, 73, 5, 0, 0, 1, 73, 1, 1, 0,
-		0, 0, 74, 75, 5, 15, 0, 0, 75, 77, 3, 6, 3, 0, 76, 78, 3, 8, 4, 0, 77,
-		76, 1, 0, 0, 0, 77, 78, 1, 0, 0, 0, 78, 80, 1, 0, 0, 0, 79, 81, 3, 4, 2,
-		0, 80, 79, 1, 0, 0, 0, 80, 81, 1, 0, 0, 0, 81, 82, 1, 0, 0, 0, 82, 83,
-		5, 9, 0, 0, 83, 84, 3, 10, 5, 0, 84, 85, 3, 12, 6, 0, 85, 86, 5, 10, 0,
-		0, 86, 3, 1, 0, 0, 0, 87, 88, 5, 24, 0, 0, 88, 89, 3, 54, 27, 0, 89, 5,
-		1, 0, 0, 0, 90, 91, 5, 38, 0, 0, 91, 7, 1, 0, 0, 0, 92, 93, 7, 0, 0, 0,
-		93, 9, 1, 0, 0, 0, 94, 95, 5, 16, 0, 0, 95, 96, 3, 20, 10, 0, 96, 11, 1,
-		0, 0, 0, 97, 98, 5, 17, 0, 0, 98, 99, 3, 14, 7, 0, 99, 13, 1, 0, 0, 0,
-		100, 101, 3, 16, 8, 0, 101, 102, 5, 8, 0, 0, 102, 104, 1, 0, 0, 0, 103,
-		100, 1, 0, 0, 0, 104, 105, 1, 0, 0, 0, 105, 103, 1, 0, 0, 0, 105, 106,
-		1, 0, 0, 0, 106, 15, 1, 0, 0, 0, 107, 110, 3, 18, 9, 0, 108, 110, 3, 32,
-		16, 0, 109, 107, 1, 0, 0, 0, 109, 108, 1, 0, 0, 0, 110, 17, 1, 0, 0, 0,
-		111, 112, 3, 36, 18, 0, 112, 113, 7, 1, 0, 0, 113, 114, 3, 20, 10, 0, 114,
-		19, 1, 0, 0, 0, 115, 117, 6, 10, -1, 0, 116, 118, 5, 23, 0, 0, 117, 116,
-		1, 0, 0, 0, 117, 118, 1, 0, 0, 0, 118, 119, 1, 0, 0, 0, 119, 120, 5, 11,
-		0, 0, 120, 121, 3, 20, 10, 0, 121, 122, 5, 12, 0, 0, 122, 125, 1, 0, 0,
-		0, 123, 125, 3, 32, 16, 0, 124, 115, 1, 0, 0, 0, 124, 123, 1, 0, 0, 0,
-		125, 148, 1, 0, 0, 0, 126, 127, 10, 7, 0, 0, 127, 128, 3, 22, 11, 0, 128,
-		129, 3, 20, 10, 8, 129, 147, 1, 0, 0, 0, 130, 131, 10, 6, 0, 0, 131, 132,
-		3, 24, 12, 0, 132, 133, 3, 20, 10, 7, 133, 147, 1, 0, 0, 0, 134, 135, 10,
-		5, 0, 0, 135, 136, 3, 26, 13, 0, 136, 137, 3, 20, 10, 6, 137, 147, 1, 0,
-		0, 0, 138, 139, 10, 4, 0, 0, 139, 140, 3, 28, 14, 0, 140, 141, 3, 20, 10,
-		5, 141, 147, 1, 0, 0, 0, 142, 143, 10, 3, 0, 0, 143, 144, 3, 30, 15, 0,
-		144, 145, 3, 20, 10, 4, 145, 147, 1, 0, 0, 0, 146, 126, 1, 0, 0, 0, 146,
-		130, 1, 0, 0, 0, 146, 134, 1, 0, 0, 0, 146, 138, 1, 0, 0, 0, 146, 142,
-		1, 0, 0, 0, 147, 150, 1, 0, 0, 0, 148, 146, 1, 0, 0, 0, 148, 149, 1, 0,
-		0, 0, 149, 21, 1, 0, 0, 0, 150, 148, 1, 0, 0, 0, 151, 152, 7, 2, 0, 0,
-		152, 23, 1, 0, 0, 0, 153, 154, 7, 3, 0, 0, 154, 25, 1, 0, 0, 0, 155, 156,
-		7, 4, 0, 0, 156, 27, 1, 0, 0, 0, 157, 158, 5, 18, 0, 0, 158, 29, 1, 0,
-		0, 0, 159, 160, 5, 19, 0, 0, 160, 31, 1, 0, 0, 0, 161, 162, 6, 16, -1,
-		0, 162, 168, 3, 34, 17, 0, 163, 168, 3, 36, 18, 0, 164, 168, 3, 42, 21,
-		0, 165, 166, 5, 23, 0, 0, 166, 168, 3, 32, 16, 1, 167, 161, 1, 0, 0, 0,
-		167, 163, 1, 0, 0, 0, 167, 164, 1, 0, 0, 0, 167, 165, 1, 0, 0, 0, 168,
-		177, 1, 0, 0, 0, 169, 170, 10, 4, 0, 0, 170, 176, 3, 44, 22, 0, 171, 172,
-		10, 3, 0, 0, 172, 176, 3, 40, 20, 0, 173, 174, 10, 2, 0, 0, 174, 176, 3,
-		38, 19, 0, 175, 169, 1, 0, 0, 0, 175, 171, 1, 0, 0, 0, 175, 173, 1, 0,
-		0, 0, 176, 179, 1, 0, 0, 0, 177, 175, 1, 0, 0, 0, 177, 178, 1, 0, 0, 0,
-		178, 33, 1, 0, 0, 0, 179, 177, 1, 0, 0, 0, 180, 186, 3, 62, 31, 0, 181,
-		186, 3, 54, 27, 0, 182, 186, 3, 48, 24, 0, 183, 186, 3, 64, 32, 0, 184,
-		186, 5, 22, 0, 0, 185, 180, 1, 0, 0, 0, 185, 181, 1, 0, 0, 0, 185, 182,
-		1, 0, 0, 0, 185, 183, 1, 0, 0, 0, 185, 184, 1, 0, 0, 0, 186, 35, 1, 0,
-		0, 0, 187, 188, 6, 18, -1, 0, 188, 189, 5, 38, 0, 0, 189, 196, 1, 0, 0,
-		0, 190, 191, 10, 3, 0, 0, 191, 195, 3, 40, 20, 0, 192, 193, 10, 2, 0, 0,
-		193, 195, 3, 38, 19, 0, 194, 190, 1, 0, 0, 0, 194, 192, 1, 0, 0, 0, 195,
-		198, 1, 0, 0, 0, 196, 194, 1, 0, 0, 0, 196, 197, 1, 0, 0, 0, 197, 37, 1,
-		0, 0, 0, 198, 196, 1, 0, 0, 0, 199, 200, 5, 13, 0, 0, 200, 201, 3, 20,
-		10, 0, 201, 202, 5, 14, 0, 0, 202, 39, 1, 0, 0, 0, 203, 204, 5, 7, 0, 0,
-		204, 205, 5, 38, 0, 0, 205, 41, 1, 0, 0, 0, 206, 207, 5, 38, 0, 0, 207,
-		209, 5, 11, 0, 0, 208, 210, 3, 46, 23, 0, 209, 208, 1, 0, 0, 0, 209, 210,
-		1, 0, 0, 0, 210, 211, 1, 0, 0, 0, 211, 212, 5, 12, 0, 0, 212, 43, 1, 0,
-		0, 0, 213, 214, 5, 7, 0, 0, 214, 215, 3, 42, 21, 0, 215, 45, 1, 0, 0, 0,
-		216, 221, 3, 20, 10, 0, 217, 218, 5, 1, 0, 0, 218, 220, 3, 20, 10, 0, 219,
-		217, 1, 0, 0, 0, 220, 223, 1, 0, 0, 0, 221, 219, 1, 0, 0, 0, 221, 222,
-		1, 0, 0, 0, 222, 47, 1, 0, 0, 0, 223, 221, 1, 0, 0, 0, 224, 227, 3, 50,
-		25, 0, 225, 227, 3, 52, 26, 0, 226, 224, 1, 0, 0, 0, 226, 225, 1, 0, 0,
-		0, 227, 49, 1, 0, 0, 0, 228, 230, 5, 3, 0, 0, 229, 228, 1, 0, 0, 0, 229,
-		230, 1, 0, 0, 0, 230, 231, 1, 0, 0, 0, 231, 232, 5, 41, 0, 0, 232, 51,
-		1, 0, 0, 0, 233, 235, 5, 3, 0, 0, 234, 233, 1, 0, 0, 0, 234, 235, 1, 0,
-		0, 0, 235, 236, 1, 0, 0, 0, 236, 237, 5, 43, 0, 0, 237, 53, 1, 0, 0, 0,
-		238, 242, 3, 56, 28, 0, 239, 242, 3, 58, 29, 0, 240, 242, 3, 60, 30, 0,
-		241, 238, 1, 0, 0, 0, 241, 239, 1, 0, 0, 0, 241, 240, 1, 0, 0, 0, 242,
-		55, 1, 0, 0, 0, 243, 245, 5, 3, 0, 0, 244, 243, 1, 0, 0, 0, 244, 245, 1,
-		0, 0, 0, 245, 246, 1, 0, 0, 0, 246, 247, 5, 45, 0, 0, 247, 57, 1, 0, 0,
-		0, 248, 250, 5, 3, 0, 0, 249, 248, 1, 0, 0, 0, 249, 250, 1, 0, 0, 0, 250,
-		251, 1, 0, 0, 0, 251, 252, 5, 46, 0, 0, 252, 59, 1, 0, 0, 0, 253, 255,
-		5, 3, 0, 0, 254, 253, 1, 0, 0, 0, 254, 255, 1, 0, 0, 0, 255, 256, 1, 0,
-		0, 0, 256, 257, 5, 47, 0, 0, 257, 61, 1, 0, 0, 0, 258, 259, 7, 0, 0, 0,
-		259, 63, 1, 0, 0, 0, 260, 261, 7, 5, 0, 0, 261, 65, 1, 0, 0, 0, 24, 69,
-		77, 80, 105, 109, 117, 124, 146, 148, 167, 175, 177, 185, 194, 196, 209,
-		221, 226, 229, 234, 241, 244, 249, 254,
+		31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36, 7, 36,
+		2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7, 41, 2,
+		42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 2, 46, 7, 46, 2, 47,
+		7, 47, 2, 48, 7, 48, 1, 0, 5, 0, 100, 8, 0, 10, 0, 12, 0, 103, 9, 0, 1,
+		0, 1, 0, 1, 1, 5, 1, 108, 8, 1, 10, 1, 12, 1, 111, 9, 1, 1, 1, 1, 1, 1,
+		1, 3, 1, 116, 8, 1, 1, 1, 3, 1, 119, 8, 1, 1, 1, 3, 1, 122, 8, 1, 1, 1,
+		5, 1, 125, 8, 1, 10, 1, 12, 1, 128, 9, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 144, 8, 3,
+		10, 3, 12, 3, 147, 9, 3, 3, 3, 149, 8, 3, 1, 3, 3, 3, 152, 8, 3, 1, 4,
+		1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 163, 8, 5, 1, 6,
+		1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1,
+		10, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14,
+		1, 14, 5, 14, 189, 8, 14, 10, 14, 12, 14, 192, 9, 14, 1, 14, 1, 14, 1,
+		15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16, 4, 16, 203, 8, 16, 11, 16,
+		12, 16, 204, 1, 17, 1, 17, 3, 17, 209, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18,
+		3, 18, 215, 8, 18, 1, 19, 1, 19, 1, 19, 3, 19, 220, 8, 19, 1, 20, 1, 20,
+		1, 20, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 5, 21, 233,
+		8, 21, 10, 21, 12, 21, 236, 9, 21, 1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 3,
+		22, 243, 8, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 24,
+		1, 24, 1, 25, 1, 25, 3, 25, 256, 8, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1,
+		25, 3, 25, 263, 8, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25,
+		1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1,
+		25, 1, 25, 1, 25, 5, 25, 285, 8, 25, 10, 25, 12, 25, 288, 9, 25, 1, 26,
+		1, 26, 1, 27, 1, 27, 1, 28, 1, 28, 1, 29, 1, 29, 1, 30, 1, 30, 1, 31, 1,
+		31, 1, 31, 1, 31, 1, 31, 1, 31, 1, 31, 3, 31, 307, 8, 31, 1, 31, 1, 31,
+		1, 31, 1, 31, 1, 31, 1, 31, 5, 31, 315, 8, 31, 10, 31, 12, 31, 318, 9,
+		31, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 3, 32, 327, 8, 32,
+		1, 32, 1, 32, 3, 32, 331, 8, 32, 1, 32, 1, 32, 1, 33, 1, 33, 1, 33, 1,
+		33, 1, 33, 3, 33, 340, 8, 33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34,
+		1, 34, 5, 34, 349, 8, 34, 10, 34, 12, 34, 352, 9, 34, 1, 35, 1, 35, 1,
+		35, 1, 35, 1, 36, 1, 36, 1, 36, 1, 37, 1, 37, 1, 37, 3, 37, 364, 8, 37,
+		1, 37, 1, 37, 1, 38, 1, 38, 1, 38, 1, 39, 1, 39, 1, 39, 5, 39, 374, 8,
+		39, 10, 39, 12, 39, 377, 9, 39, 1, 40, 1, 40, 3, 40, 381, 8, 40, 1, 41,
+		3, 41, 384, 8, 41, 1, 41, 1, 41, 1, 42, 3, 42, 389, 8, 42, 1, 42, 1, 42,
+		1, 43, 1, 43, 1, 43, 3, 43, 396, 8, 43, 1, 44, 3, 44, 399, 8, 44, 1, 44,
+		1, 44, 1, 45, 3, 45, 404, 8, 45, 1, 45, 1, 45, 1, 46, 3, 46, 409, 8, 46,
+		1, 46, 1, 46, 1, 47, 1, 47, 1, 48, 1, 48, 1, 48, 0, 3, 50, 62, 68, 49,
+		0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36,
+		38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68, 70, 72,
+		74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 94, 96, 0, 6, 1, 0, 48, 49, 1,
+		0, 35, 39, 1, 0, 4, 6, 2, 0, 2, 3, 45, 46, 2, 0, 34, 34, 40, 44, 1, 0,
+		22, 23, 423, 0, 101, 1, 0, 0, 0, 2, 109, 1, 0, 0, 0, 4, 134, 1, 0, 0, 0,
+		6, 137, 1, 0, 0, 0, 8, 153, 1, 0, 0, 0, 10, 162, 1, 0, 0, 0, 12, 164, 1,
+		0, 0, 0, 14, 167, 1, 0, 0, 0, 16, 169, 1, 0, 0, 0, 18, 171, 1, 0, 0, 0,
+		20, 174, 1, 0, 0, 0, 22, 177, 1, 0, 0, 0, 24, 180, 1, 0, 0, 0, 26, 182,
+		1, 0, 0, 0, 28, 184, 1, 0, 0, 0, 30, 195, 1, 0, 0, 0, 32, 202, 1, 0, 0,
+		0, 34, 208, 1, 0, 0, 0, 36, 210, 1, 0, 0, 0, 38, 216, 1, 0, 0, 0, 40, 221,
+		1, 0, 0, 0, 42, 227, 1, 0, 0, 0, 44, 242, 1, 0, 0, 0, 46, 244, 1, 0, 0,
+		0, 48, 248, 1, 0, 0, 0, 50, 262, 1, 0, 0, 0, 52, 289, 1, 0, 0, 0, 54, 291,
+		1, 0, 0, 0, 56, 293, 1, 0, 0, 0, 58, 295, 1, 0, 0, 0, 60, 297, 1, 0, 0,
+		0, 62, 306, 1, 0, 0, 0, 64, 319, 1, 0, 0, 0, 66, 339, 1, 0, 0, 0, 68, 341,
+		1, 0, 0, 0, 70, 353, 1, 0, 0, 0, 72, 357, 1, 0, 0, 0, 74, 360, 1, 0, 0,
+		0, 76, 367, 1, 0, 0, 0, 78, 370, 1, 0, 0, 0, 80, 380, 1, 0, 0, 0, 82, 383,
+		1, 0, 0, 0, 84, 388, 1, 0, 0, 0, 86, 395, 1, 0, 0, 0, 88, 398, 1, 0, 0,
+		0, 90, 403, 1, 0, 0, 0, 92, 408, 1, 0, 0, 0, 94, 412, 1, 0, 0, 0, 96, 414,
+		1, 0, 0, 0, 98, 100, 3, 2, 1, 0, 99, 98, 1, 0, 0, 0, 100, 103, 1, 0, 0,
+		0, 101, 99, 1, 0, 0, 0, 101, 102, 1, 0, 0, 0, 102, 104, 1, 0, 0, 0, 103,
+		101, 1, 0, 0, 0, 104, 105, 5, 0, 0, 1, 105, 1, 1, 0, 0, 0, 106, 108, 3,
+		6, 3, 0, 107, 106, 1, 0, 0, 0, 108, 111, 1, 0, 0, 0, 109, 107, 1, 0, 0,
+		0, 109, 110, 1, 0, 0, 0, 110, 112, 1, 0, 0, 0, 111, 109, 1, 0, 0, 0, 112,
+		113, 5, 17, 0, 0, 113, 115, 3, 24, 12, 0, 114, 116, 3, 4, 2, 0, 115, 114,
+		1, 0, 0, 0, 115, 116, 1, 0, 0, 0, 116, 118, 1, 0, 0, 0, 117, 119, 3, 26,
+		13, 0, 118, 117, 1, 0, 0, 0, 118, 119, 1, 0, 0, 0, 119, 121, 1, 0, 0, 0,
+		120, 122, 3, 8, 4, 0, 121, 120, 1, 0, 0, 0, 121, 122, 1, 0, 0, 0, 122,
+		126, 1, 0, 0, 0, 123, 125, 3, 10, 5, 0, 124, 123, 1, 0, 0, 0, 125, 128,
+		1, 0, 0, 0, 126, 124, 1, 0, 0, 0, 126, 127, 1, 0, 0, 0, 127, 129, 1, 0,
+		0, 0, 128, 126, 1, 0, 0, 0, 129, 130, 5, 11, 0, 0, 130, 131, 3, 28, 14,
+		0, 131, 132, 3, 30, 15, 0, 132, 133, 5, 12, 0, 0, 133, 3, 1, 0, 0, 0, 134,
+		135, 5, 32, 0, 0, 135, 136, 5, 47, 0, 0, 136, 5, 1, 0, 0, 0, 137, 138,
+		5, 10, 0, 0, 138, 151, 5, 47, 0, 0, 139, 148, 5, 13, 0, 0, 140, 145, 3,
+		94, 47, 0, 141, 142, 5, 1, 0, 0, 142, 144, 3, 94, 47, 0, 143, 141, 1, 0,
+		0, 0, 144, 147, 1, 0, 0, 0, 145, 143, 1, 0, 0, 0, 145, 146, 1, 0, 0, 0,
+		146, 149, 1, 0, 0, 0, 147, 145, 1, 0, 0, 0, 148, 140, 1, 0, 0, 0, 148,
+		149, 1, 0, 0, 0, 149, 150, 1, 0, 0, 0, 150, 152, 5, 14, 0, 0, 151, 139,
+		1, 0, 0, 0, 151, 152, 1, 0, 0, 0, 152, 7, 1, 0, 0, 0, 153, 154, 5, 26,
+		0, 0, 154, 155, 3, 86, 43, 0, 155, 9, 1, 0, 0, 0, 156, 163, 3, 12, 6, 0,
+		157, 163, 3, 14, 7, 0, 158, 163, 3, 16, 8, 0, 159, 163, 3, 18, 9, 0, 160,
+		163, 3, 20, 10, 0, 161, 163, 3, 22, 11, 0, 162, 156, 1, 0, 0, 0, 162, 157,
+		1, 0, 0, 0, 162, 158, 1, 0, 0, 0, 162, 159, 1, 0, 0, 0, 162, 160, 1, 0,
+		0, 0, 162, 161, 1, 0, 0, 0, 163, 11, 1, 0, 0, 0, 164, 165, 5, 27, 0, 0,
+		165, 166, 3, 94, 47, 0, 166, 13, 1, 0, 0, 0, 167, 168, 5, 28, 0, 0, 168,
+		15, 1, 0, 0, 0, 169, 170, 5, 29, 0, 0, 170, 17, 1, 0, 0, 0, 171, 172, 5,
+		30, 0, 0, 172, 173, 3, 94, 47, 0, 173, 19, 1, 0, 0, 0, 174, 175, 5, 31,
+		0, 0, 175, 176, 3, 94, 47, 0, 176, 21, 1, 0, 0, 0, 177, 178, 5, 33, 0,
+		0, 178, 179, 3, 50, 25, 0, 179, 23, 1, 0, 0, 0, 180, 181, 5, 47, 0, 0,
+		181, 25, 1, 0, 0, 0, 182, 183, 7, 0, 0, 0, 183, 27, 1, 0, 0, 0, 184, 190,
+		5, 18, 0, 0, 185, 186, 3, 48, 24, 0, 186, 187, 5, 8, 0, 0, 187, 189, 1,
+		0, 0, 0, 188, 185, 1, 0, 0, 0, 189, 192, 1, 0, 0, 0, 190, 188, 1, 0, 0,
+		0, 190, 191, 1, 0, 0, 0, 191, 193, 1, 0, 0, 0, 192, 190, 1, 0, 0, 0, 193,
+		194, 3, 50, 25, 0, 194, 29, 1, 0, 0, 0, 195, 196, 5, 19, 0, 0, 196, 197,
+		3, 32, 16, 0, 197, 31, 1, 0, 0, 0, 198, 199, 3, 44, 22, 0, 199, 200, 5,
+		8, 0, 0, 200, 203, 1, 0, 0, 0, 201, 203, 3, 34, 17, 0, 202, 198, 1, 0,
+		0, 0, 202, 201, 1, 0, 0, 0, 203, 204, 1, 0, 0, 0, 204, 202, 1, 0, 0, 0,
+		204, 205, 1, 0, 0, 0, 205, 33, 1, 0, 0, 0, 206, 209, 3, 36, 18, 0, 207,
+		209, 3, 40, 20, 0, 208, 206, 1, 0, 0, 0, 208, 207, 1, 0, 0, 0, 209, 35,
+		1, 0, 0, 0, 210, 211, 5, 47, 0, 0, 211, 212, 3, 50, 25, 0, 212, 214, 3,
+		42, 21, 0, 213, 215, 3, 38, 19, 0, 214, 213, 1, 0, 0, 0, 214, 215, 1, 0,
+		0, 0, 215, 37, 1, 0, 0, 0, 216, 219, 5, 47, 0, 0, 217, 220, 3, 36, 18,
+		0, 218, 220, 3, 42, 21, 0, 219, 217, 1, 0, 0, 0, 219, 218, 1, 0, 0, 0,
+		220, 39, 1, 0, 0, 0, 221, 222, 5, 47, 0, 0, 222, 223, 5, 47, 0, 0, 223,
+		224, 5, 47, 0, 0, 224, 225, 3, 62, 31, 0, 225, 226, 3, 42, 21, 0, 226,
+		41, 1, 0, 0, 0, 227, 234, 5, 11, 0, 0, 228, 229, 3, 44, 22, 0, 229, 230,
+		5, 8, 0, 0, 230, 233, 1, 0, 0, 0, 231, 233, 3, 34, 17, 0, 232, 228, 1,
+		0, 0, 0, 232, 231, 1, 0, 0, 0, 233, 236, 1, 0, 0, 0, 234, 232, 1, 0, 0,
+		0, 234, 235, 1, 0, 0, 0, 235, 237, 1, 0, 0, 0, 236, 234, 1, 0, 0, 0, 237,
+		238, 5, 12, 0, 0, 238, 43, 1, 0, 0, 0, 239, 243, 3, 46, 23, 0, 240, 243,
+		3, 48, 24, 0, 241, 243, 3, 62, 31, 0, 242, 239, 1, 0, 0, 0, 242, 240, 1,
+		0, 0, 0, 242, 241, 1, 0, 0, 0, 243, 45, 1, 0, 0, 0, 244, 245, 3, 68, 34,
+		0, 245, 246, 7, 1, 0, 0, 246, 247, 3, 50, 25, 0, 247, 47, 1, 0, 0, 0, 248,
+		249, 5, 47, 0, 0, 249, 250, 5, 47, 0, 0, 250, 251, 5, 35, 0, 0, 251, 252,
+		3, 50, 25, 0, 252, 49, 1, 0, 0, 0, 253, 255, 6, 25, -1, 0, 254, 256, 5,
+		25, 0, 0, 255, 254, 1, 0, 0, 0, 255, 256, 1, 0, 0, 0, 256, 257, 1, 0, 0,
+		0, 257, 258, 5, 13, 0, 0, 258, 259, 3, 50, 25, 0, 259, 260, 5, 14, 0, 0,
+		260, 263, 1, 0, 0, 0, 261, 263, 3, 62, 31, 0, 262, 253, 1, 0, 0, 0, 262,
+		261, 1, 0, 0, 0, 263, 286, 1, 0, 0, 0, 264, 265, 10, 7, 0, 0, 265, 266,
+		3, 52, 26, 0, 266, 267, 3, 50, 25, 8, 267, 285, 1, 0, 0, 0, 268, 269, 10,
+		6, 0, 0, 269, 270, 3, 54, 27, 0, 270, 271, 3, 50, 25, 7, 271, 285, 1, 0,
+		0, 0, 272, 273, 10, 5, 0, 0, 273, 274, 3, 56, 28, 0, 274, 275, 3, 50, 25,
+		6, 275, 285, 1, 0, 0, 0, 276, 277, 10, 4, 0, 0, 277, 278, 3, 58, 29, 0,
+		278, 279, 3, 50, 25, 5, 279, 285, 1, 0, 0, 0, 280, 281, 10, 3, 0, 0, 281,
+		282, 3, 60, 30, 0, 282, 283, 3, 50, 25, 4, 283, 285, 1, 0, 0, 0, 284, 264,
+		1, 0, 0, 0, 284, 268, 1, 0, 0, 0, 284, 272, 1, 0, 0, 0, 284, 276, 1, 0,
+		0, 0, 284, 280, 1, 0, 0, 0, 285, 288, 1, 0, 0, 0, 286, 284, 1, 0, 0, 0,
+		286, 287, 1, 0, 0, 0, 287, 51, 1, 0, 0, 0, 288, 286, 1, 0, 0, 0, 289, 290,
+		7, 2, 0, 0, 290, 53, 1, 0, 0, 0, 291, 292, 7, 3, 0, 0, 292, 55, 1, 0, 0,
+		0, 293, 294, 7, 4, 0, 0, 294, 57, 1, 0, 0, 0, 295, 296, 5, 20, 0, 0, 296,
+		59, 1, 0, 0, 0, 297, 298, 5, 21, 0, 0, 298, 61, 1, 0, 0, 0, 299, 300, 6,
+		31, -1, 0, 300, 307, 3, 66, 33, 0, 301, 307, 3, 68, 34, 0, 302, 307, 3,
+		64, 32, 0, 303, 307, 3, 74, 37, 0, 304, 305, 5, 25, 0, 0, 305, 307, 3,
+		62, 31, 1, 306, 299, 1, 0, 0, 0, 306, 301, 1, 0, 0, 0, 306, 302, 1, 0,
+		0, 0, 306, 303, 1, 0, 0, 0, 306, 304, 1, 0, 0, 0, 307, 316, 1, 0, 0, 0,
+		308, 309, 10, 4, 0, 0, 309, 315, 3, 76, 38, 0, 310, 311, 10, 3, 0, 0, 311,
+		315, 3, 72, 36, 0, 312, 313, 10, 2, 0, 0, 313, 315, 3, 70, 35, 0, 314,
+		308, 1, 0, 0, 0, 314, 310, 1, 0, 0, 0, 314, 312, 1, 0, 0, 0, 315, 318,
+		1, 0, 0, 0, 316, 314, 1, 0, 0, 0, 316, 317, 1, 0, 0, 0, 317, 63, 1, 0,
+		0, 0, 318, 316, 1, 0, 0, 0, 319, 320, 5, 47, 0, 0, 320, 321, 5, 13, 0,
+		0, 321, 322, 5, 47, 0, 0, 322, 323, 5, 47, 0, 0, 323, 326, 3, 62, 31, 0,
+		324, 325, 5, 47, 0, 0, 325, 327, 3, 50, 25, 0, 326, 324, 1, 0, 0, 0, 326,
+		327, 1, 0, 0, 0, 327, 330, 1, 0, 0, 0, 328, 329, 5, 9, 0, 0, 329, 331,
+		3, 50, 25, 0, 330, 328, 1, 0, 0, 0, 330, 331, 1, 0, 0, 0, 331, 332, 1,
+		0, 0, 0, 332, 333, 5, 14, 0, 0, 333, 65, 1, 0, 0, 0, 334, 340, 3, 94, 47,
+		0, 335, 340, 3, 86, 43, 0, 336, 340, 3, 80, 40, 0, 337, 340, 3, 96, 48,
+		0, 338, 340, 5, 24, 0, 0, 339, 334, 1, 0, 0, 0, 339, 335, 1, 0, 0, 0, 339,
+		336, 1, 0, 0, 0, 339, 337, 1, 0, 0, 0, 339, 338, 1, 0, 0, 0, 340, 67, 1,
+		0, 0, 0, 341, 342, 6, 34, -1, 0, 342, 343, 5, 47, 0, 0, 343, 350, 1, 0,
+		0, 0, 344, 345, 10, 3, 0, 0, 345, 349, 3, 72, 36, 0, 346, 347, 10, 2, 0,
+		0, 347, 349, 3, 70, 35, 0, 348, 344, 1, 0, 0, 0, 348, 346, 1, 0, 0, 0,
+		349, 352, 1, 0, 0, 0, 350, 348, 1, 0, 0, 0, 350, 351, 1, 0, 0, 0, 351,
+		69, 1, 0, 0, 0, 352, 350, 1, 0, 0, 0, 353, 354, 5, 15, 0, 0, 354, 355,
+		3, 50, 25, 0, 355, 356, 5, 16, 0, 0, 356, 71, 1, 0, 0, 0, 357, 358, 5,
+		7, 0, 0, 358, 359, 5, 47, 0, 0, 359, 73, 1, 0, 0, 0, 360, 361, 5, 47, 0,
+		0, 361, 363, 5, 13, 0, 0, 362, 364, 3, 78, 39, 0, 363, 362, 1, 0, 0, 0,
+		363, 364, 1, 0, 0, 0, 364, 365, 1, 0, 0, 0, 365, 366, 5, 14, 0, 0, 366,
+		75, 1, 0, 0, 0, 367, 368, 5, 7, 0, 0, 368, 369, 3, 74, 37, 0, 369, 77,
+		1, 0, 0, 0, 370, 375, 3, 50, 25, 0, 371, 372, 5, 1, 0, 0, 372, 374, 3,
+		50, 25, 0, 373, 371, 1, 0, 0, 0, 374, 377, 1, 0, 0, 0, 375, 373, 1, 0,
+		0, 0, 375, 376, 1, 0, 0, 0, 376, 79, 1, 0, 0, 0, 377, 375, 1, 0, 0, 0,
+		378, 381, 3, 82, 41, 0, 379, 381, 3, 84, 42, 0, 380, 378, 1, 0, 0, 0, 380,
+		379, 1, 0, 0, 0, 381, 81, 1, 0, 0, 0, 382, 384, 5, 3, 0, 0, 383, 382, 1,
+		0, 0, 0, 383, 384, 1, 0, 0, 0, 384, 385, 1, 0, 0, 0, 385, 386, 5, 50, 0,
+		0, 386, 83, 1, 0, 0, 0, 387, 389, 5, 3, 0, 0, 388, 387, 1, 0, 0, 0, 388,
+		389, 1, 0, 0, 0, 389, 390, 1, 0, 0, 0, 390, 391, 5, 52, 0, 0, 391, 85,
+		1, 0, 0, 0, 392, 396, 3, 88, 44, 0, 393, 396, 3, 90, 45, 0, 394, 396, 3,
+		92, 46, 0, 395, 392, 1, 0, 0, 0, 395, 393, 1, 0, 0, 0, 395, 394, 1, 0,
+		0, 0, 396, 87, 1, 0, 0, 0, 397, 399, 5, 3, 0, 0, 398, 397, 1, 0, 0, 0,
+		398, 399, 1, 0, 0, 0, 399, 400, 1, 0, 0, 0, 400, 401, 5, 54, 0, 0, 401,
+		89, 1, 0, 0, 0, 402, 404, 5, 3, 0, 0, 403, 402, 1, 0, 0, 0, 403, 404, 1,
+		0, 0, 0, 404, 405, 1, 0, 0, 0, 405, 406, 5, 55, 0, 0, 406, 91, 1, 0, 0,
+		0, 407, 409, 5, 3, 0, 0, 408, 407, 1, 0, 0, 0, 408, 409, 1, 0, 0, 0, 409,
+		410, 1, 0, 0, 0, 410, 411, 5, 56, 0, 0, 411, 93, 1, 0, 0, 0, 412, 413,
+		7, 0, 0, 0, 413, 95, 1, 0, 0, 0, 414, 415, 7, 5, 0, 0, 415, 97, 1, 0, 0,
+		0, 40, 101, 109, 115, 118, 121, 126, 145, 148, 151, 162, 190, 202, 204,
+		208, 214, 219, 232, 234, 242, 255, 262, 284, 286, 306, 314, 316, 326, 330,
+		339, 348, 350, 363, 375, 380, 383, 388, 395, 398, 403, 408,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -217,85 +291,110 @@ const (
 	grulev3ParserMOD               = 6
 	grulev3ParserDOT               = 7
 	grulev3ParserSEMICOLON         = 8
-	grulev3ParserLR_BRACE          = 9
-	grulev3ParserRR_BRACE          = 10
-	grulev3ParserLR_BRACKET        = 11
-	grulev3ParserRR_BRACKET        = 12
-	grulev3ParserLS_BRACKET        = 13
-	grulev3ParserRS_BRACKET        = 14
-	grulev3ParserRULE              = 15
-	grulev3ParserWHEN              = 16
-	grulev3ParserTHEN              = 17
-	grulev3ParserAND               = 18
-	grulev3ParserOR                = 19
-	grulev3ParserTRUE              = 20
-	grulev3ParserFALSE             = 21
-	grulev3ParserNIL_LITERAL       = 22
-	grulev3ParserNEGATION          = 23
-	grulev3ParserSALIENCE          = 24
-	grulev3ParserEQUALS            = 25
-	grulev3ParserASSIGN            = 26
-	grulev3ParserPLUS_ASIGN        = 27
-	grulev3ParserMINUS_ASIGN       = 28
-	grulev3ParserDIV_ASIGN         = 29
-	grulev3ParserMUL_ASIGN         = 30
-	grulev3ParserGT                = 31
-	grulev3ParserLT                = 32
-	grulev3ParserGTE               = 33
-	grulev3ParserLTE               = 34
-	grulev3ParserNOTEQUALS         = 35
-	grulev3ParserBITAND            = 36
-	grulev3ParserBITOR             = 37
-	grulev3ParserSIMPLENAME        = 38
-	grulev3ParserDQUOTA_STRING     = 39
-	grulev3ParserSQUOTA_STRING     = 40
-	grulev3ParserDECIMAL_FLOAT_LIT = 41
-	grulev3ParserDECIMAL_EXPONENT  = 42
-	grulev3ParserHEX_FLOAT_LIT     = 43
-	grulev3ParserHEX_EXPONENT      = 44
-	grulev3ParserDEC_LIT           = 45
-	grulev3ParserHEX_LIT           = 46
-	grulev3ParserOCT_LIT           = 47
-	grulev3ParserSPACE             = 48
-	grulev3ParserCOMMENT           = 49
-	grulev3ParserLINE_COMMENT      = 50
+	grulev3ParserCOLON             = 9
+	grulev3ParserAT                = 10
+	grulev3ParserLR_BRACE          = 11
+	grulev3ParserRR_BRACE          = 12
+	grulev3ParserLR_BRACKET        = 13
+	grulev3ParserRR_BRACKET        = 14
+	grulev3ParserLS_BRACKET        = 15
+	grulev3ParserRS_BRACKET        = 16
+	grulev3ParserRULE              = 17
+	grulev3ParserWHEN              = 18
+	grulev3ParserTHEN              = 19
+	grulev3ParserAND               = 20
+	grulev3ParserOR                = 21
+	grulev3ParserTRUE              = 22
+	grulev3ParserFALSE             = 23
+	grulev3ParserNIL_LITERAL       = 24
+	grulev3ParserNEGATION          = 25
+	grulev3ParserSALIENCE          = 26
+	grulev3ParserAGENDA_GROUP      = 27
+	grulev3ParserNO_LOOP           = 28
+	grulev3ParserLOCK_ON_ACTIVE    = 29
+	grulev3ParserDATE_EFFECTIVE    = 30
+	grulev3ParserDATE_EXPIRES      = 31
+	grulev3ParserEXTENDS           = 32
+	grulev3ParserENABLED           = 33
+	grulev3ParserEQUALS            = 34
+	grulev3ParserASSIGN            = 35
+	grulev3ParserPLUS_ASIGN        = 36
+	grulev3ParserMINUS_ASIGN       = 37
+	grulev3ParserDIV_ASIGN         = 38
+	grulev3ParserMUL_ASIGN         = 39
+	grulev3ParserGT                = 40
+	grulev3ParserLT                = 41
+	grulev3ParserGTE               = 42
+	grulev3ParserLTE               = 43
+	grulev3ParserNOTEQUALS         = 44
+	grulev3ParserBITAND            = 45
+	grulev3ParserBITOR             = 46
+	grulev3ParserSIMPLENAME        = 47
+	grulev3ParserDQUOTA_STRING     = 48
+	grulev3ParserSQUOTA_STRING     = 49
+	grulev3ParserDECIMAL_FLOAT_LIT = 50
+	grulev3ParserDECIMAL_EXPONENT  = 51
+	grulev3ParserHEX_FLOAT_LIT     = 52
+	grulev3ParserHEX_EXPONENT      = 53
+	grulev3ParserDEC_LIT           = 54
+	grulev3ParserHEX_LIT           = 55
+	grulev3ParserOCT_LIT           = 56
+	grulev3ParserSPACE             = 57
+	grulev3ParserCOMMENT           = 58
+	grulev3ParserLINE_COMMENT      = 59
 )
 
 // grulev3Parser rules.
 const (
 	grulev3ParserRULE_grl                     = 0
 	grulev3ParserRULE_ruleEntry               = 1
-	grulev3ParserRULE_salience                = 2
-	grulev3ParserRULE_ruleName                = 3
-	grulev3ParserRULE_ruleDescription         = 4
-	grulev3ParserRULE_whenScope               = 5
-	grulev3ParserRULE_thenScope               = 6
-	grulev3ParserRULE_thenExpressionList      = 7
-	grulev3ParserRULE_thenExpression          = 8
-	grulev3ParserRULE_assignment              = 9
-	grulev3ParserRULE_expression              = 10
-	grulev3ParserRULE_mulDivOperators         = 11
-	grulev3ParserRULE_addMinusOperators       = 12
-	grulev3ParserRULE_comparisonOperator      = 13
-	grulev3ParserRULE_andLogicOperator        = 14
-	grulev3ParserRULE_orLogicOperator         = 15
-	grulev3ParserRULE_expressionAtom          = 16
-	grulev3ParserRULE_constant                = 17
-	grulev3ParserRULE_variable                = 18
-	grulev3ParserRULE_arrayMapSelector        = 19
-	grulev3ParserRULE_memberVariable          = 20
-	grulev3ParserRULE_functionCall            = 21
-	grulev3ParserRULE_methodCall              = 22
-	grulev3ParserRULE_argumentList            = 23
-	grulev3ParserRULE_floatLiteral            = 24
-	grulev3ParserRULE_decimalFloatLiteral     = 25
-	grulev3ParserRULE_hexadecimalFloatLiteral = 26
-	grulev3ParserRULE_integerLiteral          = 27
-	grulev3ParserRULE_decimalLiteral          = 28
-	grulev3ParserRULE_hexadecimalLiteral      = 29
-	grulev3ParserRULE_octalLiteral            = 30
-	grulev3ParserRULE_stringLiteral           = 31
-	grulev3ParserRULE_booleanLiteral          = 32
+	grulev3ParserRULE_ruleExtends             = 2
+	grulev3ParserRULE_ruleAnnotation          = 3
+	grulev3ParserRULE_salience                = 4
+	grulev3ParserRULE_ruleAttribute           = 5
+	grulev3ParserRULE_agendaGroup             = 6
+	grulev3ParserRULE_noLoop                  = 7
+	grulev3ParserRULE_lockOnActive            = 8
+	grulev3ParserRULE_dateEffective           = 9
+	grulev3ParserRULE_dateExpires             = 10
+	grulev3ParserRULE_ruleEnabled             = 11
+	grulev3ParserRULE_ruleName                = 12
+	grulev3ParserRULE_ruleDescription         = 13
+	grulev3ParserRULE_whenScope               = 14
+	grulev3ParserRULE_thenScope               = 15
+	grulev3ParserRULE_thenExpressionList      = 16
+	grulev3ParserRULE_thenStatement           = 17
+	grulev3ParserRULE_ifStatement             = 18
+	grulev3ParserRULE_elseStatement           = 19
+	grulev3ParserRULE_forStatement            = 20
+	grulev3ParserRULE_thenBlock               = 21
+	grulev3ParserRULE_thenExpression          = 22
+	grulev3ParserRULE_assignment              = 23
+	grulev3ParserRULE_letStatement            = 24
+	grulev3ParserRULE_expression              = 25
+	grulev3ParserRULE_mulDivOperators         = 26
+	grulev3ParserRULE_addMinusOperators       = 27
+	grulev3ParserRULE_comparisonOperator      = 28
+	grulev3ParserRULE_andLogicOperator        = 29
+	grulev3ParserRULE_orLogicOperator         = 30
+	grulev3ParserRULE_expressionAtom          = 31
+	grulev3ParserRULE_collectionExpression    = 32
+	grulev3ParserRULE_constant                = 33
+	grulev3ParserRULE_variable                = 34
+	grulev3ParserRULE_arrayMapSelector        = 35
+	grulev3ParserRULE_memberVariable          = 36
+	grulev3ParserRULE_functionCall            = 37
+	grulev3ParserRULE_methodCall              = 38
+	grulev3ParserRULE_argumentList            = 39
+	grulev3ParserRULE_floatLiteral            = 40
+	grulev3ParserRULE_decimalFloatLiteral     = 41
+	grulev3ParserRULE_hexadecimalFloatLiteral = 42
+	grulev3ParserRULE_integerLiteral          = 43
+	grulev3ParserRULE_decimalLiteral          = 44
+	grulev3ParserRULE_hexadecimalLiteral      = 45
+	grulev3ParserRULE_octalLiteral            = 46
+	grulev3ParserRULE_stringLiteral           = 47
+	grulev3ParserRULE_booleanLiteral          = 48
 )
 
 // IGrlContext is an interface to support dynamic dispatch.
@@ -427,20 +526,20 @@ func (p *grulev3Parser) Grl() (localctx IGrlContext) {
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(69)
+	p.SetState(101)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	for _la == grulev3ParserRULE {
+	for _la == grulev3ParserAT || _la == grulev3ParserRULE {
 		{
-			p.SetState(66)
+			p.SetState(98)
 			p.RuleEntry()
 		}
 
-		p.SetState(71)
+		p.SetState(103)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -448,7 +547,7 @@ func (p *grulev3Parser) Grl() (localctx IGrlContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(72)
+		p.SetState(104)
 		p.Match(grulev3ParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -483,8 +582,13 @@ type IRuleEntryContext interface {
 	WhenScope() IWhenScopeContext
 	ThenScope() IThenScopeContext
 	RR_BRACE() antlr.TerminalNode
+	AllRuleAnnotation() []IRuleAnnotationContext
+	RuleAnnotation(i int) IRuleAnnotationContext
+	RuleExtends() IRuleExtendsContext
 	RuleDescription() IRuleDescriptionContext
 	Salience() ISalienceContext
+	AllRuleAttribute() []IRuleAttributeContext
+	RuleAttribute(i int) IRuleAttributeContext
 
 	// IsRuleEntryContext differentiates from other interfaces.
 	IsRuleEntryContext()
@@ -582,6 +686,63 @@ func (s *RuleEntryContext) RR_BRACE() antlr.TerminalNode {
 	return s.GetToken(grulev3ParserRR_BRACE, 0)
 }
 
+func (s *RuleEntryContext) AllRuleAnnotation() []IRuleAnnotationContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IRuleAnnotationContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IRuleAnnotationContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IRuleAnnotationContext); ok {
+			tst[i] = t.(IRuleAnnotationContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *RuleEntryContext) RuleAnnotation(i int) IRuleAnnotationContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IRuleAnnotationContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRuleAnnotationContext)
+}
+
+func (s *RuleEntryContext) RuleExtends() IRuleExtendsContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IRuleExtendsContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRuleExtendsContext)
+}
+
 func (s *RuleEntryContext) RuleDescription() IRuleDescriptionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -614,6 +775,47 @@ func (s *RuleEntryContext) Salience() ISalienceContext {
 	return t.(ISalienceContext)
 }
 
+func (s *RuleEntryContext) AllRuleAttribute() []IRuleAttributeContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IRuleAttributeContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IRuleAttributeContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IRuleAttributeContext); ok {
+			tst[i] = t.(IRuleAttributeContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *RuleEntryContext) RuleAttribute(i int) IRuleAttributeContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IRuleAttributeContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRuleAttributeContext)
+}
+
 func (s *RuleEntryContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -650,8 +852,28 @@ func (p *grulev3Parser) RuleEntry() (localctx IRuleEntryContext) {
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
+	p.SetState(109)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == grulev3ParserAT {
+		{
+			p.SetState(106)
+			p.RuleAnnotation()
+		}
+
+		p.SetState(111)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
 	{
-		p.SetState(74)
+		p.SetState(112)
 		p.Match(grulev3ParserRULE)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -659,10 +881,24 @@ func (p *grulev3Parser) RuleEntry() (localctx IRuleEntryContext) {
 		}
 	}
 	{
-		p.SetState(75)
+		p.SetState(113)
 		p.RuleName()
 	}
-	p.SetState(77)
+	p.SetState(115)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == grulev3ParserEXTENDS {
+		{
+			p.SetState(114)
+			p.RuleExtends()
+		}
+
+	}
+	p.SetState(118)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -671,12 +907,12 @@ func (p *grulev3Parser) RuleEntry() (localctx IRuleEntryContext) {
 
 	if _la == grulev3ParserDQUOTA_STRING || _la == grulev3ParserSQUOTA_STRING {
 		{
-			p.SetState(76)
+			p.SetState(117)
 			p.RuleDescription()
 		}
 
 	}
-	p.SetState(80)
+	p.SetState(121)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -685,13 +921,33 @@ func (p *grulev3Parser) RuleEntry() (localctx IRuleEntryContext) {
 
 	if _la == grulev3ParserSALIENCE {
 		{
-			p.SetState(79)
+			p.SetState(120)
 			p.Salience()
 		}
 
 	}
+	p.SetState(126)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&12750684160) != 0 {
+		{
+			p.SetState(123)
+			p.RuleAttribute()
+		}
+
+		p.SetState(128)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
 	{
-		p.SetState(82)
+		p.SetState(129)
 		p.Match(grulev3ParserLR_BRACE)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -699,15 +955,15 @@ func (p *grulev3Parser) RuleEntry() (localctx IRuleEntryContext) {
 		}
 	}
 	{
-		p.SetState(83)
+		p.SetState(130)
 		p.WhenScope()
 	}
 	{
-		p.SetState(84)
+		p.SetState(131)
 		p.ThenScope()
 	}
 	{
-		p.SetState(85)
+		p.SetState(132)
 		p.Match(grulev3ParserRR_BRACE)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -728,118 +984,110 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// ISalienceContext is an interface to support dynamic dispatch.
-type ISalienceContext interface {
+// IRuleExtendsContext is an interface to support dynamic dispatch.
+type IRuleExtendsContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	SALIENCE() antlr.TerminalNode
-	IntegerLiteral() IIntegerLiteralContext
+	EXTENDS() antlr.TerminalNode
+	SIMPLENAME() antlr.TerminalNode
 
-	// IsSalienceContext differentiates from other interfaces.
-	IsSalienceContext()
+	// IsRuleExtendsContext differentiates from other interfaces.
+	IsRuleExtendsContext()
 }
 
-type SalienceContext struct {
+type RuleExtendsContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptySalienceContext() *SalienceContext {
-	var p = new(SalienceContext)
+func NewEmptyRuleExtendsContext() *RuleExtendsContext {
+	var p = new(RuleExtendsContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_salience
+	p.RuleIndex = grulev3ParserRULE_ruleExtends
 	return p
 }
 
-func InitEmptySalienceContext(p *SalienceContext) {
+func InitEmptyRuleExtendsContext(p *RuleExtendsContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_salience
+	p.RuleIndex = grulev3ParserRULE_ruleExtends
 }
 
-func (*SalienceContext) IsSalienceContext() {}
+func (*RuleExtendsContext) IsRuleExtendsContext() {}
 
-func NewSalienceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SalienceContext {
-	var p = new(SalienceContext)
+func NewRuleExtendsContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleExtendsContext {
+	var p = new(RuleExtendsContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = grulev3ParserRULE_salience
+	p.RuleIndex = grulev3ParserRULE_ruleExtends
 
 	return p
 }
 
-func (s *SalienceContext) GetParser() antlr.Parser { return s.parser }
+func (s *RuleExtendsContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SalienceContext) SALIENCE() antlr.TerminalNode {
-	return s.GetToken(grulev3ParserSALIENCE, 0)
+func (s *RuleExtendsContext) EXTENDS() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserEXTENDS, 0)
 }
 
-func (s *SalienceContext) IntegerLiteral() IIntegerLiteralContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IIntegerLiteralContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IIntegerLiteralContext)
+func (s *RuleExtendsContext) SIMPLENAME() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, 0)
 }
 
-func (s *SalienceContext) GetRuleContext() antlr.RuleContext {
+func (s *RuleExtendsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *SalienceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *RuleExtendsContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SalienceContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *RuleExtendsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.EnterSalience(s)
+		listenerT.EnterRuleExtends(s)
 	}
 }
 
-func (s *SalienceContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *RuleExtendsContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.ExitSalience(s)
+		listenerT.ExitRuleExtends(s)
 	}
 }
 
-func (s *SalienceContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *RuleExtendsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case grulev3Visitor:
-		return t.VisitSalience(s)
+		return t.VisitRuleExtends(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *grulev3Parser) Salience() (localctx ISalienceContext) {
-	localctx = NewSalienceContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, grulev3ParserRULE_salience)
+func (p *grulev3Parser) RuleExtends() (localctx IRuleExtendsContext) {
+	localctx = NewRuleExtendsContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, grulev3ParserRULE_ruleExtends)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(87)
-		p.Match(grulev3ParserSALIENCE)
+		p.SetState(134)
+		p.Match(grulev3ParserEXTENDS)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(88)
-		p.IntegerLiteral()
+		p.SetState(135)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
 	}
 
 errorExit:
@@ -855,102 +1103,2285 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IRuleNameContext is an interface to support dynamic dispatch.
-type IRuleNameContext interface {
+// IRuleAnnotationContext is an interface to support dynamic dispatch.
+type IRuleAnnotationContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
+	AT() antlr.TerminalNode
 	SIMPLENAME() antlr.TerminalNode
+	LR_BRACKET() antlr.TerminalNode
+	RR_BRACKET() antlr.TerminalNode
+	AllStringLiteral() []IStringLiteralContext
+	StringLiteral(i int) IStringLiteralContext
 
-	// IsRuleNameContext differentiates from other interfaces.
-	IsRuleNameContext()
+	// IsRuleAnnotationContext differentiates from other interfaces.
+	IsRuleAnnotationContext()
 }
 
-type RuleNameContext struct {
+type RuleAnnotationContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyRuleNameContext() *RuleNameContext {
-	var p = new(RuleNameContext)
+func NewEmptyRuleAnnotationContext() *RuleAnnotationContext {
+	var p = new(RuleAnnotationContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_ruleName
+	p.RuleIndex = grulev3ParserRULE_ruleAnnotation
 	return p
 }
 
-func InitEmptyRuleNameContext(p *RuleNameContext) {
+func InitEmptyRuleAnnotationContext(p *RuleAnnotationContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_ruleName
+	p.RuleIndex = grulev3ParserRULE_ruleAnnotation
 }
 
-func (*RuleNameContext) IsRuleNameContext() {}
+func (*RuleAnnotationContext) IsRuleAnnotationContext() {}
 
-func NewRuleNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleNameContext {
-	var p = new(RuleNameContext)
+func NewRuleAnnotationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleAnnotationContext {
+	var p = new(RuleAnnotationContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = grulev3ParserRULE_ruleName
+	p.RuleIndex = grulev3ParserRULE_ruleAnnotation
 
 	return p
 }
 
-func (s *RuleNameContext) GetParser() antlr.Parser { return s.parser }
+func (s *RuleAnnotationContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *RuleNameContext) SIMPLENAME() antlr.TerminalNode {
+func (s *RuleAnnotationContext) AT() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserAT, 0)
+}
+
+func (s *RuleAnnotationContext) SIMPLENAME() antlr.TerminalNode {
 	return s.GetToken(grulev3ParserSIMPLENAME, 0)
 }
 
-func (s *RuleNameContext) GetRuleContext() antlr.RuleContext {
+func (s *RuleAnnotationContext) LR_BRACKET() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserLR_BRACKET, 0)
+}
+
+func (s *RuleAnnotationContext) RR_BRACKET() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserRR_BRACKET, 0)
+}
+
+func (s *RuleAnnotationContext) AllStringLiteral() []IStringLiteralContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IStringLiteralContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IStringLiteralContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IStringLiteralContext); ok {
+			tst[i] = t.(IStringLiteralContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *RuleAnnotationContext) StringLiteral(i int) IStringLiteralContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStringLiteralContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *RuleAnnotationContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *RuleNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *RuleAnnotationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *RuleNameContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *RuleAnnotationContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.EnterRuleName(s)
+		listenerT.EnterRuleAnnotation(s)
 	}
 }
 
-func (s *RuleNameContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *RuleAnnotationContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.ExitRuleName(s)
+		listenerT.ExitRuleAnnotation(s)
 	}
 }
 
-func (s *RuleNameContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *RuleAnnotationContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case grulev3Visitor:
-		return t.VisitRuleName(s)
+		return t.VisitRuleAnnotation(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *grulev3Parser) RuleName() (localctx IRuleNameContext) {
-	localctx = NewRuleNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, grulev3ParserRULE_ruleName)
+func (p *grulev3Parser) RuleAnnotation() (localctx IRuleAnnotationContext) {
+	localctx = NewRuleAnnotationContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, grulev3ParserRULE_ruleAnnotation)
+	var _la int
+
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(90)
-		p.Match(grulev3ParserSIMPLENAME)
+		p.SetState(137)
+		p.Match(grulev3ParserAT)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-
-errorExit:
-	if p.HasError() {
-		v := p.GetError()
+	{
+		p.SetState(138)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(151)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == grulev3ParserLR_BRACKET {
+		{
+			p.SetState(139)
+			p.Match(grulev3ParserLR_BRACKET)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(148)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == grulev3ParserDQUOTA_STRING || _la == grulev3ParserSQUOTA_STRING {
+			{
+				p.SetState(140)
+				p.StringLiteral()
+			}
+			p.SetState(145)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+
+			for _la == grulev3ParserT__0 {
+				{
+					p.SetState(141)
+					p.Match(grulev3ParserT__0)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(142)
+					p.StringLiteral()
+				}
+
+				p.SetState(147)
+				p.GetErrorHandler().Sync(p)
+				if p.HasError() {
+					goto errorExit
+				}
+				_la = p.GetTokenStream().LA(1)
+			}
+
+		}
+		{
+			p.SetState(150)
+			p.Match(grulev3ParserRR_BRACKET)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// ISalienceContext is an interface to support dynamic dispatch.
+type ISalienceContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	SALIENCE() antlr.TerminalNode
+	IntegerLiteral() IIntegerLiteralContext
+
+	// IsSalienceContext differentiates from other interfaces.
+	IsSalienceContext()
+}
+
+type SalienceContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptySalienceContext() *SalienceContext {
+	var p = new(SalienceContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_salience
+	return p
+}
+
+func InitEmptySalienceContext(p *SalienceContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_salience
+}
+
+func (*SalienceContext) IsSalienceContext() {}
+
+func NewSalienceContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SalienceContext {
+	var p = new(SalienceContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_salience
+
+	return p
+}
+
+func (s *SalienceContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *SalienceContext) SALIENCE() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSALIENCE, 0)
+}
+
+func (s *SalienceContext) IntegerLiteral() IIntegerLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIntegerLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIntegerLiteralContext)
+}
+
+func (s *SalienceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SalienceContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *SalienceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterSalience(s)
+	}
+}
+
+func (s *SalienceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitSalience(s)
+	}
+}
+
+func (s *SalienceContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitSalience(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) Salience() (localctx ISalienceContext) {
+	localctx = NewSalienceContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, grulev3ParserRULE_salience)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(153)
+		p.Match(grulev3ParserSALIENCE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(154)
+		p.IntegerLiteral()
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IRuleAttributeContext is an interface to support dynamic dispatch.
+type IRuleAttributeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AgendaGroup() IAgendaGroupContext
+	NoLoop() INoLoopContext
+	LockOnActive() ILockOnActiveContext
+	DateEffective() IDateEffectiveContext
+	DateExpires() IDateExpiresContext
+	RuleEnabled() IRuleEnabledContext
+
+	// IsRuleAttributeContext differentiates from other interfaces.
+	IsRuleAttributeContext()
+}
+
+type RuleAttributeContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleAttributeContext() *RuleAttributeContext {
+	var p = new(RuleAttributeContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleAttribute
+	return p
+}
+
+func InitEmptyRuleAttributeContext(p *RuleAttributeContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleAttribute
+}
+
+func (*RuleAttributeContext) IsRuleAttributeContext() {}
+
+func NewRuleAttributeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleAttributeContext {
+	var p = new(RuleAttributeContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_ruleAttribute
+
+	return p
+}
+
+func (s *RuleAttributeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleAttributeContext) AgendaGroup() IAgendaGroupContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAgendaGroupContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAgendaGroupContext)
+}
+
+func (s *RuleAttributeContext) NoLoop() INoLoopContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INoLoopContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INoLoopContext)
+}
+
+func (s *RuleAttributeContext) LockOnActive() ILockOnActiveContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILockOnActiveContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILockOnActiveContext)
+}
+
+func (s *RuleAttributeContext) DateEffective() IDateEffectiveContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDateEffectiveContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDateEffectiveContext)
+}
+
+func (s *RuleAttributeContext) DateExpires() IDateExpiresContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IDateExpiresContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IDateExpiresContext)
+}
+
+func (s *RuleAttributeContext) RuleEnabled() IRuleEnabledContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IRuleEnabledContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRuleEnabledContext)
+}
+
+func (s *RuleAttributeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleAttributeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleAttributeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterRuleAttribute(s)
+	}
+}
+
+func (s *RuleAttributeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitRuleAttribute(s)
+	}
+}
+
+func (s *RuleAttributeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitRuleAttribute(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) RuleAttribute() (localctx IRuleAttributeContext) {
+	localctx = NewRuleAttributeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, grulev3ParserRULE_ruleAttribute)
+	p.SetState(162)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case grulev3ParserAGENDA_GROUP:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(156)
+			p.AgendaGroup()
+		}
+
+	case grulev3ParserNO_LOOP:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(157)
+			p.NoLoop()
+		}
+
+	case grulev3ParserLOCK_ON_ACTIVE:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(158)
+			p.LockOnActive()
+		}
+
+	case grulev3ParserDATE_EFFECTIVE:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(159)
+			p.DateEffective()
+		}
+
+	case grulev3ParserDATE_EXPIRES:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(160)
+			p.DateExpires()
+		}
+
+	case grulev3ParserENABLED:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(161)
+			p.RuleEnabled()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IAgendaGroupContext is an interface to support dynamic dispatch.
+type IAgendaGroupContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AGENDA_GROUP() antlr.TerminalNode
+	StringLiteral() IStringLiteralContext
+
+	// IsAgendaGroupContext differentiates from other interfaces.
+	IsAgendaGroupContext()
+}
+
+type AgendaGroupContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAgendaGroupContext() *AgendaGroupContext {
+	var p = new(AgendaGroupContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_agendaGroup
+	return p
+}
+
+func InitEmptyAgendaGroupContext(p *AgendaGroupContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_agendaGroup
+}
+
+func (*AgendaGroupContext) IsAgendaGroupContext() {}
+
+func NewAgendaGroupContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AgendaGroupContext {
+	var p = new(AgendaGroupContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_agendaGroup
+
+	return p
+}
+
+func (s *AgendaGroupContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AgendaGroupContext) AGENDA_GROUP() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserAGENDA_GROUP, 0)
+}
+
+func (s *AgendaGroupContext) StringLiteral() IStringLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStringLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *AgendaGroupContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AgendaGroupContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *AgendaGroupContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterAgendaGroup(s)
+	}
+}
+
+func (s *AgendaGroupContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitAgendaGroup(s)
+	}
+}
+
+func (s *AgendaGroupContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitAgendaGroup(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) AgendaGroup() (localctx IAgendaGroupContext) {
+	localctx = NewAgendaGroupContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, grulev3ParserRULE_agendaGroup)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(164)
+		p.Match(grulev3ParserAGENDA_GROUP)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(165)
+		p.StringLiteral()
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// INoLoopContext is an interface to support dynamic dispatch.
+type INoLoopContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	NO_LOOP() antlr.TerminalNode
+
+	// IsNoLoopContext differentiates from other interfaces.
+	IsNoLoopContext()
+}
+
+type NoLoopContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyNoLoopContext() *NoLoopContext {
+	var p = new(NoLoopContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_noLoop
+	return p
+}
+
+func InitEmptyNoLoopContext(p *NoLoopContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_noLoop
+}
+
+func (*NoLoopContext) IsNoLoopContext() {}
+
+func NewNoLoopContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *NoLoopContext {
+	var p = new(NoLoopContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_noLoop
+
+	return p
+}
+
+func (s *NoLoopContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *NoLoopContext) NO_LOOP() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserNO_LOOP, 0)
+}
+
+func (s *NoLoopContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NoLoopContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *NoLoopContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterNoLoop(s)
+	}
+}
+
+func (s *NoLoopContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitNoLoop(s)
+	}
+}
+
+func (s *NoLoopContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitNoLoop(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) NoLoop() (localctx INoLoopContext) {
+	localctx = NewNoLoopContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, grulev3ParserRULE_noLoop)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(167)
+		p.Match(grulev3ParserNO_LOOP)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// ILockOnActiveContext is an interface to support dynamic dispatch.
+type ILockOnActiveContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	LOCK_ON_ACTIVE() antlr.TerminalNode
+
+	// IsLockOnActiveContext differentiates from other interfaces.
+	IsLockOnActiveContext()
+}
+
+type LockOnActiveContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLockOnActiveContext() *LockOnActiveContext {
+	var p = new(LockOnActiveContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_lockOnActive
+	return p
+}
+
+func InitEmptyLockOnActiveContext(p *LockOnActiveContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_lockOnActive
+}
+
+func (*LockOnActiveContext) IsLockOnActiveContext() {}
+
+func NewLockOnActiveContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LockOnActiveContext {
+	var p = new(LockOnActiveContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_lockOnActive
+
+	return p
+}
+
+func (s *LockOnActiveContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LockOnActiveContext) LOCK_ON_ACTIVE() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserLOCK_ON_ACTIVE, 0)
+}
+
+func (s *LockOnActiveContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LockOnActiveContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *LockOnActiveContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterLockOnActive(s)
+	}
+}
+
+func (s *LockOnActiveContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitLockOnActive(s)
+	}
+}
+
+func (s *LockOnActiveContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitLockOnActive(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) LockOnActive() (localctx ILockOnActiveContext) {
+	localctx = NewLockOnActiveContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, grulev3ParserRULE_lockOnActive)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(169)
+		p.Match(grulev3ParserLOCK_ON_ACTIVE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IDateEffectiveContext is an interface to support dynamic dispatch.
+type IDateEffectiveContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	DATE_EFFECTIVE() antlr.TerminalNode
+	StringLiteral() IStringLiteralContext
+
+	// IsDateEffectiveContext differentiates from other interfaces.
+	IsDateEffectiveContext()
+}
+
+type DateEffectiveContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDateEffectiveContext() *DateEffectiveContext {
+	var p = new(DateEffectiveContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_dateEffective
+	return p
+}
+
+func InitEmptyDateEffectiveContext(p *DateEffectiveContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_dateEffective
+}
+
+func (*DateEffectiveContext) IsDateEffectiveContext() {}
+
+func NewDateEffectiveContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DateEffectiveContext {
+	var p = new(DateEffectiveContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_dateEffective
+
+	return p
+}
+
+func (s *DateEffectiveContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DateEffectiveContext) DATE_EFFECTIVE() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserDATE_EFFECTIVE, 0)
+}
+
+func (s *DateEffectiveContext) StringLiteral() IStringLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStringLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *DateEffectiveContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DateEffectiveContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DateEffectiveContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterDateEffective(s)
+	}
+}
+
+func (s *DateEffectiveContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitDateEffective(s)
+	}
+}
+
+func (s *DateEffectiveContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitDateEffective(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) DateEffective() (localctx IDateEffectiveContext) {
+	localctx = NewDateEffectiveContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, grulev3ParserRULE_dateEffective)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(171)
+		p.Match(grulev3ParserDATE_EFFECTIVE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(172)
+		p.StringLiteral()
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IDateExpiresContext is an interface to support dynamic dispatch.
+type IDateExpiresContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	DATE_EXPIRES() antlr.TerminalNode
+	StringLiteral() IStringLiteralContext
+
+	// IsDateExpiresContext differentiates from other interfaces.
+	IsDateExpiresContext()
+}
+
+type DateExpiresContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyDateExpiresContext() *DateExpiresContext {
+	var p = new(DateExpiresContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_dateExpires
+	return p
+}
+
+func InitEmptyDateExpiresContext(p *DateExpiresContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_dateExpires
+}
+
+func (*DateExpiresContext) IsDateExpiresContext() {}
+
+func NewDateExpiresContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *DateExpiresContext {
+	var p = new(DateExpiresContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_dateExpires
+
+	return p
+}
+
+func (s *DateExpiresContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *DateExpiresContext) DATE_EXPIRES() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserDATE_EXPIRES, 0)
+}
+
+func (s *DateExpiresContext) StringLiteral() IStringLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStringLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringLiteralContext)
+}
+
+func (s *DateExpiresContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DateExpiresContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *DateExpiresContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterDateExpires(s)
+	}
+}
+
+func (s *DateExpiresContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitDateExpires(s)
+	}
+}
+
+func (s *DateExpiresContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitDateExpires(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) DateExpires() (localctx IDateExpiresContext) {
+	localctx = NewDateExpiresContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, grulev3ParserRULE_dateExpires)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(174)
+		p.Match(grulev3ParserDATE_EXPIRES)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(175)
+		p.StringLiteral()
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IRuleEnabledContext is an interface to support dynamic dispatch.
+type IRuleEnabledContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	ENABLED() antlr.TerminalNode
+	Expression() IExpressionContext
+
+	// IsRuleEnabledContext differentiates from other interfaces.
+	IsRuleEnabledContext()
+}
+
+type RuleEnabledContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleEnabledContext() *RuleEnabledContext {
+	var p = new(RuleEnabledContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleEnabled
+	return p
+}
+
+func InitEmptyRuleEnabledContext(p *RuleEnabledContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleEnabled
+}
+
+func (*RuleEnabledContext) IsRuleEnabledContext() {}
+
+func NewRuleEnabledContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleEnabledContext {
+	var p = new(RuleEnabledContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_ruleEnabled
+
+	return p
+}
+
+func (s *RuleEnabledContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleEnabledContext) ENABLED() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserENABLED, 0)
+}
+
+func (s *RuleEnabledContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *RuleEnabledContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleEnabledContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleEnabledContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterRuleEnabled(s)
+	}
+}
+
+func (s *RuleEnabledContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitRuleEnabled(s)
+	}
+}
+
+func (s *RuleEnabledContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitRuleEnabled(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) RuleEnabled() (localctx IRuleEnabledContext) {
+	localctx = NewRuleEnabledContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, grulev3ParserRULE_ruleEnabled)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(177)
+		p.Match(grulev3ParserENABLED)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(178)
+		p.expression(0)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IRuleNameContext is an interface to support dynamic dispatch.
+type IRuleNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	SIMPLENAME() antlr.TerminalNode
+
+	// IsRuleNameContext differentiates from other interfaces.
+	IsRuleNameContext()
+}
+
+type RuleNameContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleNameContext() *RuleNameContext {
+	var p = new(RuleNameContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleName
+	return p
+}
+
+func InitEmptyRuleNameContext(p *RuleNameContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleName
+}
+
+func (*RuleNameContext) IsRuleNameContext() {}
+
+func NewRuleNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleNameContext {
+	var p = new(RuleNameContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_ruleName
+
+	return p
+}
+
+func (s *RuleNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleNameContext) SIMPLENAME() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, 0)
+}
+
+func (s *RuleNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterRuleName(s)
+	}
+}
+
+func (s *RuleNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitRuleName(s)
+	}
+}
+
+func (s *RuleNameContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitRuleName(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) RuleName() (localctx IRuleNameContext) {
+	localctx = NewRuleNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, grulev3ParserRULE_ruleName)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(180)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IRuleDescriptionContext is an interface to support dynamic dispatch.
+type IRuleDescriptionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	DQUOTA_STRING() antlr.TerminalNode
+	SQUOTA_STRING() antlr.TerminalNode
+
+	// IsRuleDescriptionContext differentiates from other interfaces.
+	IsRuleDescriptionContext()
+}
+
+type RuleDescriptionContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRuleDescriptionContext() *RuleDescriptionContext {
+	var p = new(RuleDescriptionContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleDescription
+	return p
+}
+
+func InitEmptyRuleDescriptionContext(p *RuleDescriptionContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_ruleDescription
+}
+
+func (*RuleDescriptionContext) IsRuleDescriptionContext() {}
+
+func NewRuleDescriptionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleDescriptionContext {
+	var p = new(RuleDescriptionContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_ruleDescription
+
+	return p
+}
+
+func (s *RuleDescriptionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RuleDescriptionContext) DQUOTA_STRING() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserDQUOTA_STRING, 0)
+}
+
+func (s *RuleDescriptionContext) SQUOTA_STRING() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSQUOTA_STRING, 0)
+}
+
+func (s *RuleDescriptionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RuleDescriptionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RuleDescriptionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterRuleDescription(s)
+	}
+}
+
+func (s *RuleDescriptionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitRuleDescription(s)
+	}
+}
+
+func (s *RuleDescriptionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitRuleDescription(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) RuleDescription() (localctx IRuleDescriptionContext) {
+	localctx = NewRuleDescriptionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, grulev3ParserRULE_ruleDescription)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(182)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == grulev3ParserDQUOTA_STRING || _la == grulev3ParserSQUOTA_STRING) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IWhenScopeContext is an interface to support dynamic dispatch.
+type IWhenScopeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	WHEN() antlr.TerminalNode
+	Expression() IExpressionContext
+	AllLetStatement() []ILetStatementContext
+	LetStatement(i int) ILetStatementContext
+	AllSEMICOLON() []antlr.TerminalNode
+	SEMICOLON(i int) antlr.TerminalNode
+
+	// IsWhenScopeContext differentiates from other interfaces.
+	IsWhenScopeContext()
+}
+
+type WhenScopeContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyWhenScopeContext() *WhenScopeContext {
+	var p = new(WhenScopeContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_whenScope
+	return p
+}
+
+func InitEmptyWhenScopeContext(p *WhenScopeContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_whenScope
+}
+
+func (*WhenScopeContext) IsWhenScopeContext() {}
+
+func NewWhenScopeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *WhenScopeContext {
+	var p = new(WhenScopeContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_whenScope
+
+	return p
+}
+
+func (s *WhenScopeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *WhenScopeContext) WHEN() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserWHEN, 0)
+}
+
+func (s *WhenScopeContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *WhenScopeContext) AllLetStatement() []ILetStatementContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ILetStatementContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ILetStatementContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ILetStatementContext); ok {
+			tst[i] = t.(ILetStatementContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *WhenScopeContext) LetStatement(i int) ILetStatementContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILetStatementContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILetStatementContext)
+}
+
+func (s *WhenScopeContext) AllSEMICOLON() []antlr.TerminalNode {
+	return s.GetTokens(grulev3ParserSEMICOLON)
+}
+
+func (s *WhenScopeContext) SEMICOLON(i int) antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSEMICOLON, i)
+}
+
+func (s *WhenScopeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *WhenScopeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *WhenScopeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterWhenScope(s)
+	}
+}
+
+func (s *WhenScopeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitWhenScope(s)
+	}
+}
+
+func (s *WhenScopeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitWhenScope(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) WhenScope() (localctx IWhenScopeContext) {
+	localctx = NewWhenScopeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, grulev3ParserRULE_whenScope)
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(184)
+		p.Match(grulev3ParserWHEN)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(190)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext())
+	if p.HasError() {
+		goto errorExit
+	}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			{
+				p.SetState(185)
+				p.LetStatement()
+			}
+			{
+				p.SetState(186)
+				p.Match(grulev3ParserSEMICOLON)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		p.SetState(192)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(193)
+		p.expression(0)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IThenScopeContext is an interface to support dynamic dispatch.
+type IThenScopeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	THEN() antlr.TerminalNode
+	ThenExpressionList() IThenExpressionListContext
+
+	// IsThenScopeContext differentiates from other interfaces.
+	IsThenScopeContext()
+}
+
+type ThenScopeContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyThenScopeContext() *ThenScopeContext {
+	var p = new(ThenScopeContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_thenScope
+	return p
+}
+
+func InitEmptyThenScopeContext(p *ThenScopeContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_thenScope
+}
+
+func (*ThenScopeContext) IsThenScopeContext() {}
+
+func NewThenScopeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ThenScopeContext {
+	var p = new(ThenScopeContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_thenScope
+
+	return p
+}
+
+func (s *ThenScopeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ThenScopeContext) THEN() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserTHEN, 0)
+}
+
+func (s *ThenScopeContext) ThenExpressionList() IThenExpressionListContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenExpressionListContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenExpressionListContext)
+}
+
+func (s *ThenScopeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ThenScopeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ThenScopeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterThenScope(s)
+	}
+}
+
+func (s *ThenScopeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitThenScope(s)
+	}
+}
+
+func (s *ThenScopeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitThenScope(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) ThenScope() (localctx IThenScopeContext) {
+	localctx = NewThenScopeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, grulev3ParserRULE_thenScope)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(195)
+		p.Match(grulev3ParserTHEN)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(196)
+		p.ThenExpressionList()
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IThenExpressionListContext is an interface to support dynamic dispatch.
+type IThenExpressionListContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllThenExpression() []IThenExpressionContext
+	ThenExpression(i int) IThenExpressionContext
+	AllSEMICOLON() []antlr.TerminalNode
+	SEMICOLON(i int) antlr.TerminalNode
+	AllThenStatement() []IThenStatementContext
+	ThenStatement(i int) IThenStatementContext
+
+	// IsThenExpressionListContext differentiates from other interfaces.
+	IsThenExpressionListContext()
+}
+
+type ThenExpressionListContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyThenExpressionListContext() *ThenExpressionListContext {
+	var p = new(ThenExpressionListContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_thenExpressionList
+	return p
+}
+
+func InitEmptyThenExpressionListContext(p *ThenExpressionListContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_thenExpressionList
+}
+
+func (*ThenExpressionListContext) IsThenExpressionListContext() {}
+
+func NewThenExpressionListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ThenExpressionListContext {
+	var p = new(ThenExpressionListContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_thenExpressionList
+
+	return p
+}
+
+func (s *ThenExpressionListContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ThenExpressionListContext) AllThenExpression() []IThenExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IThenExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IThenExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IThenExpressionContext); ok {
+			tst[i] = t.(IThenExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ThenExpressionListContext) ThenExpression(i int) IThenExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenExpressionContext)
+}
+
+func (s *ThenExpressionListContext) AllSEMICOLON() []antlr.TerminalNode {
+	return s.GetTokens(grulev3ParserSEMICOLON)
+}
+
+func (s *ThenExpressionListContext) SEMICOLON(i int) antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSEMICOLON, i)
+}
+
+func (s *ThenExpressionListContext) AllThenStatement() []IThenStatementContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IThenStatementContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IThenStatementContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IThenStatementContext); ok {
+			tst[i] = t.(IThenStatementContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ThenExpressionListContext) ThenStatement(i int) IThenStatementContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenStatementContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenStatementContext)
+}
+
+func (s *ThenExpressionListContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ThenExpressionListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ThenExpressionListContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterThenExpressionList(s)
+	}
+}
+
+func (s *ThenExpressionListContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitThenExpressionList(s)
+	}
+}
+
+func (s *ThenExpressionListContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitThenExpressionList(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) ThenExpressionList() (localctx IThenExpressionListContext) {
+	localctx = NewThenExpressionListContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, grulev3ParserRULE_thenExpressionList)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(202)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for ok := true; ok; ok = ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&132715451581988872) != 0) {
+		p.SetState(202)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+
+		switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 11, p.GetParserRuleContext()) {
+		case 1:
+			{
+				p.SetState(198)
+				p.ThenExpression()
+			}
+			{
+				p.SetState(199)
+				p.Match(grulev3ParserSEMICOLON)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		case 2:
+			{
+				p.SetState(201)
+				p.ThenStatement()
+			}
+
+		case antlr.ATNInvalidAltNumber:
+			goto errorExit
+		}
+
+		p.SetState(204)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IThenStatementContext is an interface to support dynamic dispatch.
+type IThenStatementContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	IfStatement() IIfStatementContext
+	ForStatement() IForStatementContext
+
+	// IsThenStatementContext differentiates from other interfaces.
+	IsThenStatementContext()
+}
+
+type ThenStatementContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyThenStatementContext() *ThenStatementContext {
+	var p = new(ThenStatementContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_thenStatement
+	return p
+}
+
+func InitEmptyThenStatementContext(p *ThenStatementContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_thenStatement
+}
+
+func (*ThenStatementContext) IsThenStatementContext() {}
+
+func NewThenStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ThenStatementContext {
+	var p = new(ThenStatementContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_thenStatement
+
+	return p
+}
+
+func (s *ThenStatementContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ThenStatementContext) IfStatement() IIfStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIfStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIfStatementContext)
+}
+
+func (s *ThenStatementContext) ForStatement() IForStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IForStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IForStatementContext)
+}
+
+func (s *ThenStatementContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ThenStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ThenStatementContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterThenStatement(s)
+	}
+}
+
+func (s *ThenStatementContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitThenStatement(s)
+	}
+}
+
+func (s *ThenStatementContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitThenStatement(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) ThenStatement() (localctx IThenStatementContext) {
+	localctx = NewThenStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, grulev3ParserRULE_thenStatement)
+	p.SetState(208)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 13, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(206)
+			p.IfStatement()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(207)
+			p.ForStatement()
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
 		localctx.SetException(v)
 		p.GetErrorHandler().ReportError(p, v)
 		p.GetErrorHandler().Recover(p, v)
@@ -961,107 +3392,168 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IRuleDescriptionContext is an interface to support dynamic dispatch.
-type IRuleDescriptionContext interface {
+// IIfStatementContext is an interface to support dynamic dispatch.
+type IIfStatementContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	DQUOTA_STRING() antlr.TerminalNode
-	SQUOTA_STRING() antlr.TerminalNode
+	SIMPLENAME() antlr.TerminalNode
+	Expression() IExpressionContext
+	ThenBlock() IThenBlockContext
+	ElseStatement() IElseStatementContext
 
-	// IsRuleDescriptionContext differentiates from other interfaces.
-	IsRuleDescriptionContext()
+	// IsIfStatementContext differentiates from other interfaces.
+	IsIfStatementContext()
 }
 
-type RuleDescriptionContext struct {
+type IfStatementContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyRuleDescriptionContext() *RuleDescriptionContext {
-	var p = new(RuleDescriptionContext)
+func NewEmptyIfStatementContext() *IfStatementContext {
+	var p = new(IfStatementContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_ruleDescription
+	p.RuleIndex = grulev3ParserRULE_ifStatement
 	return p
 }
 
-func InitEmptyRuleDescriptionContext(p *RuleDescriptionContext) {
+func InitEmptyIfStatementContext(p *IfStatementContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_ruleDescription
+	p.RuleIndex = grulev3ParserRULE_ifStatement
 }
 
-func (*RuleDescriptionContext) IsRuleDescriptionContext() {}
+func (*IfStatementContext) IsIfStatementContext() {}
 
-func NewRuleDescriptionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RuleDescriptionContext {
-	var p = new(RuleDescriptionContext)
+func NewIfStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IfStatementContext {
+	var p = new(IfStatementContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = grulev3ParserRULE_ruleDescription
+	p.RuleIndex = grulev3ParserRULE_ifStatement
 
 	return p
 }
 
-func (s *RuleDescriptionContext) GetParser() antlr.Parser { return s.parser }
+func (s *IfStatementContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *RuleDescriptionContext) DQUOTA_STRING() antlr.TerminalNode {
-	return s.GetToken(grulev3ParserDQUOTA_STRING, 0)
+func (s *IfStatementContext) SIMPLENAME() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, 0)
 }
 
-func (s *RuleDescriptionContext) SQUOTA_STRING() antlr.TerminalNode {
-	return s.GetToken(grulev3ParserSQUOTA_STRING, 0)
+func (s *IfStatementContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
 }
 
-func (s *RuleDescriptionContext) GetRuleContext() antlr.RuleContext {
+func (s *IfStatementContext) ThenBlock() IThenBlockContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenBlockContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenBlockContext)
+}
+
+func (s *IfStatementContext) ElseStatement() IElseStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IElseStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IElseStatementContext)
+}
+
+func (s *IfStatementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *RuleDescriptionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *IfStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *RuleDescriptionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *IfStatementContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.EnterRuleDescription(s)
+		listenerT.EnterIfStatement(s)
 	}
 }
 
-func (s *RuleDescriptionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *IfStatementContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.ExitRuleDescription(s)
+		listenerT.ExitIfStatement(s)
 	}
 }
 
-func (s *RuleDescriptionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *IfStatementContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case grulev3Visitor:
-		return t.VisitRuleDescription(s)
+		return t.VisitIfStatement(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *grulev3Parser) RuleDescription() (localctx IRuleDescriptionContext) {
-	localctx = NewRuleDescriptionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, grulev3ParserRULE_ruleDescription)
-	var _la int
-
+func (p *grulev3Parser) IfStatement() (localctx IIfStatementContext) {
+	localctx = NewIfStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, grulev3ParserRULE_ifStatement)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(92)
-		_la = p.GetTokenStream().LA(1)
+		p.SetState(210)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(211)
+		p.expression(0)
+	}
+	{
+		p.SetState(212)
+		p.ThenBlock()
+	}
+	p.SetState(214)
+	p.GetErrorHandler().Sync(p)
 
-		if !(_la == grulev3ParserDQUOTA_STRING || _la == grulev3ParserSQUOTA_STRING) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
+	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(213)
+			p.ElseStatement()
 		}
+
+	} else if p.HasError() { // JIM
+		goto errorExit
 	}
 
 errorExit:
@@ -1077,61 +3569,62 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IWhenScopeContext is an interface to support dynamic dispatch.
-type IWhenScopeContext interface {
+// IElseStatementContext is an interface to support dynamic dispatch.
+type IElseStatementContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	WHEN() antlr.TerminalNode
-	Expression() IExpressionContext
+	SIMPLENAME() antlr.TerminalNode
+	IfStatement() IIfStatementContext
+	ThenBlock() IThenBlockContext
 
-	// IsWhenScopeContext differentiates from other interfaces.
-	IsWhenScopeContext()
+	// IsElseStatementContext differentiates from other interfaces.
+	IsElseStatementContext()
 }
 
-type WhenScopeContext struct {
+type ElseStatementContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyWhenScopeContext() *WhenScopeContext {
-	var p = new(WhenScopeContext)
+func NewEmptyElseStatementContext() *ElseStatementContext {
+	var p = new(ElseStatementContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_whenScope
+	p.RuleIndex = grulev3ParserRULE_elseStatement
 	return p
 }
 
-func InitEmptyWhenScopeContext(p *WhenScopeContext) {
+func InitEmptyElseStatementContext(p *ElseStatementContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_whenScope
+	p.RuleIndex = grulev3ParserRULE_elseStatement
 }
 
-func (*WhenScopeContext) IsWhenScopeContext() {}
+func (*ElseStatementContext) IsElseStatementContext() {}
 
-func NewWhenScopeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *WhenScopeContext {
-	var p = new(WhenScopeContext)
+func NewElseStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ElseStatementContext {
+	var p = new(ElseStatementContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = grulev3ParserRULE_whenScope
+	p.RuleIndex = grulev3ParserRULE_elseStatement
 
 	return p
 }
 
-func (s *WhenScopeContext) GetParser() antlr.Parser { return s.parser }
+func (s *ElseStatementContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *WhenScopeContext) WHEN() antlr.TerminalNode {
-	return s.GetToken(grulev3ParserWHEN, 0)
+func (s *ElseStatementContext) SIMPLENAME() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, 0)
 }
 
-func (s *WhenScopeContext) Expression() IExpressionContext {
+func (s *ElseStatementContext) IfStatement() IIfStatementContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IIfStatementContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -1141,54 +3634,89 @@ func (s *WhenScopeContext) Expression() IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IIfStatementContext)
 }
 
-func (s *WhenScopeContext) GetRuleContext() antlr.RuleContext {
+func (s *ElseStatementContext) ThenBlock() IThenBlockContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenBlockContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenBlockContext)
+}
+
+func (s *ElseStatementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *WhenScopeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ElseStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *WhenScopeContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ElseStatementContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.EnterWhenScope(s)
+		listenerT.EnterElseStatement(s)
 	}
 }
 
-func (s *WhenScopeContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ElseStatementContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.ExitWhenScope(s)
+		listenerT.ExitElseStatement(s)
 	}
 }
 
-func (s *WhenScopeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ElseStatementContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case grulev3Visitor:
-		return t.VisitWhenScope(s)
+		return t.VisitElseStatement(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *grulev3Parser) WhenScope() (localctx IWhenScopeContext) {
-	localctx = NewWhenScopeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, grulev3ParserRULE_whenScope)
+func (p *grulev3Parser) ElseStatement() (localctx IElseStatementContext) {
+	localctx = NewElseStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, grulev3ParserRULE_elseStatement)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(94)
-		p.Match(grulev3ParserWHEN)
+		p.SetState(216)
+		p.Match(grulev3ParserSIMPLENAME)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	{
-		p.SetState(95)
-		p.expression(0)
+	p.SetState(219)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case grulev3ParserSIMPLENAME:
+		{
+			p.SetState(217)
+			p.IfStatement()
+		}
+
+	case grulev3ParserLR_BRACE:
+		{
+			p.SetState(218)
+			p.ThenBlock()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
 	}
 
 errorExit:
@@ -1204,61 +3732,67 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IThenScopeContext is an interface to support dynamic dispatch.
-type IThenScopeContext interface {
+// IForStatementContext is an interface to support dynamic dispatch.
+type IForStatementContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	THEN() antlr.TerminalNode
-	ThenExpressionList() IThenExpressionListContext
+	AllSIMPLENAME() []antlr.TerminalNode
+	SIMPLENAME(i int) antlr.TerminalNode
+	ExpressionAtom() IExpressionAtomContext
+	ThenBlock() IThenBlockContext
 
-	// IsThenScopeContext differentiates from other interfaces.
-	IsThenScopeContext()
+	// IsForStatementContext differentiates from other interfaces.
+	IsForStatementContext()
 }
 
-type ThenScopeContext struct {
+type ForStatementContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyThenScopeContext() *ThenScopeContext {
-	var p = new(ThenScopeContext)
+func NewEmptyForStatementContext() *ForStatementContext {
+	var p = new(ForStatementContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_thenScope
+	p.RuleIndex = grulev3ParserRULE_forStatement
 	return p
 }
 
-func InitEmptyThenScopeContext(p *ThenScopeContext) {
+func InitEmptyForStatementContext(p *ForStatementContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_thenScope
+	p.RuleIndex = grulev3ParserRULE_forStatement
 }
 
-func (*ThenScopeContext) IsThenScopeContext() {}
+func (*ForStatementContext) IsForStatementContext() {}
 
-func NewThenScopeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ThenScopeContext {
-	var p = new(ThenScopeContext)
+func NewForStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ForStatementContext {
+	var p = new(ForStatementContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = grulev3ParserRULE_thenScope
+	p.RuleIndex = grulev3ParserRULE_forStatement
 
 	return p
 }
 
-func (s *ThenScopeContext) GetParser() antlr.Parser { return s.parser }
+func (s *ForStatementContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ThenScopeContext) THEN() antlr.TerminalNode {
-	return s.GetToken(grulev3ParserTHEN, 0)
+func (s *ForStatementContext) AllSIMPLENAME() []antlr.TerminalNode {
+	return s.GetTokens(grulev3ParserSIMPLENAME)
 }
 
-func (s *ThenScopeContext) ThenExpressionList() IThenExpressionListContext {
+func (s *ForStatementContext) SIMPLENAME(i int) antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, i)
+}
+
+func (s *ForStatementContext) ExpressionAtom() IExpressionAtomContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IThenExpressionListContext); ok {
+		if _, ok := ctx.(IExpressionAtomContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -1268,54 +3802,90 @@ func (s *ThenScopeContext) ThenExpressionList() IThenExpressionListContext {
 		return nil
 	}
 
-	return t.(IThenExpressionListContext)
+	return t.(IExpressionAtomContext)
 }
 
-func (s *ThenScopeContext) GetRuleContext() antlr.RuleContext {
+func (s *ForStatementContext) ThenBlock() IThenBlockContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenBlockContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenBlockContext)
+}
+
+func (s *ForStatementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ThenScopeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ForStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ThenScopeContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ForStatementContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.EnterThenScope(s)
+		listenerT.EnterForStatement(s)
 	}
 }
 
-func (s *ThenScopeContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ForStatementContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.ExitThenScope(s)
+		listenerT.ExitForStatement(s)
 	}
 }
 
-func (s *ThenScopeContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ForStatementContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case grulev3Visitor:
-		return t.VisitThenScope(s)
+		return t.VisitForStatement(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
-}
-
-func (p *grulev3Parser) ThenScope() (localctx IThenScopeContext) {
-	localctx = NewThenScopeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, grulev3ParserRULE_thenScope)
-	p.EnterOuterAlt(localctx, 1)
+}
+
+func (p *grulev3Parser) ForStatement() (localctx IForStatementContext) {
+	localctx = NewForStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, grulev3ParserRULE_forStatement)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(221)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
 	{
-		p.SetState(97)
-		p.Match(grulev3ParserTHEN)
+		p.SetState(222)
+		p.Match(grulev3ParserSIMPLENAME)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(98)
-		p.ThenExpressionList()
+		p.SetState(223)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(224)
+		p.expressionAtom(0)
+	}
+	{
+		p.SetState(225)
+		p.ThenBlock()
 	}
 
 errorExit:
@@ -1331,56 +3901,68 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IThenExpressionListContext is an interface to support dynamic dispatch.
-type IThenExpressionListContext interface {
+// IThenBlockContext is an interface to support dynamic dispatch.
+type IThenBlockContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
+	LR_BRACE() antlr.TerminalNode
+	RR_BRACE() antlr.TerminalNode
 	AllThenExpression() []IThenExpressionContext
 	ThenExpression(i int) IThenExpressionContext
 	AllSEMICOLON() []antlr.TerminalNode
 	SEMICOLON(i int) antlr.TerminalNode
+	AllThenStatement() []IThenStatementContext
+	ThenStatement(i int) IThenStatementContext
 
-	// IsThenExpressionListContext differentiates from other interfaces.
-	IsThenExpressionListContext()
+	// IsThenBlockContext differentiates from other interfaces.
+	IsThenBlockContext()
 }
 
-type ThenExpressionListContext struct {
+type ThenBlockContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyThenExpressionListContext() *ThenExpressionListContext {
-	var p = new(ThenExpressionListContext)
+func NewEmptyThenBlockContext() *ThenBlockContext {
+	var p = new(ThenBlockContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_thenExpressionList
+	p.RuleIndex = grulev3ParserRULE_thenBlock
 	return p
 }
 
-func InitEmptyThenExpressionListContext(p *ThenExpressionListContext) {
+func InitEmptyThenBlockContext(p *ThenBlockContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = grulev3ParserRULE_thenExpressionList
+	p.RuleIndex = grulev3ParserRULE_thenBlock
 }
 
-func (*ThenExpressionListContext) IsThenExpressionListContext() {}
+func (*ThenBlockContext) IsThenBlockContext() {}
 
-func NewThenExpressionListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ThenExpressionListContext {
-	var p = new(ThenExpressionListContext)
+func NewThenBlockContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ThenBlockContext {
+	var p = new(ThenBlockContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = grulev3ParserRULE_thenExpressionList
+	p.RuleIndex = grulev3ParserRULE_thenBlock
 
 	return p
 }
 
-func (s *ThenExpressionListContext) GetParser() antlr.Parser { return s.parser }
+func (s *ThenBlockContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ThenExpressionListContext) AllThenExpression() []IThenExpressionContext {
+func (s *ThenBlockContext) LR_BRACE() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserLR_BRACE, 0)
+}
+
+func (s *ThenBlockContext) RR_BRACE() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserRR_BRACE, 0)
+}
+
+func (s *ThenBlockContext) AllThenExpression() []IThenExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1401,7 +3983,7 @@ func (s *ThenExpressionListContext) AllThenExpression() []IThenExpressionContext
 	return tst
 }
 
-func (s *ThenExpressionListContext) ThenExpression(i int) IThenExpressionContext {
+func (s *ThenBlockContext) ThenExpression(i int) IThenExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1421,78 +4003,153 @@ func (s *ThenExpressionListContext) ThenExpression(i int) IThenExpressionContext
 	return t.(IThenExpressionContext)
 }
 
-func (s *ThenExpressionListContext) AllSEMICOLON() []antlr.TerminalNode {
+func (s *ThenBlockContext) AllSEMICOLON() []antlr.TerminalNode {
 	return s.GetTokens(grulev3ParserSEMICOLON)
 }
 
-func (s *ThenExpressionListContext) SEMICOLON(i int) antlr.TerminalNode {
+func (s *ThenBlockContext) SEMICOLON(i int) antlr.TerminalNode {
 	return s.GetToken(grulev3ParserSEMICOLON, i)
 }
 
-func (s *ThenExpressionListContext) GetRuleContext() antlr.RuleContext {
+func (s *ThenBlockContext) AllThenStatement() []IThenStatementContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IThenStatementContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IThenStatementContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IThenStatementContext); ok {
+			tst[i] = t.(IThenStatementContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ThenBlockContext) ThenStatement(i int) IThenStatementContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IThenStatementContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IThenStatementContext)
+}
+
+func (s *ThenBlockContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ThenExpressionListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ThenBlockContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ThenExpressionListContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ThenBlockContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.EnterThenExpressionList(s)
+		listenerT.EnterThenBlock(s)
 	}
 }
 
-func (s *ThenExpressionListContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ThenBlockContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(grulev3Listener); ok {
-		listenerT.ExitThenExpressionList(s)
+		listenerT.ExitThenBlock(s)
 	}
 }
 
-func (s *ThenExpressionListContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ThenBlockContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case grulev3Visitor:
-		return t.VisitThenExpressionList(s)
+		return t.VisitThenBlock(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *grulev3Parser) ThenExpressionList() (localctx IThenExpressionListContext) {
-	localctx = NewThenExpressionListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, grulev3ParserRULE_thenExpressionList)
+func (p *grulev3Parser) ThenBlock() (localctx IThenBlockContext) {
+	localctx = NewThenBlockContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 42, grulev3ParserRULE_thenBlock)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(103)
+	{
+		p.SetState(227)
+		p.Match(grulev3ParserLR_BRACE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(234)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	for ok := true; ok; ok = ((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&259209881976840) != 0) {
-		{
-			p.SetState(100)
-			p.ThenExpression()
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&132715451581988872) != 0 {
+		p.SetState(232)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
 		}
-		{
-			p.SetState(101)
-			p.Match(grulev3ParserSEMICOLON)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
+
+		switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 16, p.GetParserRuleContext()) {
+		case 1:
+			{
+				p.SetState(228)
+				p.ThenExpression()
+			}
+			{
+				p.SetState(229)
+				p.Match(grulev3ParserSEMICOLON)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
 			}
+
+		case 2:
+			{
+				p.SetState(231)
+				p.ThenStatement()
+			}
+
+		case antlr.ATNInvalidAltNumber:
+			goto errorExit
 		}
 
-		p.SetState(105)
+		p.SetState(236)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 	}
+	{
+		p.SetState(237)
+		p.Match(grulev3ParserRR_BRACE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
 
 errorExit:
 	if p.HasError() {
@@ -1516,6 +4173,7 @@ type IThenExpressionContext interface {
 
 	// Getter signatures
 	Assignment() IAssignmentContext
+	LetStatement() ILetStatementContext
 	ExpressionAtom() IExpressionAtomContext
 
 	// IsThenExpressionContext differentiates from other interfaces.
@@ -1570,6 +4228,22 @@ func (s *ThenExpressionContext) Assignment() IAssignmentContext {
 	return t.(IAssignmentContext)
 }
 
+func (s *ThenExpressionContext) LetStatement() ILetStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILetStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILetStatementContext)
+}
+
 func (s *ThenExpressionContext) ExpressionAtom() IExpressionAtomContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -1618,25 +4292,32 @@ func (s *ThenExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *grulev3Parser) ThenExpression() (localctx IThenExpressionContext) {
 	localctx = NewThenExpressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, grulev3ParserRULE_thenExpression)
-	p.SetState(109)
+	p.EnterRule(localctx, 44, grulev3ParserRULE_thenExpression)
+	p.SetState(242)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 4, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 18, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(107)
+			p.SetState(239)
 			p.Assignment()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(108)
+			p.SetState(240)
+			p.LetStatement()
+		}
+
+	case 3:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(241)
 			p.expressionAtom(0)
 		}
 
@@ -1793,19 +4474,19 @@ func (s *AssignmentContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *grulev3Parser) Assignment() (localctx IAssignmentContext) {
 	localctx = NewAssignmentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, grulev3ParserRULE_assignment)
+	p.EnterRule(localctx, 46, grulev3ParserRULE_assignment)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(111)
+		p.SetState(244)
 		p.variable(0)
 	}
 	{
-		p.SetState(112)
+		p.SetState(245)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&2080374784) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1065151889408) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -1813,7 +4494,160 @@ func (p *grulev3Parser) Assignment() (localctx IAssignmentContext) {
 		}
 	}
 	{
-		p.SetState(113)
+		p.SetState(246)
+		p.expression(0)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// ILetStatementContext is an interface to support dynamic dispatch.
+type ILetStatementContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllSIMPLENAME() []antlr.TerminalNode
+	SIMPLENAME(i int) antlr.TerminalNode
+	ASSIGN() antlr.TerminalNode
+	Expression() IExpressionContext
+
+	// IsLetStatementContext differentiates from other interfaces.
+	IsLetStatementContext()
+}
+
+type LetStatementContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLetStatementContext() *LetStatementContext {
+	var p = new(LetStatementContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_letStatement
+	return p
+}
+
+func InitEmptyLetStatementContext(p *LetStatementContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_letStatement
+}
+
+func (*LetStatementContext) IsLetStatementContext() {}
+
+func NewLetStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LetStatementContext {
+	var p = new(LetStatementContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_letStatement
+
+	return p
+}
+
+func (s *LetStatementContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LetStatementContext) AllSIMPLENAME() []antlr.TerminalNode {
+	return s.GetTokens(grulev3ParserSIMPLENAME)
+}
+
+func (s *LetStatementContext) SIMPLENAME(i int) antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, i)
+}
+
+func (s *LetStatementContext) ASSIGN() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserASSIGN, 0)
+}
+
+func (s *LetStatementContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *LetStatementContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LetStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *LetStatementContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterLetStatement(s)
+	}
+}
+
+func (s *LetStatementContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitLetStatement(s)
+	}
+}
+
+func (s *LetStatementContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitLetStatement(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) LetStatement() (localctx ILetStatementContext) {
+	localctx = NewLetStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 48, grulev3ParserRULE_letStatement)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(248)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(249)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(250)
+		p.Match(grulev3ParserASSIGN)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(251)
 		p.expression(0)
 	}
 
@@ -2076,22 +4910,22 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IExpressionContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 20
-	p.EnterRecursionRule(localctx, 20, grulev3ParserRULE_expression, _p)
+	_startState := 50
+	p.EnterRecursionRule(localctx, 50, grulev3ParserRULE_expression, _p)
 	var _la int
 
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(124)
+	p.SetState(262)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 20, p.GetParserRuleContext()) {
 	case 1:
-		p.SetState(117)
+		p.SetState(255)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2100,7 +4934,7 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 
 		if _la == grulev3ParserNEGATION {
 			{
-				p.SetState(116)
+				p.SetState(254)
 				p.Match(grulev3ParserNEGATION)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2110,7 +4944,7 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 
 		}
 		{
-			p.SetState(119)
+			p.SetState(257)
 			p.Match(grulev3ParserLR_BRACKET)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2118,11 +4952,11 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(120)
+			p.SetState(258)
 			p.expression(0)
 		}
 		{
-			p.SetState(121)
+			p.SetState(259)
 			p.Match(grulev3ParserRR_BRACKET)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2132,7 +4966,7 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 
 	case 2:
 		{
-			p.SetState(123)
+			p.SetState(261)
 			p.expressionAtom(0)
 		}
 
@@ -2140,12 +4974,12 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(148)
+	p.SetState(286)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 22, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -2155,100 +4989,100 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(146)
+			p.SetState(284)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 
-			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 21, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewExpressionContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expression)
-				p.SetState(126)
+				p.SetState(264)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(127)
+					p.SetState(265)
 					p.MulDivOperators()
 				}
 				{
-					p.SetState(128)
+					p.SetState(266)
 					p.expression(8)
 				}
 
 			case 2:
 				localctx = NewExpressionContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expression)
-				p.SetState(130)
+				p.SetState(268)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(131)
+					p.SetState(269)
 					p.AddMinusOperators()
 				}
 				{
-					p.SetState(132)
+					p.SetState(270)
 					p.expression(7)
 				}
 
 			case 3:
 				localctx = NewExpressionContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expression)
-				p.SetState(134)
+				p.SetState(272)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(135)
+					p.SetState(273)
 					p.ComparisonOperator()
 				}
 				{
-					p.SetState(136)
+					p.SetState(274)
 					p.expression(6)
 				}
 
 			case 4:
 				localctx = NewExpressionContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expression)
-				p.SetState(138)
+				p.SetState(276)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(139)
+					p.SetState(277)
 					p.AndLogicOperator()
 				}
 				{
-					p.SetState(140)
+					p.SetState(278)
 					p.expression(5)
 				}
 
 			case 5:
 				localctx = NewExpressionContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expression)
-				p.SetState(142)
+				p.SetState(280)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(143)
+					p.SetState(281)
 					p.OrLogicOperator()
 				}
 				{
-					p.SetState(144)
+					p.SetState(282)
 					p.expression(4)
 				}
 
@@ -2257,12 +5091,12 @@ func (p *grulev3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 
 		}
-		p.SetState(150)
+		p.SetState(288)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 22, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -2373,12 +5207,12 @@ func (s *MulDivOperatorsContext) Accept(visitor antlr.ParseTreeVisitor) interfac
 
 func (p *grulev3Parser) MulDivOperators() (localctx IMulDivOperatorsContext) {
 	localctx = NewMulDivOperatorsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, grulev3ParserRULE_mulDivOperators)
+	p.EnterRule(localctx, 52, grulev3ParserRULE_mulDivOperators)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(151)
+		p.SetState(289)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&112) != 0) {
@@ -2499,15 +5333,15 @@ func (s *AddMinusOperatorsContext) Accept(visitor antlr.ParseTreeVisitor) interf
 
 func (p *grulev3Parser) AddMinusOperators() (localctx IAddMinusOperatorsContext) {
 	localctx = NewAddMinusOperatorsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, grulev3ParserRULE_addMinusOperators)
+	p.EnterRule(localctx, 54, grulev3ParserRULE_addMinusOperators)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(153)
+		p.SetState(291)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&206158430220) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&105553116266508) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -2635,15 +5469,15 @@ func (s *ComparisonOperatorContext) Accept(visitor antlr.ParseTreeVisitor) inter
 
 func (p *grulev3Parser) ComparisonOperator() (localctx IComparisonOperatorContext) {
 	localctx = NewComparisonOperatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, grulev3ParserRULE_comparisonOperator)
+	p.EnterRule(localctx, 56, grulev3ParserRULE_comparisonOperator)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(155)
+		p.SetState(293)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&66605547520) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&34102040330240) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -2746,10 +5580,10 @@ func (s *AndLogicOperatorContext) Accept(visitor antlr.ParseTreeVisitor) interfa
 
 func (p *grulev3Parser) AndLogicOperator() (localctx IAndLogicOperatorContext) {
 	localctx = NewAndLogicOperatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, grulev3ParserRULE_andLogicOperator)
+	p.EnterRule(localctx, 58, grulev3ParserRULE_andLogicOperator)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(157)
+		p.SetState(295)
 		p.Match(grulev3ParserAND)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2852,10 +5686,10 @@ func (s *OrLogicOperatorContext) Accept(visitor antlr.ParseTreeVisitor) interfac
 
 func (p *grulev3Parser) OrLogicOperator() (localctx IOrLogicOperatorContext) {
 	localctx = NewOrLogicOperatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, grulev3ParserRULE_orLogicOperator)
+	p.EnterRule(localctx, 60, grulev3ParserRULE_orLogicOperator)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(159)
+		p.SetState(297)
 		p.Match(grulev3ParserOR)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2886,6 +5720,7 @@ type IExpressionAtomContext interface {
 	// Getter signatures
 	Constant() IConstantContext
 	Variable() IVariableContext
+	CollectionExpression() ICollectionExpressionContext
 	FunctionCall() IFunctionCallContext
 	NEGATION() antlr.TerminalNode
 	ExpressionAtom() IExpressionAtomContext
@@ -2961,6 +5796,22 @@ func (s *ExpressionAtomContext) Variable() IVariableContext {
 	return t.(IVariableContext)
 }
 
+func (s *ExpressionAtomContext) CollectionExpression() ICollectionExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ICollectionExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICollectionExpressionContext)
+}
+
 func (s *ExpressionAtomContext) FunctionCall() IFunctionCallContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -3086,39 +5937,45 @@ func (p *grulev3Parser) expressionAtom(_p int) (localctx IExpressionAtomContext)
 	localctx = NewExpressionAtomContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IExpressionAtomContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 32
-	p.EnterRecursionRule(localctx, 32, grulev3ParserRULE_expressionAtom, _p)
+	_startState := 62
+	p.EnterRecursionRule(localctx, 62, grulev3ParserRULE_expressionAtom, _p)
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(167)
+	p.SetState(306)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 23, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(162)
+			p.SetState(300)
 			p.Constant()
 		}
 
 	case 2:
 		{
-			p.SetState(163)
+			p.SetState(301)
 			p.variable(0)
 		}
 
 	case 3:
 		{
-			p.SetState(164)
-			p.FunctionCall()
+			p.SetState(302)
+			p.CollectionExpression()
 		}
 
 	case 4:
 		{
-			p.SetState(165)
+			p.SetState(303)
+			p.FunctionCall()
+		}
+
+	case 5:
+		{
+			p.SetState(304)
 			p.Match(grulev3ParserNEGATION)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3126,7 +5983,7 @@ func (p *grulev3Parser) expressionAtom(_p int) (localctx IExpressionAtomContext)
 			}
 		}
 		{
-			p.SetState(166)
+			p.SetState(305)
 			p.expressionAtom(1)
 		}
 
@@ -3134,12 +5991,12 @@ func (p *grulev3Parser) expressionAtom(_p int) (localctx IExpressionAtomContext)
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(177)
+	p.SetState(316)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 11, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 25, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -3149,52 +6006,52 @@ func (p *grulev3Parser) expressionAtom(_p int) (localctx IExpressionAtomContext)
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(175)
+			p.SetState(314)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 
-			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 24, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewExpressionAtomContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expressionAtom)
-				p.SetState(169)
+				p.SetState(308)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(170)
+					p.SetState(309)
 					p.MethodCall()
 				}
 
 			case 2:
 				localctx = NewExpressionAtomContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expressionAtom)
-				p.SetState(171)
+				p.SetState(310)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(172)
+					p.SetState(311)
 					p.MemberVariable()
 				}
 
 			case 3:
 				localctx = NewExpressionAtomContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_expressionAtom)
-				p.SetState(173)
+				p.SetState(312)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(174)
+					p.SetState(313)
 					p.ArrayMapSelector()
 				}
 
@@ -3203,12 +6060,12 @@ func (p *grulev3Parser) expressionAtom(_p int) (localctx IExpressionAtomContext)
 			}
 
 		}
-		p.SetState(179)
+		p.SetState(318)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 11, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 25, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -3227,6 +6084,274 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
+// ICollectionExpressionContext is an interface to support dynamic dispatch.
+type ICollectionExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllSIMPLENAME() []antlr.TerminalNode
+	SIMPLENAME(i int) antlr.TerminalNode
+	LR_BRACKET() antlr.TerminalNode
+	ExpressionAtom() IExpressionAtomContext
+	RR_BRACKET() antlr.TerminalNode
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
+	COLON() antlr.TerminalNode
+
+	// IsCollectionExpressionContext differentiates from other interfaces.
+	IsCollectionExpressionContext()
+}
+
+type CollectionExpressionContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCollectionExpressionContext() *CollectionExpressionContext {
+	var p = new(CollectionExpressionContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_collectionExpression
+	return p
+}
+
+func InitEmptyCollectionExpressionContext(p *CollectionExpressionContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = grulev3ParserRULE_collectionExpression
+}
+
+func (*CollectionExpressionContext) IsCollectionExpressionContext() {}
+
+func NewCollectionExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CollectionExpressionContext {
+	var p = new(CollectionExpressionContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = grulev3ParserRULE_collectionExpression
+
+	return p
+}
+
+func (s *CollectionExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *CollectionExpressionContext) AllSIMPLENAME() []antlr.TerminalNode {
+	return s.GetTokens(grulev3ParserSIMPLENAME)
+}
+
+func (s *CollectionExpressionContext) SIMPLENAME(i int) antlr.TerminalNode {
+	return s.GetToken(grulev3ParserSIMPLENAME, i)
+}
+
+func (s *CollectionExpressionContext) LR_BRACKET() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserLR_BRACKET, 0)
+}
+
+func (s *CollectionExpressionContext) ExpressionAtom() IExpressionAtomContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionAtomContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionAtomContext)
+}
+
+func (s *CollectionExpressionContext) RR_BRACKET() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserRR_BRACKET, 0)
+}
+
+func (s *CollectionExpressionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *CollectionExpressionContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *CollectionExpressionContext) COLON() antlr.TerminalNode {
+	return s.GetToken(grulev3ParserCOLON, 0)
+}
+
+func (s *CollectionExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CollectionExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CollectionExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.EnterCollectionExpression(s)
+	}
+}
+
+func (s *CollectionExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(grulev3Listener); ok {
+		listenerT.ExitCollectionExpression(s)
+	}
+}
+
+func (s *CollectionExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case grulev3Visitor:
+		return t.VisitCollectionExpression(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *grulev3Parser) CollectionExpression() (localctx ICollectionExpressionContext) {
+	localctx = NewCollectionExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 64, grulev3ParserRULE_collectionExpression)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(319)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(320)
+		p.Match(grulev3ParserLR_BRACKET)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(321)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(322)
+		p.Match(grulev3ParserSIMPLENAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(323)
+		p.expressionAtom(0)
+	}
+	p.SetState(326)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == grulev3ParserSIMPLENAME {
+		{
+			p.SetState(324)
+			p.Match(grulev3ParserSIMPLENAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(325)
+			p.expression(0)
+		}
+
+	}
+	p.SetState(330)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == grulev3ParserCOLON {
+		{
+			p.SetState(328)
+			p.Match(grulev3ParserCOLON)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(329)
+			p.expression(0)
+		}
+
+	}
+	{
+		p.SetState(332)
+		p.Match(grulev3ParserRR_BRACKET)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
 // IConstantContext is an interface to support dynamic dispatch.
 type IConstantContext interface {
 	antlr.ParserRuleContext
@@ -3377,46 +6502,46 @@ func (s *ConstantContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *grulev3Parser) Constant() (localctx IConstantContext) {
 	localctx = NewConstantContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, grulev3ParserRULE_constant)
-	p.SetState(185)
+	p.EnterRule(localctx, 66, grulev3ParserRULE_constant)
+	p.SetState(339)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 12, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 28, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(180)
+			p.SetState(334)
 			p.StringLiteral()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(181)
+			p.SetState(335)
 			p.IntegerLiteral()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(182)
+			p.SetState(336)
 			p.FloatLiteral()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(183)
+			p.SetState(337)
 			p.BooleanLiteral()
 		}
 
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(184)
+			p.SetState(338)
 			p.Match(grulev3ParserNIL_LITERAL)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3583,13 +6708,13 @@ func (p *grulev3Parser) variable(_p int) (localctx IVariableContext) {
 	localctx = NewVariableContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IVariableContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 36
-	p.EnterRecursionRule(localctx, 36, grulev3ParserRULE_variable, _p)
+	_startState := 68
+	p.EnterRecursionRule(localctx, 68, grulev3ParserRULE_variable, _p)
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(188)
+		p.SetState(342)
 		p.Match(grulev3ParserSIMPLENAME)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3598,12 +6723,12 @@ func (p *grulev3Parser) variable(_p int) (localctx IVariableContext) {
 	}
 
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(196)
+	p.SetState(350)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 30, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -3613,38 +6738,38 @@ func (p *grulev3Parser) variable(_p int) (localctx IVariableContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(194)
+			p.SetState(348)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 
-			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 13, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 29, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewVariableContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_variable)
-				p.SetState(190)
+				p.SetState(344)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(191)
+					p.SetState(345)
 					p.MemberVariable()
 				}
 
 			case 2:
 				localctx = NewVariableContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, grulev3ParserRULE_variable)
-				p.SetState(192)
+				p.SetState(346)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(193)
+					p.SetState(347)
 					p.ArrayMapSelector()
 				}
 
@@ -3653,12 +6778,12 @@ func (p *grulev3Parser) variable(_p int) (localctx IVariableContext) {
 			}
 
 		}
-		p.SetState(198)
+		p.SetState(352)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 30, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -3781,10 +6906,10 @@ func (s *ArrayMapSelectorContext) Accept(visitor antlr.ParseTreeVisitor) interfa
 
 func (p *grulev3Parser) ArrayMapSelector() (localctx IArrayMapSelectorContext) {
 	localctx = NewArrayMapSelectorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 38, grulev3ParserRULE_arrayMapSelector)
+	p.EnterRule(localctx, 70, grulev3ParserRULE_arrayMapSelector)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(199)
+		p.SetState(353)
 		p.Match(grulev3ParserLS_BRACKET)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3792,11 +6917,11 @@ func (p *grulev3Parser) ArrayMapSelector() (localctx IArrayMapSelectorContext) {
 		}
 	}
 	{
-		p.SetState(200)
+		p.SetState(354)
 		p.expression(0)
 	}
 	{
-		p.SetState(201)
+		p.SetState(355)
 		p.Match(grulev3ParserRS_BRACKET)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3904,10 +7029,10 @@ func (s *MemberVariableContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *grulev3Parser) MemberVariable() (localctx IMemberVariableContext) {
 	localctx = NewMemberVariableContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, grulev3ParserRULE_memberVariable)
+	p.EnterRule(localctx, 72, grulev3ParserRULE_memberVariable)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(203)
+		p.SetState(357)
 		p.Match(grulev3ParserDOT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3915,7 +7040,7 @@ func (p *grulev3Parser) MemberVariable() (localctx IMemberVariableContext) {
 		}
 	}
 	{
-		p.SetState(204)
+		p.SetState(358)
 		p.Match(grulev3ParserSIMPLENAME)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4045,12 +7170,12 @@ func (s *FunctionCallContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *grulev3Parser) FunctionCall() (localctx IFunctionCallContext) {
 	localctx = NewFunctionCallContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 42, grulev3ParserRULE_functionCall)
+	p.EnterRule(localctx, 74, grulev3ParserRULE_functionCall)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(206)
+		p.SetState(360)
 		p.Match(grulev3ParserSIMPLENAME)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4058,29 +7183,29 @@ func (p *grulev3Parser) FunctionCall() (localctx IFunctionCallContext) {
 		}
 	}
 	{
-		p.SetState(207)
+		p.SetState(361)
 		p.Match(grulev3ParserLR_BRACKET)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(209)
+	p.SetState(363)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&259209881978888) != 0 {
+	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&132715451581997064) != 0 {
 		{
-			p.SetState(208)
+			p.SetState(362)
 			p.ArgumentList()
 		}
 
 	}
 	{
-		p.SetState(211)
+		p.SetState(365)
 		p.Match(grulev3ParserRR_BRACKET)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4200,10 +7325,10 @@ func (s *MethodCallContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *grulev3Parser) MethodCall() (localctx IMethodCallContext) {
 	localctx = NewMethodCallContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 44, grulev3ParserRULE_methodCall)
+	p.EnterRule(localctx, 76, grulev3ParserRULE_methodCall)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(213)
+		p.SetState(367)
 		p.Match(grulev3ParserDOT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4211,7 +7336,7 @@ func (p *grulev3Parser) MethodCall() (localctx IMethodCallContext) {
 		}
 	}
 	{
-		p.SetState(214)
+		p.SetState(368)
 		p.FunctionCall()
 	}
 
@@ -4348,15 +7473,15 @@ func (s *ArgumentListContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *grulev3Parser) ArgumentList() (localctx IArgumentListContext) {
 	localctx = NewArgumentListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 46, grulev3ParserRULE_argumentList)
+	p.EnterRule(localctx, 78, grulev3ParserRULE_argumentList)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(216)
+		p.SetState(370)
 		p.expression(0)
 	}
-	p.SetState(221)
+	p.SetState(375)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4365,7 +7490,7 @@ func (p *grulev3Parser) ArgumentList() (localctx IArgumentListContext) {
 
 	for _la == grulev3ParserT__0 {
 		{
-			p.SetState(217)
+			p.SetState(371)
 			p.Match(grulev3ParserT__0)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4373,11 +7498,11 @@ func (p *grulev3Parser) ArgumentList() (localctx IArgumentListContext) {
 			}
 		}
 		{
-			p.SetState(218)
+			p.SetState(372)
 			p.expression(0)
 		}
 
-		p.SetState(223)
+		p.SetState(377)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -4509,25 +7634,25 @@ func (s *FloatLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *grulev3Parser) FloatLiteral() (localctx IFloatLiteralContext) {
 	localctx = NewFloatLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 48, grulev3ParserRULE_floatLiteral)
-	p.SetState(226)
+	p.EnterRule(localctx, 80, grulev3ParserRULE_floatLiteral)
+	p.SetState(380)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 17, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 33, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(224)
+			p.SetState(378)
 			p.DecimalFloatLiteral()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(225)
+			p.SetState(379)
 			p.HexadecimalFloatLiteral()
 		}
 
@@ -4635,11 +7760,11 @@ func (s *DecimalFloatLiteralContext) Accept(visitor antlr.ParseTreeVisitor) inte
 
 func (p *grulev3Parser) DecimalFloatLiteral() (localctx IDecimalFloatLiteralContext) {
 	localctx = NewDecimalFloatLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 50, grulev3ParserRULE_decimalFloatLiteral)
+	p.EnterRule(localctx, 82, grulev3ParserRULE_decimalFloatLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(229)
+	p.SetState(383)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4648,7 +7773,7 @@ func (p *grulev3Parser) DecimalFloatLiteral() (localctx IDecimalFloatLiteralCont
 
 	if _la == grulev3ParserMINUS {
 		{
-			p.SetState(228)
+			p.SetState(382)
 			p.Match(grulev3ParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4658,7 +7783,7 @@ func (p *grulev3Parser) DecimalFloatLiteral() (localctx IDecimalFloatLiteralCont
 
 	}
 	{
-		p.SetState(231)
+		p.SetState(385)
 		p.Match(grulev3ParserDECIMAL_FLOAT_LIT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4766,11 +7891,11 @@ func (s *HexadecimalFloatLiteralContext) Accept(visitor antlr.ParseTreeVisitor)
 
 func (p *grulev3Parser) HexadecimalFloatLiteral() (localctx IHexadecimalFloatLiteralContext) {
 	localctx = NewHexadecimalFloatLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 52, grulev3ParserRULE_hexadecimalFloatLiteral)
+	p.EnterRule(localctx, 84, grulev3ParserRULE_hexadecimalFloatLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(234)
+	p.SetState(388)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4779,7 +7904,7 @@ func (p *grulev3Parser) HexadecimalFloatLiteral() (localctx IHexadecimalFloatLit
 
 	if _la == grulev3ParserMINUS {
 		{
-			p.SetState(233)
+			p.SetState(387)
 			p.Match(grulev3ParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4789,7 +7914,7 @@ func (p *grulev3Parser) HexadecimalFloatLiteral() (localctx IHexadecimalFloatLit
 
 	}
 	{
-		p.SetState(236)
+		p.SetState(390)
 		p.Match(grulev3ParserHEX_FLOAT_LIT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -4938,32 +8063,32 @@ func (s *IntegerLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *grulev3Parser) IntegerLiteral() (localctx IIntegerLiteralContext) {
 	localctx = NewIntegerLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 54, grulev3ParserRULE_integerLiteral)
-	p.SetState(241)
+	p.EnterRule(localctx, 86, grulev3ParserRULE_integerLiteral)
+	p.SetState(395)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 20, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 36, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(238)
+			p.SetState(392)
 			p.DecimalLiteral()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(239)
+			p.SetState(393)
 			p.HexadecimalLiteral()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(240)
+			p.SetState(394)
 			p.OctalLiteral()
 		}
 
@@ -5071,11 +8196,11 @@ func (s *DecimalLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *grulev3Parser) DecimalLiteral() (localctx IDecimalLiteralContext) {
 	localctx = NewDecimalLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 56, grulev3ParserRULE_decimalLiteral)
+	p.EnterRule(localctx, 88, grulev3ParserRULE_decimalLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(244)
+	p.SetState(398)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -5084,7 +8209,7 @@ func (p *grulev3Parser) DecimalLiteral() (localctx IDecimalLiteralContext) {
 
 	if _la == grulev3ParserMINUS {
 		{
-			p.SetState(243)
+			p.SetState(397)
 			p.Match(grulev3ParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -5094,7 +8219,7 @@ func (p *grulev3Parser) DecimalLiteral() (localctx IDecimalLiteralContext) {
 
 	}
 	{
-		p.SetState(246)
+		p.SetState(400)
 		p.Match(grulev3ParserDEC_LIT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5202,11 +8327,11 @@ func (s *HexadecimalLiteralContext) Accept(visitor antlr.ParseTreeVisitor) inter
 
 func (p *grulev3Parser) HexadecimalLiteral() (localctx IHexadecimalLiteralContext) {
 	localctx = NewHexadecimalLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 58, grulev3ParserRULE_hexadecimalLiteral)
+	p.EnterRule(localctx, 90, grulev3ParserRULE_hexadecimalLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(249)
+	p.SetState(403)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -5215,7 +8340,7 @@ func (p *grulev3Parser) HexadecimalLiteral() (localctx IHexadecimalLiteralContex
 
 	if _la == grulev3ParserMINUS {
 		{
-			p.SetState(248)
+			p.SetState(402)
 			p.Match(grulev3ParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -5225,7 +8350,7 @@ func (p *grulev3Parser) HexadecimalLiteral() (localctx IHexadecimalLiteralContex
 
 	}
 	{
-		p.SetState(251)
+		p.SetState(405)
 		p.Match(grulev3ParserHEX_LIT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5333,11 +8458,11 @@ func (s *OctalLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *grulev3Parser) OctalLiteral() (localctx IOctalLiteralContext) {
 	localctx = NewOctalLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 60, grulev3ParserRULE_octalLiteral)
+	p.EnterRule(localctx, 92, grulev3ParserRULE_octalLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(254)
+	p.SetState(408)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -5346,7 +8471,7 @@ func (p *grulev3Parser) OctalLiteral() (localctx IOctalLiteralContext) {
 
 	if _la == grulev3ParserMINUS {
 		{
-			p.SetState(253)
+			p.SetState(407)
 			p.Match(grulev3ParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -5356,7 +8481,7 @@ func (p *grulev3Parser) OctalLiteral() (localctx IOctalLiteralContext) {
 
 	}
 	{
-		p.SetState(256)
+		p.SetState(410)
 		p.Match(grulev3ParserOCT_LIT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -5464,12 +8589,12 @@ func (s *StringLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{
 
 func (p *grulev3Parser) StringLiteral() (localctx IStringLiteralContext) {
 	localctx = NewStringLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 62, grulev3ParserRULE_stringLiteral)
+	p.EnterRule(localctx, 94, grulev3ParserRULE_stringLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(258)
+		p.SetState(412)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == grulev3ParserDQUOTA_STRING || _la == grulev3ParserSQUOTA_STRING) {
@@ -5580,12 +8705,12 @@ func (s *BooleanLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *grulev3Parser) BooleanLiteral() (localctx IBooleanLiteralContext) {
 	localctx = NewBooleanLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 64, grulev3ParserRULE_booleanLiteral)
+	p.EnterRule(localctx, 96, grulev3ParserRULE_booleanLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(260)
+		p.SetState(414)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == grulev3ParserTRUE || _la == grulev3ParserFALSE) {
@@ -5611,21 +8736,21 @@ errorExit:
 
 func (p *grulev3Parser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 10:
+	case 25:
 		var t *ExpressionContext = nil
 		if localctx != nil {
 			t = localctx.(*ExpressionContext)
 		}
 		return p.Expression_Sempred(t, predIndex)
 
-	case 16:
+	case 31:
 		var t *ExpressionAtomContext = nil
 		if localctx != nil {
 			t = localctx.(*ExpressionAtomContext)
 		}
 		return p.ExpressionAtom_Sempred(t, predIndex)
 
-	case 18:
+	case 34:
 		var t *VariableContext = nil
 		if localctx != nil {
 			t = localctx.(*VariableContext)
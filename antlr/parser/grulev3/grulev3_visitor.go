@@ -13,9 +13,36 @@ type grulev3Visitor interface {
 	// Visit a parse tree produced by grulev3Parser#ruleEntry.
 	VisitRuleEntry(ctx *RuleEntryContext) interface{}
 
+	// Visit a parse tree produced by grulev3Parser#ruleExtends.
+	VisitRuleExtends(ctx *RuleExtendsContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#ruleAnnotation.
+	VisitRuleAnnotation(ctx *RuleAnnotationContext) interface{}
+
 	// Visit a parse tree produced by grulev3Parser#salience.
 	VisitSalience(ctx *SalienceContext) interface{}
 
+	// Visit a parse tree produced by grulev3Parser#ruleAttribute.
+	VisitRuleAttribute(ctx *RuleAttributeContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#agendaGroup.
+	VisitAgendaGroup(ctx *AgendaGroupContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#noLoop.
+	VisitNoLoop(ctx *NoLoopContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#lockOnActive.
+	VisitLockOnActive(ctx *LockOnActiveContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#dateEffective.
+	VisitDateEffective(ctx *DateEffectiveContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#dateExpires.
+	VisitDateExpires(ctx *DateExpiresContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#ruleEnabled.
+	VisitRuleEnabled(ctx *RuleEnabledContext) interface{}
+
 	// Visit a parse tree produced by grulev3Parser#ruleName.
 	VisitRuleName(ctx *RuleNameContext) interface{}
 
@@ -31,12 +58,30 @@ type grulev3Visitor interface {
 	// Visit a parse tree produced by grulev3Parser#thenExpressionList.
 	VisitThenExpressionList(ctx *ThenExpressionListContext) interface{}
 
+	// Visit a parse tree produced by grulev3Parser#thenStatement.
+	VisitThenStatement(ctx *ThenStatementContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#ifStatement.
+	VisitIfStatement(ctx *IfStatementContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#elseStatement.
+	VisitElseStatement(ctx *ElseStatementContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#forStatement.
+	VisitForStatement(ctx *ForStatementContext) interface{}
+
+	// Visit a parse tree produced by grulev3Parser#thenBlock.
+	VisitThenBlock(ctx *ThenBlockContext) interface{}
+
 	// Visit a parse tree produced by grulev3Parser#thenExpression.
 	VisitThenExpression(ctx *ThenExpressionContext) interface{}
 
 	// Visit a parse tree produced by grulev3Parser#assignment.
 	VisitAssignment(ctx *AssignmentContext) interface{}
 
+	// Visit a parse tree produced by grulev3Parser#letStatement.
+	VisitLetStatement(ctx *LetStatementContext) interface{}
+
 	// Visit a parse tree produced by grulev3Parser#expression.
 	VisitExpression(ctx *ExpressionContext) interface{}
 
@@ -58,6 +103,9 @@ type grulev3Visitor interface {
 	// Visit a parse tree produced by grulev3Parser#expressionAtom.
 	VisitExpressionAtom(ctx *ExpressionAtomContext) interface{}
 
+	// Visit a parse tree produced by grulev3Parser#collectionExpression.
+	VisitCollectionExpression(ctx *CollectionExpressionContext) interface{}
+
 	// Visit a parse tree produced by grulev3Parser#constant.
 	VisitConstant(ctx *ConstantContext) interface{}
 
@@ -0,0 +1,42 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+// DefaultAgendaGroup is the agenda group of rule entries that do not declare an agenda group.
+// It is always at the bottom of the focus stack.
+const DefaultAgendaGroup = "MAIN"
+
+// NewAgendaGroup create new AgendaGroup AST object
+func NewAgendaGroup() *AgendaGroup {
+
+	return &AgendaGroup{
+		GroupName: DefaultAgendaGroup,
+	}
+}
+
+// AgendaGroup is a simple AST object that stores the name of the agenda group of a rule entry
+type AgendaGroup struct {
+	GroupName string
+}
+
+// AgendaGroupReceiver must be implemented by any AST object that stores agenda group
+type AgendaGroupReceiver interface {
+	AcceptAgendaGroup(agendaGroup *AgendaGroup) error
+}
+
+// AcceptStringLiteral accept the assigned string
+func (ag *AgendaGroup) AcceptStringLiteral(lit *StringLiteral) {
+	ag.GroupName = lit.String
+}
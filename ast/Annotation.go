@@ -0,0 +1,40 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+// NewAnnotation create new Annotation AST object
+func NewAnnotation(name string) *Annotation {
+
+	return &Annotation{
+		Name:   name,
+		Values: make([]string, 0),
+	}
+}
+
+// Annotation is a simple AST object that stores an annotation of a rule entry, e.g. @tags("kyc", "eu")
+type Annotation struct {
+	Name   string
+	Values []string
+}
+
+// AnnotationReceiver must be implemented by any AST object that stores annotations
+type AnnotationReceiver interface {
+	AcceptAnnotation(annotation *Annotation) error
+}
+
+// AcceptStringLiteral accept the next value of the annotation
+func (an *Annotation) AcceptStringLiteral(lit *StringLiteral) {
+	an.Values = append(an.Values, lit.String)
+}
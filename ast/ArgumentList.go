@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"reflect"
 
@@ -130,9 +131,15 @@ type ArgumentListReceiver interface {
 
 // Evaluate will evaluate this AST graph for when scope evaluation
 func (e *ArgumentList) Evaluate(dataContext IDataContext, memory *WorkingMemory) ([]reflect.Value, error) {
+
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *ArgumentList) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) ([]reflect.Value, error) {
 	values := make([]reflect.Value, len(e.Arguments))
 	for i, exp := range e.Arguments {
-		val, err := exp.Evaluate(dataContext, memory)
+		val, err := exp.EvaluateWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return values, err
@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
@@ -35,9 +36,11 @@ type ArrayMapSelector struct {
 	AstID   string
 	GrlText string
 
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
 	Expression *Expression
 
-	Value reflect.Value
 }
 
 // MakeCatalog will create a catalog entry from ArrayMapSelector node.
@@ -65,8 +68,9 @@ type ArrayMapSelectorReceiver interface {
 // Clone will clone this ArgumentList. The new clone will have an identical structure
 func (e *ArrayMapSelector) Clone(cloneTable *pkg.CloneTable) *ArrayMapSelector {
 	clone := &ArrayMapSelector{
-		AstID:   unique.NewID(),
-		GrlText: e.GrlText,
+		AstID:    unique.NewID(),
+		GrlText:  e.GrlText,
+		Position: e.Position,
 	}
 	if e.Expression != nil {
 		if cloneTable.IsCloned(e.Expression.AstID) {
@@ -125,15 +129,15 @@ func (e *ArrayMapSelector) SetGrlText(grlText string) {
 
 // Evaluate will evaluate this AST graph for when scope evaluation
 func (e *ArrayMapSelector) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
-	if e.Expression != nil {
-		val, err := e.Expression.Evaluate(dataContext, memory)
-		if err != nil {
 
-			return val, err
-		}
-		e.Value = val
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *ArrayMapSelector) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	if e.Expression != nil {
 
-		return val, err
+		return e.Expression.EvaluateWithContext(ctx, dataContext, memory)
 	}
 
 	return reflect.ValueOf(nil), fmt.Errorf("array Map Selector contains no selector expression")
@@ -16,9 +16,11 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"reflect"
 )
 
 // NewAssignment will create new instance of Assignment AST Node
@@ -33,6 +35,9 @@ type Assignment struct {
 	AstID   string
 	GrlText string
 
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
 	Variable      *Variable
 	Expression    *Expression
 	IsAssign      bool
@@ -40,6 +45,8 @@ type Assignment struct {
 	IsMinusAssign bool
 	IsDivAssign   bool
 	IsMulAssign   bool
+	// IsLet is set when the assignment declares a local variable of the rule entry, e.g. let discount = Order.Total * 0.1
+	IsLet bool
 }
 
 // MakeCatalog will create a catalog entry from Assignment node.
@@ -65,6 +72,7 @@ func (e *Assignment) MakeCatalog(cat *Catalog) {
 		meta.IsMinusAssign = e.IsMinusAssign
 		meta.IsDivAssign = e.IsDivAssign
 		meta.IsMulAssign = e.IsMulAssign
+		meta.IsLet = e.IsLet
 	}
 }
 
@@ -76,8 +84,9 @@ type AssignmentReceiver interface {
 // Clone will clone this Assignment. The new clone will have an identical structure
 func (e *Assignment) Clone(cloneTable *pkg.CloneTable) *Assignment {
 	clone := &Assignment{
-		AstID:   unique.NewID(),
-		GrlText: e.GrlText,
+		AstID:    unique.NewID(),
+		GrlText:  e.GrlText,
+		Position: e.Position,
 	}
 	if e.Variable != nil {
 		if cloneTable.IsCloned(e.Variable.AstID) {
@@ -102,6 +111,7 @@ func (e *Assignment) Clone(cloneTable *pkg.CloneTable) *Assignment {
 	clone.IsMinusAssign = e.IsMinusAssign
 	clone.IsMulAssign = e.IsMulAssign
 	clone.IsPlusAssign = e.IsPlusAssign
+	clone.IsLet = e.IsLet
 
 	return clone
 }
@@ -144,6 +154,9 @@ func (e *Assignment) GetSnapshot() string {
 	var buff bytes.Buffer
 	buff.WriteString(ASSIGMENT)
 	buff.WriteString("(")
+	if e.IsLet {
+		buff.WriteString("let ")
+	}
 	buff.WriteString(e.Variable.GetSnapshot())
 	if e.IsAssign {
 		buff.WriteString("=")
@@ -174,14 +187,64 @@ func (e *Assignment) SetGrlText(grlText string) {
 
 // Execute will execute this graph in the Then scope
 func (e *Assignment) Execute(dataContext IDataContext, memory *WorkingMemory) error {
-	exprVal, err := e.Expression.Evaluate(dataContext, memory)
+
+	return e.ExecuteWithContext(context.Background(), dataContext, memory)
+}
+
+// ExecuteWithContext is Execute with the context of the execution, which carries its limits and function registries.
+func (e *Assignment) ExecuteWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+	if limiter := executionLimiterOf(ctx); limiter != nil {
+		if err := limiter.CountAssignment(); err != nil {
+
+			return err
+		}
+	}
+	tracer := memory.GetTracer()
+	if tracer == nil {
+
+		return e.execute(ctx, dataContext, memory)
+	}
+	before := e.peekVariable(ctx, dataContext, memory)
+	err := e.execute(ctx, dataContext, memory)
+	if err == nil {
+		tracer.TraceAssignment(e, before, e.peekVariable(ctx, dataContext, memory))
+	}
+
+	return err
+}
+
+// peekVariable returns a copy of the current value of the assigned variable, or invalid value if it can not be evaluated.
+func (e *Assignment) peekVariable(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (val reflect.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			val = reflect.Value{}
+		}
+	}()
+	val, err := e.Variable.EvaluateWithContext(ctx, dataContext, memory)
+	if err != nil || !val.IsValid() || !val.CanInterface() {
+
+		return reflect.Value{}
+	}
+
+	// the value may refer to the variable's storage, copy it so it does not change along with the assignment.
+	return reflect.ValueOf(val.Interface())
+}
+
+func (e *Assignment) execute(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+	exprVal, err := e.Expression.EvaluateWithContext(ctx, dataContext, memory)
 	if err != nil {
 		return err
 	}
+
+	return e.assign(ctx, exprVal, dataContext, memory)
+}
+
+// assign assigns the evaluated expression value into the variable, applying the assignment operator.
+func (e *Assignment) assign(ctx context.Context, exprVal reflect.Value, dataContext IDataContext, memory *WorkingMemory) error {
 	if e.IsAssign {
-		return e.Variable.Assign(exprVal, dataContext, memory)
+		return e.Variable.AssignWithContext(ctx, exprVal, dataContext, memory)
 	}
-	varval, err := e.Variable.Evaluate(dataContext, memory)
+	varval, err := e.Variable.EvaluateWithContext(ctx, dataContext, memory)
 	if err != nil {
 		return err
 	}
@@ -192,7 +255,7 @@ func (e *Assignment) Execute(dataContext IDataContext, memory *WorkingMemory) er
 			return err
 		}
 
-		return e.Variable.Assign(nval, dataContext, memory)
+		return e.Variable.AssignWithContext(ctx, nval, dataContext, memory)
 	}
 	if e.IsMinusAssign {
 		nval, err := pkg.EvaluateSubtraction(varval, exprVal)
@@ -201,7 +264,7 @@ func (e *Assignment) Execute(dataContext IDataContext, memory *WorkingMemory) er
 			return err
 		}
 
-		return e.Variable.Assign(nval, dataContext, memory)
+		return e.Variable.AssignWithContext(ctx, nval, dataContext, memory)
 	}
 	if e.IsMulAssign {
 		nval, err := pkg.EvaluateMultiplication(varval, exprVal)
@@ -210,7 +273,7 @@ func (e *Assignment) Execute(dataContext IDataContext, memory *WorkingMemory) er
 			return err
 		}
 
-		return e.Variable.Assign(nval, dataContext, memory)
+		return e.Variable.AssignWithContext(ctx, nval, dataContext, memory)
 	}
 	if e.IsDivAssign {
 		nval, err := pkg.EvaluateDivision(varval, exprVal)
@@ -219,7 +282,7 @@ func (e *Assignment) Execute(dataContext IDataContext, memory *WorkingMemory) er
 			return err
 		}
 
-		return e.Variable.Assign(nval, dataContext, memory)
+		return e.Variable.AssignWithContext(ctx, nval, dataContext, memory)
 	}
 
 	return nil
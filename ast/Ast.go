@@ -28,14 +28,20 @@ const (
 	MAPARRAYSELECTOR = "MAS"
 	// ASSIGMENT signature for assignment snapshot
 	ASSIGMENT = "AS"
+	// COLLECTIONEXPRESSION signature for collection expression snapshot
+	COLLECTIONEXPRESSION = "CE"
 	// CONSTANT signature for constant snapshot
 	CONSTANT = "C"
 	// EXPRESSION signature for expression snapshot
 	EXPRESSION = "E"
 	// EXPRESSIONATOM signature for expression atom snapshot
 	EXPRESSIONATOM = "A"
+	// FORSTATEMENT signature for for statement snapshot
+	FORSTATEMENT = "FOR"
 	// FUNCTIONCALL signature for function call snapshot
 	FUNCTIONCALL = "F"
+	// IFSTATEMENT signature for if statement snapshot
+	IFSTATEMENT = "IF"
 	// RULEENTRY signature for rule entry snapshot
 	RULEENTRY = "R"
 	// THENEXPRESSION signature for then expression snapshot
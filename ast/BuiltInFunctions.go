@@ -15,10 +15,12 @@
 package ast
 
 import (
+	"fmt"
 	"math"
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hyperjumptech/grule-rule-engine/logger"
@@ -41,6 +43,28 @@ type BuiltInFunctions struct {
 	Knowledge     *KnowledgeBase
 	WorkingMemory *WorkingMemory
 	DataContext   IDataContext
+	// Clock gives the time returned by Now and NowIn. If nil, the SystemClock is used.
+	Clock Clock
+}
+
+// locations caches the time zones loaded by name.
+var locations sync.Map
+
+// loadLocation returns the time zone of the name, e.g. "Asia/Jakarta", "UTC" or "Local".
+// As the built-in functions can not return an error, it panics on an unknown time zone, the engine reports the panic
+// as an error of the rule.
+func loadLocation(name string) *time.Location {
+	if loc, ok := locations.Load(name); ok {
+
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(fmt.Errorf("unknown time zone %s. got %w", name, err))
+	}
+	locations.Store(name, loc)
+
+	return loc
 }
 
 // Complete will cause the engine to stop processing further rules in the current cycle.
@@ -54,6 +78,12 @@ func (gf *BuiltInFunctions) MakeTime(year, month, day, hour, minute, second int6
 	return time.Date(int(year), time.Month(month), int(day), int(hour), int(minute), int(second), 0, time.Local)
 }
 
+// MakeTimeIn will create a Time struct according to the argument values, in the time zone, e.g. "Asia/Jakarta".
+func (gf *BuiltInFunctions) MakeTimeIn(year, month, day, hour, minute, second int64, timezone string) time.Time {
+
+	return time.Date(int(year), time.Month(month), int(day), int(hour), int(minute), int(second), 0, loadLocation(timezone))
+}
+
 // Changed is another name for Forget function. This function is retained for backward compatibility reason and will be removed in the future.
 func (gf *BuiltInFunctions) Changed(variableName string) {
 	gf.WorkingMemory.Reset(variableName)
@@ -70,10 +100,20 @@ func (gf *BuiltInFunctions) Forget(snippet string) {
 	gf.Knowledge.DataContext.IncrementVariableChangeCount()
 }
 
-// Now is an extension tn time.Now().
+// Now will get the current time from the Clock, by default an extension to time.Now().
 func (gf *BuiltInFunctions) Now() time.Time {
+	if gf.Clock == nil {
+
+		return SystemClock.Now()
+	}
 
-	return time.Now()
+	return gf.Clock.Now()
+}
+
+// NowIn will get the current time from the Clock, in the time zone, e.g. "Asia/Jakarta".
+func (gf *BuiltInFunctions) NowIn(timezone string) time.Time {
+
+	return gf.Now().In(loadLocation(timezone))
 }
 
 // Log extension to log.Print
@@ -141,6 +181,16 @@ func (gf *BuiltInFunctions) Retract(ruleName string) {
 	gf.Knowledge.RetractRule(ruleName)
 }
 
+// SetFocus will give the focus to an agenda group, so from the next cycle only the rules of that group are candidates.
+func (gf *BuiltInFunctions) SetFocus(agendaGroup string) {
+	gf.Knowledge.SetFocus(agendaGroup)
+}
+
+// PopFocus will give the focus back to the agenda group that had it before the current one.
+func (gf *BuiltInFunctions) PopFocus() {
+	gf.Knowledge.PopFocus()
+}
+
 // GetTimeYear will get the year value of time
 func (gf *BuiltInFunctions) GetTimeYear(time time.Time) int {
 
@@ -195,6 +245,36 @@ func (gf *BuiltInFunctions) TimeFormat(time time.Time, layout string) string {
 	return time.Format(layout)
 }
 
+// AddDays will add a number of days, possibly negative, to a time. The time of the day is kept across
+// daylight saving time changes.
+func (gf *BuiltInFunctions) AddDays(time time.Time, days int64) time.Time {
+
+	return time.AddDate(0, 0, int(days))
+}
+
+// AddDuration will add a duration, possibly negative, to a time.
+func (gf *BuiltInFunctions) AddDuration(time time.Time, duration time.Duration) time.Time {
+
+	return time.Add(duration)
+}
+
+// DurationBetween will get the duration from the 1st argument to the 2nd argument, negative if the 2nd is before.
+func (gf *BuiltInFunctions) DurationBetween(from, to time.Time) time.Duration {
+
+	return to.Sub(from)
+}
+
+// ParseDuration will parse a duration such as "36h" or "1h30m", as time.ParseDuration. It panics on an invalid
+// duration, the engine reports the panic as an error of the rule.
+func (gf *BuiltInFunctions) ParseDuration(duration string) time.Duration {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
 // Max will pick the biggest of value in the arguments
 func (gf *BuiltInFunctions) Max(vals ...float64) float64 {
 	val := float64(0)
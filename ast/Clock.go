@@ -0,0 +1,34 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import "time"
+
+// Clock gives the current time to the rule engine. Tests may use a fixed clock to get reproducible executions.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to use an ordinary function as a Clock, e.g. ClockFunc(func() time.Time { return fixed })
+type ClockFunc func() time.Time
+
+// Now returns the time given by the function.
+func (f ClockFunc) Now() time.Time {
+
+	return f()
+}
+
+// SystemClock is the Clock reading the time of the system.
+var SystemClock Clock = ClockFunc(time.Now)
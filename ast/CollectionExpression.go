@@ -0,0 +1,488 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
+	"github.com/hyperjumptech/grule-rule-engine/model"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+const (
+	// CollectionExists is true if any item satisfies the predicate
+	CollectionExists int = iota
+	// CollectionForall is true if all items satisfy the predicate
+	CollectionForall
+	// CollectionCount is the number of items satisfying the predicate
+	CollectionCount
+	// CollectionSum is the sum of the values of the items
+	CollectionSum
+	// CollectionMin is the smallest value of the items
+	CollectionMin
+	// CollectionMax is the biggest value of the items
+	CollectionMax
+)
+
+// collectionOperators maps the operator names used in GRL, in lower case, to the operators.
+var collectionOperators = map[string]int{
+	"exists": CollectionExists,
+	"any":    CollectionExists,
+	"forall": CollectionForall,
+	"all":    CollectionForall,
+	"count":  CollectionCount,
+	"sum":    CollectionSum,
+	"min":    CollectionMin,
+	"max":    CollectionMax,
+}
+
+// GetCollectionOperator returns the collection operator of the name used in GRL, e.g. "exists" or "sum".
+func GetCollectionOperator(name string) (int, bool) {
+	operator, ok := collectionOperators[strings.ToLower(name)]
+
+	return operator, ok
+}
+
+// NewCollectionExpression creates new instance of CollectionExpression
+func NewCollectionExpression() *CollectionExpression {
+
+	return &CollectionExpression{
+		AstID: unique.NewID(),
+	}
+}
+
+// CollectionExpression AST graph node. It evaluates an expression for every item of an array or a map,
+// e.g. exists(i in Order.Items : i.Price > 100) or sum(i in Order.Items where i.Taxed : i.Price).
+// The item is available to the Filter and the Expression under VariableName, it hides a fact with the same name.
+type CollectionExpression struct {
+	AstID   string
+	GrlText string
+
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
+	Operator     int
+	VariableName string
+	Collection   *ExpressionAtom
+	// Filter, if set, selects the items the Expression is evaluated for.
+	Filter *Expression
+	// Expression is the predicate of exists, forall and count, or the value of sum, min and max.
+	// If not set, count counts the items, exists checks if there is any item, and sum, min and max use the items.
+	Expression *Expression
+
+	// HasFilter is set by the ANTLR4 listener, the first expression it gives is the Filter if set.
+	HasFilter bool
+}
+
+// MakeCatalog will create a catalog entry from CollectionExpression node.
+func (e *CollectionExpression) MakeCatalog(cat *Catalog) {
+	meta := &CollectionExpressionMeta{
+		NodeMeta: NodeMeta{
+			AstID:    e.AstID,
+			GrlText:  e.GrlText,
+			Snapshot: e.GetSnapshot(),
+		},
+	}
+	if cat.AddMeta(e.AstID, meta) {
+		meta.Operator = e.Operator
+		meta.VariableName = e.VariableName
+		if e.Collection != nil {
+			meta.CollectionID = e.Collection.AstID
+			e.Collection.MakeCatalog(cat)
+		}
+		if e.Filter != nil {
+			meta.FilterID = e.Filter.AstID
+			e.Filter.MakeCatalog(cat)
+		}
+		if e.Expression != nil {
+			meta.ExpressionID = e.Expression.AstID
+			e.Expression.MakeCatalog(cat)
+		}
+	}
+}
+
+// Clone will clone this CollectionExpression. The new clone will have an identical structure
+func (e *CollectionExpression) Clone(cloneTable *pkg.CloneTable) *CollectionExpression {
+	clone := &CollectionExpression{
+		AstID:        unique.NewID(),
+		GrlText:      e.GrlText,
+		Position:     e.Position,
+		Operator:     e.Operator,
+		VariableName: e.VariableName,
+		HasFilter:    e.HasFilter,
+	}
+
+	if e.Collection != nil {
+		if cloneTable.IsCloned(e.Collection.AstID) {
+			clone.Collection = cloneTable.Records[e.Collection.AstID].CloneInstance.(*ExpressionAtom)
+		} else {
+			cloned := e.Collection.Clone(cloneTable)
+			clone.Collection = cloned
+			cloneTable.MarkCloned(e.Collection.AstID, cloned.AstID, e.Collection, cloned)
+		}
+	}
+
+	if e.Filter != nil {
+		if cloneTable.IsCloned(e.Filter.AstID) {
+			clone.Filter = cloneTable.Records[e.Filter.AstID].CloneInstance.(*Expression)
+		} else {
+			cloned := e.Filter.Clone(cloneTable)
+			clone.Filter = cloned
+			cloneTable.MarkCloned(e.Filter.AstID, cloned.AstID, e.Filter, cloned)
+		}
+	}
+
+	if e.Expression != nil {
+		if cloneTable.IsCloned(e.Expression.AstID) {
+			clone.Expression = cloneTable.Records[e.Expression.AstID].CloneInstance.(*Expression)
+		} else {
+			cloned := e.Expression.Clone(cloneTable)
+			clone.Expression = cloned
+			cloneTable.MarkCloned(e.Expression.AstID, cloned.AstID, e.Expression, cloned)
+		}
+	}
+
+	return clone
+}
+
+// CollectionExpressionReceiver should be implemented by AST graph node to receive a CollectionExpression AST graph node
+type CollectionExpressionReceiver interface {
+	AcceptCollectionExpression(collection *CollectionExpression) error
+}
+
+// AcceptExpressionAtom will accept the collection ExpressionAtom AST graph into this ast graph
+func (e *CollectionExpression) AcceptExpressionAtom(atom *ExpressionAtom) error {
+	if e.Collection != nil {
+
+		return errors.New("collection for CollectionExpression already assigned")
+	}
+	e.Collection = atom
+
+	return nil
+}
+
+// AcceptExpression will accept the filter, then the expression AST graph into this ast graph
+func (e *CollectionExpression) AcceptExpression(exp *Expression) error {
+	if e.HasFilter && e.Filter == nil {
+		e.Filter = exp
+
+		return nil
+	}
+	if e.Expression != nil {
+
+		return errors.New("expression for CollectionExpression already assigned")
+	}
+	e.Expression = exp
+
+	return nil
+}
+
+// GetAstID get the UUID asigned for this AST graph node
+func (e *CollectionExpression) GetAstID() string {
+
+	return e.AstID
+}
+
+// GetGrlText get the expression syntax related to this graph when it wast constructed
+func (e *CollectionExpression) GetGrlText() string {
+
+	return e.GrlText
+}
+
+// GetSnapshot will create a structure signature or AST graph
+func (e *CollectionExpression) GetSnapshot() string {
+	var buff bytes.Buffer
+	buff.WriteString(COLLECTIONEXPRESSION)
+	buff.WriteString(fmt.Sprintf("(o:%d,n:%s", e.Operator, e.VariableName))
+	if e.Collection != nil {
+		buff.WriteString(",in:")
+		buff.WriteString(e.Collection.GetSnapshot())
+	}
+	if e.Filter != nil {
+		buff.WriteString(",where:")
+		buff.WriteString(e.Filter.GetSnapshot())
+	}
+	if e.Expression != nil {
+		buff.WriteString(",:")
+		buff.WriteString(e.Expression.GetSnapshot())
+	}
+	buff.WriteString(")")
+
+	return buff.String()
+}
+
+// SetGrlText set the expression syntax related to this graph when it was constructed. Only ANTLR4 listener should
+// call this function.
+func (e *CollectionExpression) SetGrlText(grlText string) {
+	e.GrlText = grlText
+}
+
+// collectionDataContext is the data context the Filter and the Expression are evaluated with,
+// it gives the current item under the variable name.
+type collectionDataContext struct {
+	IDataContext
+	name string
+	item model.ValueNode
+}
+
+// Get returns the current item for the variable name, or the fact from the data context.
+func (ctx *collectionDataContext) Get(key string) model.ValueNode {
+	if key == ctx.name {
+
+		return ctx.item
+	}
+
+	return ctx.IDataContext.Get(key)
+}
+
+// IsRetracted returns false for the variable name, as the current item is not a fact.
+func (ctx *collectionDataContext) IsRetracted(key string) bool {
+	if key == ctx.name {
+
+		return false
+	}
+
+	return ctx.IDataContext.IsRetracted(key)
+}
+
+// Evaluate will evaluate this AST graph for when scope evaluation
+func (e *CollectionExpression) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *CollectionExpression) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	_, collectionNode, err := e.Collection.evaluateValueNode(ctx, dataContext, memory)
+	if err != nil {
+
+		return reflect.Value{}, err
+	}
+	items, err := collectionItems(collectionNode)
+	if err != nil {
+
+		return reflect.Value{}, fmt.Errorf("can not evaluate %s. got %w", e.GrlText, err)
+	}
+
+	aggregate := newCollectionAggregate(e.Operator)
+	for _, item := range items {
+		// The filter and the expression are shared by all the items, and by the rule entries evaluated concurrently,
+		// every item is evaluated in a frame of its own, so no lock of the frame of the memory is taken while iterating.
+		itemMemory := memory.itemMemory()
+		itemContext := &collectionDataContext{IDataContext: dataContext, name: e.VariableName, item: item}
+		if e.Filter != nil {
+			selected, err := e.Filter.EvaluateWithContext(ctx, itemContext, itemMemory)
+			if err != nil {
+
+				return reflect.Value{}, err
+			}
+			if selected.Kind() != reflect.Bool {
+
+				return reflect.Value{}, fmt.Errorf("filter of %s is not a boolean", e.GrlText)
+			}
+			if !selected.Bool() {
+				continue
+			}
+		}
+		value := item.Value()
+		if e.Expression != nil {
+			value, err = e.Expression.EvaluateWithContext(ctx, itemContext, itemMemory)
+			if err != nil {
+
+				return reflect.Value{}, err
+			}
+		}
+		done, err := aggregate.add(value)
+		if err != nil {
+
+			return reflect.Value{}, fmt.Errorf("can not evaluate %s. got %w", e.GrlText, err)
+		}
+		if done {
+			break
+		}
+	}
+
+	result, err := aggregate.result()
+	if err != nil {
+
+		return reflect.Value{}, fmt.Errorf("can not evaluate %s. got %w", e.GrlText, err)
+	}
+
+	return result, nil
+}
+
+// collectionItems returns the value nodes of the items of an array, or of the values of a map ordered by their keys.
+func collectionItems(collection model.ValueNode) ([]model.ValueNode, error) {
+	switch {
+	case collection.IsArray():
+		length, err := collection.Length()
+		if err != nil {
+
+			return nil, err
+		}
+		items := make([]model.ValueNode, length)
+		for i := range items {
+			items[i], err = collection.GetChildNodeByIndex(i)
+			if err != nil {
+
+				return nil, err
+			}
+		}
+
+		return items, nil
+	case collection.IsMap():
+		keys := collection.Value().MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		items := make([]model.ValueNode, len(keys))
+		for i, key := range keys {
+			item, err := collection.GetChildNodeBySelector(key)
+			if err != nil {
+
+				return nil, err
+			}
+			items[i] = item
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("%s is not an array nor a map", collection.IdentifiedAs())
+}
+
+// collectionAggregate accumulates the values of the items for a collection operator.
+type collectionAggregate struct {
+	operator int
+	found    bool
+	count    int64
+	integer  int64
+	real     float64
+	isReal   bool
+	extreme  reflect.Value
+}
+
+func newCollectionAggregate(operator int) *collectionAggregate {
+
+	return &collectionAggregate{
+		operator: operator,
+		found:    operator == CollectionForall,
+	}
+}
+
+// add takes the value of an item into account, it returns true if the result is known without the next items.
+func (a *collectionAggregate) add(value reflect.Value) (bool, error) {
+	switch a.operator {
+	case CollectionExists, CollectionForall, CollectionCount:
+		if !value.IsValid() || value.Kind() != reflect.Bool {
+			if a.operator == CollectionExists || a.operator == CollectionCount {
+				// without a predicate, every item is counted.
+				a.found = true
+				a.count++
+
+				return a.operator == CollectionExists, nil
+			}
+
+			return true, fmt.Errorf("predicate is of type %s, not a boolean", valueTypeName(value))
+		}
+		if value.Bool() {
+			a.count++
+		}
+		switch a.operator {
+		case CollectionExists:
+			a.found = value.Bool()
+
+			return a.found, nil
+		case CollectionForall:
+			a.found = value.Bool()
+
+			return !a.found, nil
+		}
+
+		return false, nil
+	case CollectionSum:
+		switch pkg.GetBaseKind(value) {
+		case reflect.Int64:
+			a.integer += value.Int()
+			a.real += float64(value.Int())
+		case reflect.Uint64:
+			a.integer += int64(value.Uint())
+			a.real += float64(value.Uint())
+		case reflect.Float64:
+			a.real += value.Float()
+			a.isReal = true
+		default:
+
+			return true, fmt.Errorf("can not sum value of type %s", valueTypeName(value))
+		}
+
+		return false, nil
+	}
+
+	// min and max
+	if !a.extreme.IsValid() {
+		a.extreme = value
+
+		return false, nil
+	}
+	var better reflect.Value
+	var err error
+	if a.operator == CollectionMin {
+		better, err = pkg.EvaluateLesserThan(value, a.extreme)
+	} else {
+		better, err = pkg.EvaluateGreaterThan(value, a.extreme)
+	}
+	if err != nil {
+
+		return true, err
+	}
+	if better.Bool() {
+		a.extreme = value
+	}
+
+	return false, nil
+}
+
+func (a *collectionAggregate) result() (reflect.Value, error) {
+	switch a.operator {
+	case CollectionExists, CollectionForall:
+
+		return reflect.ValueOf(a.found), nil
+	case CollectionCount:
+
+		return reflect.ValueOf(a.count), nil
+	case CollectionSum:
+		if a.isReal {
+
+			return reflect.ValueOf(a.real), nil
+		}
+
+		return reflect.ValueOf(a.integer), nil
+	}
+	if !a.extreme.IsValid() {
+
+		return reflect.Value{}, errors.New("the collection has no item")
+	}
+
+	return a.extreme, nil
+}
@@ -0,0 +1,666 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+/*
+The compiler turns the when and then scope of a rule entry into a tree of Go closures.
+Constants, fact fields and operators are compiled, their operations are specialized for the kinds of the operands
+seen on their first execution, and specialized again if the kinds change. Function calls, array and map selectors,
+and everything the compiler does not know about, are evaluated by the AST nodes themselves, just like when the
+knowledge base is not compiled.
+*/
+
+// compiledExpression evaluates a compiled expression.
+type compiledExpression func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error)
+
+// compiledStatements executes the compiled then scope.
+type compiledStatements func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error
+
+var (
+	trueValue  = reflect.ValueOf(true)
+	falseValue = reflect.ValueOf(false)
+)
+
+func boolValue(b bool) reflect.Value {
+	if b {
+
+		return trueValue
+	}
+
+	return falseValue
+}
+
+// compile compiles the when and then scope of this rule entry, unless they are already compiled.
+func (e *RuleEntry) compile() {
+	if e.WhenScope != nil && e.WhenScope.Expression != nil && e.WhenScope.compiled.Load() == nil {
+		compiled := compileExpression(e.WhenScope.Expression)
+		e.WhenScope.compiled.CompareAndSwap(nil, &compiled)
+	}
+	if e.ThenScope != nil && e.ThenScope.ThenExpressionList != nil && e.ThenScope.compiled.Load() == nil {
+		compiled := compileThenExpressionList(e.ThenScope.ThenExpressionList)
+		e.ThenScope.compiled.CompareAndSwap(nil, &compiled)
+	}
+}
+
+func compileThenExpressionList(list *ThenExpressionList) compiledStatements {
+	statements := make([]compiledStatements, 0, len(list.ThenExpressions))
+	for _, thenExpression := range list.ThenExpressions {
+		switch {
+		case thenExpression.Assignment != nil:
+			statements = append(statements, compileAssignment(thenExpression.Assignment))
+		case thenExpression.IfStatement != nil:
+			statements = append(statements, compileIfStatement(thenExpression.IfStatement))
+		case thenExpression.ForStatement != nil:
+			statements = append(statements, compileForStatement(thenExpression.ForStatement))
+		default:
+			statements = append(statements, thenExpression.ExecuteWithContext)
+		}
+	}
+
+	return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+		for _, statement := range statements {
+			if err := statement(ctx, dataContext, memory); err != nil {
+
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func compileIfStatement(statement *IfStatement) compiledStatements {
+	condition := compileExpression(statement.Condition)
+	var then, otherwise compiledStatements
+	if statement.Then != nil {
+		then = compileThenExpressionList(statement.Then)
+	}
+	switch {
+	case statement.ElseIf != nil:
+		otherwise = compileIfStatement(statement.ElseIf)
+	case statement.Else != nil:
+		otherwise = compileThenExpressionList(statement.Else)
+	}
+
+	return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+		val, err := condition(ctx, dataContext, memory)
+		if err != nil {
+
+			return err
+		}
+		if val.Kind() != reflect.Bool {
+
+			return fmt.Errorf("condition of %s is not a boolean", statement.GrlText)
+		}
+		if val.Bool() && then != nil {
+
+			return then(ctx, dataContext, memory)
+		}
+		if !val.Bool() && otherwise != nil {
+
+			return otherwise(ctx, dataContext, memory)
+		}
+
+		return nil
+	}
+}
+
+func compileForStatement(statement *ForStatement) compiledStatements {
+	var body compiledStatements
+	if statement.Body != nil {
+		body = compileThenExpressionList(statement.Body)
+	}
+
+	return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+
+		return statement.execute(ctx, dataContext, memory, body)
+	}
+}
+
+func compileAssignment(assignment *Assignment) compiledStatements {
+	expression := compileExpression(assignment.Expression)
+
+	return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+		if limiter := executionLimiterOf(ctx); limiter != nil {
+			if err := limiter.CountAssignment(); err != nil {
+
+				return err
+			}
+		}
+		exprVal, err := expression(ctx, dataContext, memory)
+		if err != nil {
+			AstLog.Errorf("error while executing assignment %s. got %s", assignment.GrlText, err.Error())
+
+			return err
+		}
+		err = assignment.assign(ctx, exprVal, dataContext, memory)
+		if err != nil {
+			AstLog.Errorf("error while executing assignment %s. got %s", assignment.GrlText, err.Error())
+		}
+
+		return err
+	}
+}
+
+func compileExpression(expression *Expression) compiledExpression {
+	switch {
+	case expression.ExpressionAtom != nil:
+
+		return compileExpressionAtom(expression.ExpressionAtom)
+	case expression.SingleExpression != nil:
+		single := compileExpression(expression.SingleExpression)
+		if !expression.Negated {
+
+			return single
+		}
+
+		return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+			val, err := single(ctx, dataContext, memory)
+			if err != nil {
+
+				return val, err
+			}
+			if val.Kind() == reflect.Bool {
+
+				return boolValue(!val.Bool()), nil
+			}
+			AstLog.Warnf("Expression \"%s\" is a negation to non boolean value, negation is ignored.", expression.SingleExpression.GrlText)
+
+			return val, nil
+		}
+	case expression.LeftExpression != nil && expression.RightExpression != nil:
+
+		return compileOperation(expression.Operator, compileExpression(expression.LeftExpression), compileExpression(expression.RightExpression))
+	}
+
+	return expression.EvaluateWithContext
+}
+
+func compileOperation(operator int, left, right compiledExpression) compiledExpression {
+	switch operator {
+	case OpAnd, OpOr:
+		// the right hand expression is not evaluated when the left hand expression decides the result.
+		shortCircuit := operator == OpOr
+		logic := pkg.EvaluateLogicAnd
+		if operator == OpOr {
+			logic = pkg.EvaluateLogicOr
+		}
+
+		return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+			lval, err := left(ctx, dataContext, memory)
+			if err != nil {
+
+				return reflect.Value{}, fmt.Errorf("left hand expression error. got %w", err)
+			}
+			if lval.Kind() == reflect.Bool && lval.Bool() == shortCircuit {
+
+				return boolValue(shortCircuit), nil
+			}
+			rval, err := right(ctx, dataContext, memory)
+			if err != nil {
+
+				return reflect.Value{}, fmt.Errorf("right hand expression error.  got %w", err)
+			}
+			if lval.Kind() == reflect.Bool && rval.Kind() == reflect.Bool {
+
+				return boolValue(rval.Bool()), nil
+			}
+
+			return logic(lval, rval)
+		}
+	}
+
+	var specialized atomic.Pointer[operation]
+
+	return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+		lval, lerr := left(ctx, dataContext, memory)
+		rval, rerr := right(ctx, dataContext, memory)
+		if lerr != nil {
+
+			return reflect.Value{}, fmt.Errorf("left hand expression error. got %w", lerr)
+		}
+		if rerr != nil {
+
+			return reflect.Value{}, fmt.Errorf("right hand expression error.  got %w", rerr)
+		}
+		op := specialized.Load()
+		if op == nil || op.left != lval.Kind() || op.right != rval.Kind() {
+			op = specializeOperation(operator, lval.Kind(), rval.Kind())
+			specialized.Store(op)
+		}
+
+		return op.evaluate(lval, rval)
+	}
+}
+
+func compileExpressionAtom(atom *ExpressionAtom) compiledExpression {
+	switch {
+	case atom.Constant != nil:
+		val := atom.Constant.Value
+		if atom.Constant.IsNil {
+			val = reflect.ValueOf(nil)
+		}
+
+		return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+
+			return val, nil
+		}
+	case atom.Variable != nil:
+
+		return compileVariable(atom.Variable)
+	case atom.ExpressionAtom != nil && atom.FunctionCall == nil && len(atom.VariableName) == 0 && atom.ArrayMapSelector == nil:
+		inner := compileExpressionAtom(atom.ExpressionAtom)
+		if !atom.Negated {
+
+			return inner
+		}
+
+		return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+			val, err := inner(ctx, dataContext, memory)
+			if err != nil {
+
+				return val, err
+			}
+			if val.Kind() == reflect.Bool {
+
+				return boolValue(!val.Bool()), nil
+			}
+			AstLog.Warnf("Expression \"%s\" is a negation to non boolean value, negation is ignored.", atom.ExpressionAtom.GrlText)
+
+			return val, nil
+		}
+	}
+
+	// function calls keep being evaluated by the AST, which only calls them again when their variables change.
+	return atom.EvaluateWithContext
+}
+
+// fieldAccess is the index of a field in the struct type it was resolved for.
+type fieldAccess struct {
+	structType reflect.Type
+	index      []int
+}
+
+func compileVariable(variable *Variable) compiledExpression {
+	switch {
+	case len(variable.LocalOf) > 0:
+
+		return variable.EvaluateWithContext
+	case len(variable.Name) > 0 && variable.Variable == nil:
+
+		return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+			valueNode := dataContext.Get(variable.Name)
+			if valueNode == nil {
+
+				return reflect.ValueOf(nil), &MissingFactError{FactName: variable.Name}
+			}
+
+			return valueNode.Value(), nil
+		}
+	case variable.Variable != nil && len(variable.Name) > 0:
+		parent := compileVariable(variable.Variable)
+		var resolved atomic.Pointer[fieldAccess]
+
+		return func(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+			parentVal, err := parent(ctx, dataContext, memory)
+			if err != nil {
+
+				return reflect.Value{}, err
+			}
+			obj := parentVal
+			for obj.Kind() == reflect.Ptr || obj.Kind() == reflect.Interface {
+				if obj.IsNil() {
+
+					return variable.EvaluateWithContext(ctx, dataContext, memory)
+				}
+				obj = obj.Elem()
+			}
+			if obj.Kind() != reflect.Struct {
+				// JSON facts and maps are accessed through their value node.
+				return variable.EvaluateWithContext(ctx, dataContext, memory)
+			}
+			access := resolved.Load()
+			if access == nil || access.structType != obj.Type() {
+				field, ok := obj.Type().FieldByName(variable.Name)
+				if !ok {
+
+					return variable.EvaluateWithContext(ctx, dataContext, memory)
+				}
+				access = &fieldAccess{structType: obj.Type(), index: field.Index}
+				resolved.Store(access)
+			}
+
+			return obj.FieldByIndex(access.index), nil
+		}
+	}
+
+	return variable.EvaluateWithContext
+}
+
+// operation is an operator specialized for the kinds of its operands.
+type operation struct {
+	left, right reflect.Kind
+	evaluate    func(left, right reflect.Value) (reflect.Value, error)
+}
+
+type kindClass int
+
+const (
+	classOther kindClass = iota
+	classSigned
+	classReal
+	classString
+	classBool
+)
+
+func classOf(kind reflect.Kind) kindClass {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		return classSigned
+	case reflect.Float32, reflect.Float64:
+
+		return classReal
+	case reflect.String:
+
+		return classString
+	case reflect.Bool:
+
+		return classBool
+	}
+
+	return classOther
+}
+
+// genericOperations are the operators of pkg/reflectmath, used for the kinds without a specialized operation.
+var genericOperations = map[int]func(left, right reflect.Value) (reflect.Value, error){
+	OpMul:    pkg.EvaluateMultiplication,
+	OpDiv:    pkg.EvaluateDivision,
+	OpMod:    pkg.EvaluateModulo,
+	OpAdd:    pkg.EvaluateAddition,
+	OpSub:    pkg.EvaluateSubtraction,
+	OpBitAnd: pkg.EvaluateBitAnd,
+	OpBitOr:  pkg.EvaluateBitOr,
+	OpGT:     pkg.EvaluateGreaterThan,
+	OpLT:     pkg.EvaluateLesserThan,
+	OpGTE:    pkg.EvaluateGreaterThanEqual,
+	OpLTE:    pkg.EvaluateLesserThanEqual,
+	OpEq:     pkg.EvaluateEqual,
+	OpNEq:    pkg.EvaluateNotEqual,
+	OpAnd:    pkg.EvaluateLogicAnd,
+	OpOr:     pkg.EvaluateLogicOr,
+}
+
+func specializeOperation(operator int, left, right reflect.Kind) *operation {
+	op := &operation{left: left, right: right}
+	leftClass, rightClass := classOf(left), classOf(right)
+	switch {
+	case leftClass == classSigned && rightClass == classSigned:
+		op.evaluate = signedOperation(operator)
+	case (leftClass == classSigned || leftClass == classReal) && (rightClass == classSigned || rightClass == classReal):
+		op.evaluate = realOperation(operator, leftClass == classSigned, rightClass == classSigned)
+	case leftClass == classString && rightClass == classString:
+		op.evaluate = stringOperation(operator)
+	case leftClass == classBool && rightClass == classBool:
+		op.evaluate = boolOperation(operator)
+	}
+	if op.evaluate == nil {
+		op.evaluate = genericOperations[operator]
+	}
+
+	return op
+}
+
+func signedOperation(operator int) func(left, right reflect.Value) (reflect.Value, error) {
+	switch operator {
+	case OpMul:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.Int() * right.Int()), nil
+		}
+	case OpDiv:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(float64(left.Int()) / float64(right.Int())), nil
+		}
+	case OpMod:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.Int() % right.Int()), nil
+		}
+	case OpAdd:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.Int() + right.Int()), nil
+		}
+	case OpSub:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.Int() - right.Int()), nil
+		}
+	case OpBitAnd:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.Int() & right.Int()), nil
+		}
+	case OpBitOr:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.Int() | right.Int()), nil
+		}
+	case OpGT:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Int() > right.Int()), nil
+		}
+	case OpLT:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Int() < right.Int()), nil
+		}
+	case OpGTE:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Int() >= right.Int()), nil
+		}
+	case OpLTE:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Int() <= right.Int()), nil
+		}
+	case OpEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Int() == right.Int()), nil
+		}
+	case OpNEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Int() != right.Int()), nil
+		}
+	}
+
+	return nil
+}
+
+func realOperation(operator int, leftSigned, rightSigned bool) func(left, right reflect.Value) (reflect.Value, error) {
+	leftFloat, rightFloat := reflect.Value.Float, reflect.Value.Float
+	if leftSigned {
+		leftFloat = func(v reflect.Value) float64 {
+
+			return float64(v.Int())
+		}
+	}
+	if rightSigned {
+		rightFloat = func(v reflect.Value) float64 {
+
+			return float64(v.Int())
+		}
+	}
+	switch operator {
+	case OpMul:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(leftFloat(left) * rightFloat(right)), nil
+		}
+	case OpDiv:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(leftFloat(left) / rightFloat(right)), nil
+		}
+	case OpAdd:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(leftFloat(left) + rightFloat(right)), nil
+		}
+	case OpSub:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(leftFloat(left) - rightFloat(right)), nil
+		}
+	case OpGT:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(leftFloat(left) > rightFloat(right)), nil
+		}
+	case OpLT:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(leftFloat(left) < rightFloat(right)), nil
+		}
+	case OpGTE:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(leftFloat(left) >= rightFloat(right)), nil
+		}
+	case OpLTE:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(leftFloat(left) <= rightFloat(right)), nil
+		}
+	case OpEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(leftFloat(left) == rightFloat(right)), nil
+		}
+	case OpNEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(leftFloat(left) != rightFloat(right)), nil
+		}
+	}
+
+	return nil
+}
+
+func stringOperation(operator int) func(left, right reflect.Value) (reflect.Value, error) {
+	switch operator {
+	case OpAdd:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return reflect.ValueOf(left.String() + right.String()), nil
+		}
+	case OpGT:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.String() > right.String()), nil
+		}
+	case OpLT:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.String() < right.String()), nil
+		}
+	case OpGTE:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.String() >= right.String()), nil
+		}
+	case OpLTE:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.String() <= right.String()), nil
+		}
+	case OpEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.String() == right.String()), nil
+		}
+	case OpNEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.String() != right.String()), nil
+		}
+	}
+
+	return nil
+}
+
+func boolOperation(operator int) func(left, right reflect.Value) (reflect.Value, error) {
+	switch operator {
+	case OpEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Bool() == right.Bool()), nil
+		}
+	case OpNEq:
+
+		return func(left, right reflect.Value) (reflect.Value, error) {
+
+			return boolValue(left.Bool() != right.Bool()), nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecializeOperation(t *testing.T) {
+	values := []interface{}{
+		int64(7), int64(-3), 12, int32(5), uint(4),
+		2.5, float32(-1.5), 4.0,
+		"abc", "abd", "",
+		true, false,
+	}
+	operators := []int{OpMul, OpDiv, OpMod, OpAdd, OpSub, OpBitAnd, OpBitOr, OpGT, OpLT, OpGTE, OpLTE, OpEq, OpNEq, OpAnd, OpOr}
+	for _, operator := range operators {
+		for _, left := range values {
+			for _, right := range values {
+				leftValue, rightValue := reflect.ValueOf(left), reflect.ValueOf(right)
+				expected, expectedErr := genericOperations[operator](leftValue, rightValue)
+				op := specializeOperation(operator, leftValue.Kind(), rightValue.Kind())
+				actual, actualErr := op.evaluate(leftValue, rightValue)
+				if expectedErr != nil {
+					assert.Error(t, actualErr, "operator %d on %#v and %#v", operator, left, right)
+
+					continue
+				}
+				if assert.NoError(t, actualErr, "operator %d on %#v and %#v", operator, left, right) {
+					assert.Equal(t, expected.Interface(), actual.Interface(), "operator %d on %#v and %#v", operator, left, right)
+				}
+			}
+		}
+	}
+}
@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
@@ -166,6 +167,12 @@ func (e *Constant) AcceptBooleanLiteral(fun *BooleanLiteral) {
 
 // Evaluate will evaluate this AST graph for when scope evaluation
 func (e *Constant) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *Constant) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
 	if e.IsNil {
 
 		return reflect.ValueOf(nil), nil
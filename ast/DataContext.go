@@ -58,6 +58,11 @@ func (ctx *DataContext) Complete() {
 	ctx.complete = true
 }
 
+// Resume clears the completed mark, telling the engine to process rules again
+func (ctx *DataContext) Resume() {
+	ctx.complete = false
+}
+
 // IsComplete checks whether the DataContext has been completed
 func (ctx *DataContext) IsComplete() bool {
 
@@ -118,6 +123,11 @@ func (ctx *DataContext) Add(key string, obj interface{}) error {
 	return nil
 }
 
+// Remove will remove the struct instance from rule execution context
+func (ctx *DataContext) Remove(key string) {
+	delete(ctx.ObjectStore, key)
+}
+
 // AddJSON will add struct instance into rule execution context
 func (ctx *DataContext) AddJSON(key string, JSON []byte) error {
 	vn, err := model.NewJSONValueNode(string(JSON), key)
@@ -0,0 +1,43 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"fmt"
+)
+
+// MissingFactError is returned when a rule refers to a fact that is not added into the data context.
+type MissingFactError struct {
+	// FactName is the name the fact is referred by in the rule.
+	FactName string
+}
+
+// Error returns the description of the missing fact.
+func (e *MissingFactError) Error() string {
+
+	return fmt.Sprintf("non existent key %s", e.FactName)
+}
+
+// RuleEntryExistError is returned when a rule entry is added into a knowledge base that already has a rule entry with the same name.
+type RuleEntryExistError struct {
+	// RuleName is the name of the rule entry.
+	RuleName string
+}
+
+// Error returns the description of the duplicated rule entry.
+func (e *RuleEntryExistError) Error() string {
+
+	return fmt.Sprintf("rule entry %s already exist", e.RuleName)
+}
@@ -0,0 +1,31 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"reflect"
+)
+
+// EvaluationTracer is implemented by those who want to receive the values computed while the AST graph is evaluated
+// and executed. A tracer is attached to a WorkingMemory using WorkingMemory.SetTracer
+type EvaluationTracer interface {
+	// TraceExpression is called every time an Expression is evaluated, including when its value is taken from the working memory.
+	TraceExpression(expr *Expression, value reflect.Value, err error)
+	// TraceExpressionAtom is called every time an ExpressionAtom is evaluated, including when its value is taken from the working memory.
+	TraceExpressionAtom(atom *ExpressionAtom, value reflect.Value, err error)
+	// TraceAssignment is called every time an Assignment is successfully executed.
+	// The before value is invalid if the variable can not be evaluated prior to the assignment.
+	TraceAssignment(assign *Assignment, before, after reflect.Value)
+}
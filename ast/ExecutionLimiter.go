@@ -0,0 +1,49 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import "context"
+
+// ExecutionLimiter is implemented by those who want to limit the resources used while the AST graph is evaluated
+// and executed. A limiter is attached to the context of an execution using WithExecutionLimiter
+type ExecutionLimiter interface {
+	// CountFunctionCall is called before every function or method call. Returning an error prevents the call,
+	// and the error is returned by the evaluation.
+	CountFunctionCall(functionName string) error
+	// CountAssignment is called before every Assignment. Returning an error prevents the assignment,
+	// and the error is returned by the execution.
+	CountAssignment() error
+}
+
+// executionLimiterKey is the context key of the ExecutionLimiter.
+type executionLimiterKey struct{}
+
+// WithExecutionLimiter returns a copy of the context carrying the limiter. The evaluations and executions given
+// that context are limited by it, including the ones still running in the background once the execution is done.
+func WithExecutionLimiter(ctx context.Context, limiter ExecutionLimiter) context.Context {
+
+	return context.WithValue(ctx, executionLimiterKey{}, limiter)
+}
+
+// executionLimiterOf returns the ExecutionLimiter carried by the context, nil if there is none.
+func executionLimiterOf(ctx context.Context) ExecutionLimiter {
+	if ctx == nil {
+
+		return nil
+	}
+	limiter, _ := ctx.Value(executionLimiterKey{}).(ExecutionLimiter)
+
+	return limiter
+}
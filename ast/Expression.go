@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
@@ -71,15 +72,29 @@ type Expression struct {
 	AstID   string
 	GrlText string
 
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
 	LeftExpression   *Expression
 	RightExpression  *Expression
 	SingleExpression *Expression
 	ExpressionAtom   *ExpressionAtom
 	Operator         int
 	Negated          bool
-	Value            reflect.Value
-
+	// Value is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.ValueOf.
+	Value reflect.Value
+	// Evaluated is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.IsEvaluated.
 	Evaluated bool
+
+	// Type is the type inferred by the TypeChecker, nil if the node is not checked.
+	Type *StaticType
+
+	// slot is the index of the evaluation state of this node in the frame of the working memory.
+	slot int
 }
 
 // MakeCatalog will create a catalog entry from Expression node.
@@ -118,8 +133,10 @@ func (e *Expression) Clone(cloneTable *pkg.CloneTable) *Expression {
 	clone := &Expression{
 		AstID:    unique.NewID(),
 		GrlText:  e.GrlText,
+		Position: e.Position,
 		Operator: e.Operator,
 		Negated:  e.Negated,
+		Type:     e.Type,
 	}
 
 	if e.LeftExpression != nil {
@@ -279,49 +296,67 @@ func (e *Expression) SetGrlText(grlText string) {
 
 // Evaluate will evaluate this AST graph for when scope evaluation
 func (e *Expression) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
-	if e.Evaluated == true {
 
-		return e.Value, nil
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *Expression) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	state := memory.state(e.slot, e)
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	val, err := e.evaluate(ctx, state, dataContext, memory)
+	if tracer := memory.GetTracer(); tracer != nil {
+		tracer.TraceExpression(e, val, err)
+	}
+
+	return val, err
+}
+
+func (e *Expression) evaluate(ctx context.Context, state *nodeState, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	if state.evaluated.Load() {
+
+		return state.value, nil
 	}
 	if e.ExpressionAtom != nil {
-		val, err := e.ExpressionAtom.Evaluate(dataContext, memory)
+		val, err := e.ExpressionAtom.EvaluateWithContext(ctx, dataContext, memory)
 		if err == nil {
-			e.Value = val
-			e.Evaluated = true
+			state.value = val
+			state.evaluated.Store(true)
 		}
 
 		return val, err
 	}
 	if e.SingleExpression != nil {
-		val, err := e.SingleExpression.Evaluate(dataContext, memory)
+		val, err := e.SingleExpression.EvaluateWithContext(ctx, dataContext, memory)
 		if err == nil {
-			e.Value = val
+			state.value = val
 			if e.Negated {
-				if e.Value.Kind() == reflect.Bool {
-					e.Value = reflect.ValueOf(!e.Value.Bool())
+				if state.value.Kind() == reflect.Bool {
+					state.value = reflect.ValueOf(!state.value.Bool())
 				} else {
 					AstLog.Warnf("Expression \"%s\" is a negation to non boolean value, negation is ignored.", e.SingleExpression.GrlText)
 				}
 			}
-			e.Evaluated = true
+			state.evaluated.Store(true)
 		}
 
-		return e.Value, err
+		return state.value, err
 	}
 	if e.LeftExpression != nil && e.RightExpression != nil {
 		var val reflect.Value
 		var opErr error
 
-		lval, lerr := e.LeftExpression.Evaluate(dataContext, memory)
+		lval, lerr := e.LeftExpression.EvaluateWithContext(ctx, dataContext, memory)
 		if e.Operator == OpAnd {
 			if lerr != nil {
 
-				return reflect.Value{}, fmt.Errorf("left hand expression error. got %v", lerr)
+				return reflect.Value{}, fmt.Errorf("left hand expression error. got %w", lerr)
 			}
 			val, opErr = pkg.EvaluateLogicSingle(lval)
 			if opErr == nil && !val.Bool() {
-				e.Value = val
-				e.Evaluated = true
+				state.value = val
+				state.evaluated.Store(true)
 
 				return val, opErr
 			}
@@ -329,25 +364,25 @@ func (e *Expression) Evaluate(dataContext IDataContext, memory *WorkingMemory) (
 		if e.Operator == OpOr {
 			if lerr != nil {
 
-				return reflect.Value{}, fmt.Errorf("left hand expression error. got %v", lerr)
+				return reflect.Value{}, fmt.Errorf("left hand expression error. got %w", lerr)
 			}
 			val, opErr = pkg.EvaluateLogicSingle(lval)
 			if opErr == nil && val.Bool() {
-				e.Value = val
-				e.Evaluated = true
+				state.value = val
+				state.evaluated.Store(true)
 
 				return val, opErr
 			}
 		}
 
-		rval, rerr := e.RightExpression.Evaluate(dataContext, memory)
+		rval, rerr := e.RightExpression.EvaluateWithContext(ctx, dataContext, memory)
 		if lerr != nil {
 
-			return reflect.Value{}, fmt.Errorf("left hand expression error. got %v", lerr)
+			return reflect.Value{}, fmt.Errorf("left hand expression error. got %w", lerr)
 		}
 		if rerr != nil {
 
-			return reflect.Value{}, fmt.Errorf("right hand expression error.  got %v", rerr)
+			return reflect.Value{}, fmt.Errorf("right hand expression error.  got %w", rerr)
 		}
 
 		switch e.Operator {
@@ -383,8 +418,8 @@ func (e *Expression) Evaluate(dataContext IDataContext, memory *WorkingMemory) (
 			val, opErr = pkg.EvaluateLogicOr(lval, rval)
 		}
 		if opErr == nil {
-			e.Value = val
-			e.Evaluated = true
+			state.value = val
+			state.evaluated.Store(true)
 		}
 
 		return val, opErr
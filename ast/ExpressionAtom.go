@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
@@ -38,6 +39,9 @@ type ExpressionAtom struct {
 	AstID   string
 	GrlText string
 
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
 	VariableName     string
 	Constant         *Constant
 	FunctionCall     *FunctionCall
@@ -45,11 +49,27 @@ type ExpressionAtom struct {
 	Negated          bool
 	ExpressionAtom   *ExpressionAtom
 	ArrayMapSelector *ArrayMapSelector
-
-	Value     reflect.Value
+	// CollectionExpression is set for an operation over the items of a collection, e.g. exists(i in Fact.Items : i.Ok)
+	CollectionExpression *CollectionExpression
+
+	// Value is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.ValueOf.
+	Value reflect.Value
+	// ValueNode is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.ValueNodeOf.
 	ValueNode model.ValueNode
-
+	// Evaluated is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.IsEvaluated.
 	Evaluated bool
+
+	// Type is the type inferred by the TypeChecker, nil if the node is not checked.
+	Type *StaticType
+
+	// slot is the index of the evaluation state of this node in the frame of the working memory.
+	slot int
 }
 
 // MakeCatalog will create a catalog entry from ExpressionAtom node.
@@ -82,6 +102,10 @@ func (e *ExpressionAtom) MakeCatalog(cat *Catalog) {
 			meta.ArrayMapSelectorID = e.ArrayMapSelector.AstID
 			e.ArrayMapSelector.MakeCatalog(cat)
 		}
+		if e.CollectionExpression != nil {
+			meta.CollectionExpressionID = e.CollectionExpression.AstID
+			e.CollectionExpression.MakeCatalog(cat)
+		}
 		meta.VariableName = e.VariableName
 		meta.Negated = e.Negated
 	}
@@ -97,8 +121,10 @@ func (e *ExpressionAtom) Clone(cloneTable *pkg.CloneTable) *ExpressionAtom {
 	clone := &ExpressionAtom{
 		AstID:        unique.NewID(),
 		GrlText:      e.GrlText,
+		Position:     e.Position,
 		VariableName: e.VariableName,
 		Negated:      e.Negated,
+		Type:         e.Type,
 	}
 
 	if e.Constant != nil {
@@ -151,6 +177,16 @@ func (e *ExpressionAtom) Clone(cloneTable *pkg.CloneTable) *ExpressionAtom {
 		}
 	}
 
+	if e.CollectionExpression != nil {
+		if cloneTable.IsCloned(e.CollectionExpression.AstID) {
+			clone.CollectionExpression = cloneTable.Records[e.CollectionExpression.AstID].CloneInstance.(*CollectionExpression)
+		} else {
+			cloned := e.CollectionExpression.Clone(cloneTable)
+			clone.CollectionExpression = cloned
+			cloneTable.MarkCloned(e.CollectionExpression.AstID, cloned.AstID, e.CollectionExpression, cloned)
+		}
+	}
+
 	return clone
 }
 
@@ -210,6 +246,17 @@ func (e *ExpressionAtom) AcceptArrayMapSelector(sel *ArrayMapSelector) error {
 	return nil
 }
 
+// AcceptCollectionExpression will accept a CollectionExpression AST graph into this ast graph
+func (e *ExpressionAtom) AcceptCollectionExpression(collection *CollectionExpression) error {
+	if e.CollectionExpression != nil {
+
+		return errors.New("collection expression for ExpressionAtom already assigned")
+	}
+	e.CollectionExpression = collection
+
+	return nil
+}
+
 // GetAstID get the UUID asigned for this AST graph node
 func (e *ExpressionAtom) GetAstID() string {
 
@@ -231,6 +278,8 @@ func (e *ExpressionAtom) GetSnapshot() string {
 		buff.WriteString(e.Variable.GetSnapshot())
 	} else if e.Constant != nil {
 		buff.WriteString(e.Constant.GetSnapshot())
+	} else if e.CollectionExpression != nil {
+		buff.WriteString(e.CollectionExpression.GetSnapshot())
 	} else if e.FunctionCall != nil && e.ExpressionAtom == nil {
 		buff.WriteString(e.FunctionCall.GetSnapshot())
 	} else if e.FunctionCall == nil && e.ExpressionAtom != nil && len(e.VariableName) == 0 {
@@ -264,154 +313,257 @@ func (e *ExpressionAtom) SetGrlText(grlText string) {
 }
 
 // Evaluate will evaluate this AST graph for when scope evaluation
-func (e *ExpressionAtom) Evaluate(dataContext IDataContext, memory *WorkingMemory) (val reflect.Value, err error) {
-	if e.Evaluated == true {
+func (e *ExpressionAtom) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *ExpressionAtom) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	val, _, err := e.evaluateValueNode(ctx, dataContext, memory)
+
+	return val, err
+}
+
+// evaluateValueNode will evaluate this AST graph and return the value node of the result along with its value.
+// The value node must be read while the state is locked, as another rule entry sharing this node could be evaluating it.
+func (e *ExpressionAtom) evaluateValueNode(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, model.ValueNode, error) {
+	state := memory.state(e.slot, e)
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	val, err := e.evaluate(ctx, state, dataContext, memory)
+	if tracer := memory.GetTracer(); tracer != nil {
+		tracer.TraceExpressionAtom(e, val, err)
+	}
+
+	return val, state.valueNode, err
+}
+
+// namespacedFunction returns the registered function called by this node, if it calls a function of a namespace,
+// e.g. Geo.Distance(a, b), and no fact is added into the data context under the namespace name.
+func (e *ExpressionAtom) namespacedFunction(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (*RegisteredFunction, bool) {
+	namespace := e.ExpressionAtom.Variable
+	if namespace == nil || namespace.Variable != nil || len(namespace.Name) == 0 || !hasFunctionNamespace(ctx, namespace.Name) {
+
+		return nil, false
+	}
+	if dataContext.Get(namespace.Name) != nil {
+
+		return nil, false
+	}
+
+	return registeredFunctionOf(ctx, namespace.Name+"."+e.FunctionCall.FunctionName)
+}
 
-		return e.Value, nil
+// callRegisteredFunction calls a function of the FunctionRegistry. Like the built-in functions, its result is not
+// kept as the value of this node, so the function is called again on every evaluation.
+func (e *ExpressionAtom) callRegisteredFunction(ctx context.Context, state *nodeState, function *RegisteredFunction, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	args, err := e.FunctionCall.EvaluateArgumentListWithContext(ctx, dataContext, memory)
+	if err != nil {
+
+		return reflect.Value{}, err
+	}
+	if err := e.countFunctionCall(ctx); err != nil {
+
+		return reflect.Value{}, err
+	}
+	ret, err := function.Call(ctx, args)
+	if err != nil {
+
+		return reflect.Value{}, err
+	}
+	state.value = ret
+	state.valueNode = model.NewGoValueNode(ret, fmt.Sprintf("%s()", function.Name))
+
+	return ret, nil
+}
+
+func (e *ExpressionAtom) evaluate(ctx context.Context, state *nodeState, dataContext IDataContext, memory *WorkingMemory) (val reflect.Value, err error) {
+	if state.evaluated.Load() {
+
+		return state.value, nil
 	}
 	if e.Constant != nil {
-		val, err := e.Constant.Evaluate(dataContext, memory)
+		val, err := e.Constant.EvaluateWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		e.Value = val
-		e.ValueNode = model.NewGoValueNode(val, fmt.Sprintf("%s->%s", val.Type().String(), val.String()))
-		e.Evaluated = true
+		state.value = val
+		state.valueNode = model.NewGoValueNode(val, fmt.Sprintf("%s->%s", val.Type().String(), val.String()))
+		state.evaluated.Store(true)
 
 		return val, err
 	}
 	if e.Variable != nil {
-		val, err := e.Variable.Evaluate(dataContext, memory)
+		val, valueNode, err := e.Variable.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		//t, _ := e.Variable.ValueNode.GetType()
-		e.Value = val
-		e.ValueNode = e.Variable.ValueNode
-		e.Evaluated = true
+		//t, _ := valueNode.GetType()
+		state.value = val
+		state.valueNode = valueNode
+		state.evaluated.Store(true)
 
 		return val, err
 	}
+	if e.CollectionExpression != nil {
+		val, err := e.CollectionExpression.EvaluateWithContext(ctx, dataContext, memory)
+		if err != nil {
+
+			return reflect.Value{}, err
+		}
+		// like a function call, the result is not kept as the items may change without the collection being reset.
+		state.value = val
+		state.valueNode = model.NewGoValueNode(val, e.GrlText)
+
+		return val, nil
+	}
 	if e.ExpressionAtom == nil && e.FunctionCall != nil {
+		if function, ok := registeredFunctionOf(ctx, e.FunctionCall.FunctionName); ok {
+
+			return e.callRegisteredFunction(ctx, state, function, dataContext, memory)
+		}
 		valueNode := dataContext.Get("DEFUNC")
-		args, err := e.FunctionCall.EvaluateArgumentList(dataContext, memory)
+		args, err := e.FunctionCall.EvaluateArgumentListWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
+		if err := e.countFunctionCall(ctx); err != nil {
+
+			return reflect.Value{}, err
+		}
 		ret, err := valueNode.CallFunction(e.FunctionCall.FunctionName, args...)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		e.Value = ret
-		e.ValueNode = model.NewGoValueNode(e.Value, fmt.Sprintf("%s()", e.FunctionCall.FunctionName))
-		// e.Evaluated = true
+		state.value = ret
+		state.valueNode = model.NewGoValueNode(state.value, fmt.Sprintf("%s()", e.FunctionCall.FunctionName))
+		// state.evaluated.Store(true)
 
 		return ret, err
 	}
 	if e.ExpressionAtom != nil && e.FunctionCall == nil && len(e.VariableName) == 0 && e.ArrayMapSelector == nil {
-		val, err := e.ExpressionAtom.Evaluate(dataContext, memory)
+		val, atomValueNode, err := e.ExpressionAtom.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		e.Value = val
-		e.ValueNode = e.ExpressionAtom.ValueNode
+		state.value = val
+		state.valueNode = atomValueNode
 		if e.Negated {
-			if e.Value.Kind() == reflect.Bool {
-				e.Value = reflect.ValueOf(!e.Value.Bool())
-				e.ValueNode = model.NewGoValueNode(e.Value, fmt.Sprintf("!%s", e.GrlText))
+			if state.value.Kind() == reflect.Bool {
+				state.value = reflect.ValueOf(!state.value.Bool())
+				state.valueNode = model.NewGoValueNode(state.value, fmt.Sprintf("!%s", e.GrlText))
 			} else {
 				AstLog.Warnf("Expression \"%s\" is a negation to non boolean value, negation is ignored.", e.ExpressionAtom.GrlText)
 			}
 		}
 
-		e.Evaluated = true
+		state.evaluated.Store(true)
 
-		return e.Value, err
+		return state.value, err
 	}
 	if e.ExpressionAtom != nil && e.FunctionCall != nil {
-		_, err := e.ExpressionAtom.Evaluate(dataContext, memory)
+		if function, ok := e.namespacedFunction(ctx, dataContext, memory); ok {
+
+			return e.callRegisteredFunction(ctx, state, function, dataContext, memory)
+		}
+		_, atomValueNode, err := e.ExpressionAtom.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.ValueOf(nil), err
 		}
 
-		args, err := e.FunctionCall.EvaluateArgumentList(dataContext, memory)
+		args, err := e.FunctionCall.EvaluateArgumentListWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.ValueOf(nil), err
 		}
 
-		retVal, err := e.ExpressionAtom.ValueNode.CallFunction(e.FunctionCall.FunctionName, args...)
+		if err := e.countFunctionCall(ctx); err != nil {
+
+			return reflect.ValueOf(nil), err
+		}
+		retVal, err := atomValueNode.CallFunction(e.FunctionCall.FunctionName, args...)
 		if err != nil {
 
 			return reflect.ValueOf(nil), err
 		}
 
 		if retVal.IsValid() {
-			e.Value = retVal
+			state.value = retVal
 		}
-		e.ValueNode = e.ExpressionAtom.ValueNode.ContinueWithValue(retVal, e.FunctionCall.FunctionName)
-		e.Evaluated = true
+		state.valueNode = atomValueNode.ContinueWithValue(retVal, e.FunctionCall.FunctionName)
+		state.evaluated.Store(true)
 
-		return e.Value, nil
+		return state.value, nil
 	}
 	if e.ExpressionAtom != nil && len(e.VariableName) > 0 {
-		_, err := e.ExpressionAtom.Evaluate(dataContext, memory)
+		_, atomValueNode, err := e.ExpressionAtom.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		valueNode, err := e.ExpressionAtom.ValueNode.GetChildNodeByField(e.VariableName)
+		valueNode, err := atomValueNode.GetChildNodeByField(e.VariableName)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		e.ValueNode = valueNode
-		e.Value = valueNode.Value()
-		e.Evaluated = true
+		state.valueNode = valueNode
+		state.value = valueNode.Value()
+		state.evaluated.Store(true)
 
-		return e.Value, nil
+		return state.value, nil
 	}
 	if e.ExpressionAtom != nil && e.ArrayMapSelector != nil && len(e.VariableName) == 0 {
 
-		_, err := e.ExpressionAtom.Evaluate(dataContext, memory)
+		_, atomValueNode, err := e.ExpressionAtom.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		selValue, err := e.ArrayMapSelector.Evaluate(dataContext, memory)
+		selValue, err := e.ArrayMapSelector.EvaluateWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
 		var valueNode model.ValueNode
-		if e.ExpressionAtom.ValueNode.IsArray() {
-			valueNode, err = e.ExpressionAtom.ValueNode.GetChildNodeByIndex(int(selValue.Int()))
+		if atomValueNode.IsArray() {
+			valueNode, err = atomValueNode.GetChildNodeByIndex(int(selValue.Int()))
 			if err != nil {
 
 				return reflect.Value{}, err
 			}
-		} else if e.ExpressionAtom.ValueNode.IsMap() {
-			valueNode, err = e.ExpressionAtom.ValueNode.GetChildNodeBySelector(selValue)
+		} else if atomValueNode.IsMap() {
+			valueNode, err = atomValueNode.GetChildNodeBySelector(selValue)
 			if err != nil {
 
 				return reflect.Value{}, err
 			}
 		} else {
 
-			return reflect.Value{}, fmt.Errorf("%s is not an array nor map", e.Variable.ValueNode.IdentifiedAs())
+			return reflect.Value{}, fmt.Errorf("%s is not an array nor map", atomValueNode.IdentifiedAs())
 		}
 
-		e.ValueNode = valueNode
-		e.Value = valueNode.Value()
+		state.valueNode = valueNode
+		state.value = valueNode.Value()
 
-		return e.Value, nil
+		return state.value, nil
 	}
 
 	return reflect.Value{}, fmt.Errorf("this portion of code should not be reached")
 }
+
+// countFunctionCall will notify the ExecutionLimiter of the execution, if any, that the function is about to be called.
+func (e *ExpressionAtom) countFunctionCall(ctx context.Context) error {
+	if limiter := executionLimiterOf(ctx); limiter != nil {
+
+		return limiter.CountFunctionCall(e.FunctionCall.FunctionName)
+	}
+
+	return nil
+}
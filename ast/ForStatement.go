@@ -0,0 +1,356 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+// NewForStatement creates new instance of ForStatement
+func NewForStatement() *ForStatement {
+
+	return &ForStatement{
+		AstID: unique.NewID(),
+	}
+}
+
+// ForStatement AST graph node. It executes the Body once for every item of an array or a map,
+// e.g. for item in Order.Items { item.Price = item.Price * 0.9; }
+// The loop is bounded by the number of items, which are taken before the first execution of the Body.
+// The item is available to the Body under VariableName, it hides a fact with the same name.
+type ForStatement struct {
+	AstID   string
+	GrlText string
+
+	VariableName string
+	Collection   *ExpressionAtom
+	Body         *ThenExpressionList
+}
+
+// MakeCatalog will create a catalog entry from ForStatement node.
+func (e *ForStatement) MakeCatalog(cat *Catalog) {
+	meta := &ForStatementMeta{
+		NodeMeta: NodeMeta{
+			AstID:    e.AstID,
+			GrlText:  e.GrlText,
+			Snapshot: e.GetSnapshot(),
+		},
+	}
+	if cat.AddMeta(e.AstID, meta) {
+		meta.VariableName = e.VariableName
+		if e.Collection != nil {
+			meta.CollectionID = e.Collection.AstID
+			e.Collection.MakeCatalog(cat)
+		}
+		if e.Body != nil {
+			meta.BodyID = e.Body.AstID
+			e.Body.MakeCatalog(cat)
+		}
+	}
+}
+
+// Clone will clone this ForStatement. The new clone will have an identical structure
+func (e *ForStatement) Clone(cloneTable *pkg.CloneTable) *ForStatement {
+	clone := &ForStatement{
+		AstID:        unique.NewID(),
+		GrlText:      e.GrlText,
+		VariableName: e.VariableName,
+	}
+
+	if e.Collection != nil {
+		if cloneTable.IsCloned(e.Collection.AstID) {
+			clone.Collection = cloneTable.Records[e.Collection.AstID].CloneInstance.(*ExpressionAtom)
+		} else {
+			cloned := e.Collection.Clone(cloneTable)
+			clone.Collection = cloned
+			cloneTable.MarkCloned(e.Collection.AstID, cloned.AstID, e.Collection, cloned)
+		}
+	}
+
+	if e.Body != nil {
+		if cloneTable.IsCloned(e.Body.AstID) {
+			clone.Body = cloneTable.Records[e.Body.AstID].CloneInstance.(*ThenExpressionList)
+		} else {
+			cloned := e.Body.Clone(cloneTable)
+			clone.Body = cloned
+			cloneTable.MarkCloned(e.Body.AstID, cloned.AstID, e.Body, cloned)
+		}
+	}
+
+	return clone
+}
+
+// ForStatementReceiver should be implemented by AST graph node to receive a ForStatement AST graph node
+type ForStatementReceiver interface {
+	AcceptForStatement(statement *ForStatement) error
+}
+
+// AcceptExpressionAtom will accept the collection ExpressionAtom AST graph into this ast graph
+func (e *ForStatement) AcceptExpressionAtom(atom *ExpressionAtom) error {
+	if e.Collection != nil {
+
+		return errors.New("collection for ForStatement already assigned")
+	}
+	e.Collection = atom
+
+	return nil
+}
+
+// AcceptThenExpressionList will accept the body into this ast graph
+func (e *ForStatement) AcceptThenExpressionList(list *ThenExpressionList) error {
+	if e.Body != nil {
+
+		return errors.New("body for ForStatement already assigned")
+	}
+	e.Body = list
+
+	return nil
+}
+
+// GetAstID get the UUID asigned for this AST graph node
+func (e *ForStatement) GetAstID() string {
+
+	return e.AstID
+}
+
+// GetGrlText get the expression syntax related to this graph when it wast constructed
+func (e *ForStatement) GetGrlText() string {
+
+	return e.GrlText
+}
+
+// GetSnapshot will create a structure signature or AST graph
+func (e *ForStatement) GetSnapshot() string {
+	var buff bytes.Buffer
+	buff.WriteString(FORSTATEMENT)
+	buff.WriteString(fmt.Sprintf("(n:%s", e.VariableName))
+	if e.Collection != nil {
+		buff.WriteString(",in:")
+		buff.WriteString(e.Collection.GetSnapshot())
+	}
+	if e.Body != nil {
+		buff.WriteString(",")
+		buff.WriteString(e.Body.GetSnapshot())
+	}
+	buff.WriteString(")")
+
+	return buff.String()
+}
+
+// SetGrlText set the expression syntax related to this graph when it was constructed. Only ANTLR4 listener should
+// call this function.
+func (e *ForStatement) SetGrlText(grlText string) {
+	e.GrlText = grlText
+}
+
+// Execute will execute the body for every item of the collection.
+func (e *ForStatement) Execute(dataContext IDataContext, memory *WorkingMemory) error {
+
+	return e.ExecuteWithContext(context.Background(), dataContext, memory)
+}
+
+// ExecuteWithContext is Execute with the context of the execution, which carries its limits and function registries.
+// It stops as soon as the context is canceled.
+func (e *ForStatement) ExecuteWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+	if e.Body == nil {
+
+		return e.execute(ctx, dataContext, memory, nil)
+	}
+
+	return e.execute(ctx, dataContext, memory, e.Body.ExecuteWithContext)
+}
+
+// execute runs the body, interpreted or compiled, for every item of the collection.
+func (e *ForStatement) execute(ctx context.Context, dataContext IDataContext, memory *WorkingMemory, body compiledStatements) error {
+	// The then scopes are executed one at a time, unlike the collection expressions the body is executed in the frame
+	// of the memory, as its assignments reset the expressions reading the changed variables. Its nodes keep the values
+	// of the last item, they must not be seen after the loop.
+	defer resetThenExpressionList(memory, e.Body)
+
+	_, collectionNode, err := e.Collection.evaluateValueNode(ctx, dataContext, memory)
+	if err != nil {
+
+		return err
+	}
+	items, err := collectionItems(collectionNode)
+	if err != nil {
+
+		return fmt.Errorf("can not execute %s. got %w", e.GrlText, err)
+	}
+	if body == nil {
+
+		return nil
+	}
+	if e.Collection.Variable != nil && assignsTo(e.Body, e.VariableName) {
+		// the body changes the items through the loop variable, which is not seen by the expressions
+		// reading them from the collection, e.g. Order.Items[0].Price, so the collection is reset.
+		defer memory.ResetVariable(e.Collection.Variable)
+	}
+
+	for _, item := range items {
+		if ctx.Err() != nil {
+
+			return fmt.Errorf("context error on executing %s. got %w", e.GrlText, ctx.Err())
+		}
+		resetThenExpressionList(memory, e.Body)
+		err = body(ctx, &collectionDataContext{IDataContext: dataContext, name: e.VariableName, item: item}, memory)
+		if err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assignsTo tells whether the list assigns a field, or an item, of the variable, e.g. item.Price = 10
+// for the variable item. The assignments made through the variables of the nested loops over the variable
+// are taken into account.
+func assignsTo(list *ThenExpressionList, name string) bool {
+	if list == nil {
+
+		return false
+	}
+	for _, thenExpression := range list.ThenExpressions {
+		if thenExpression.Assignment != nil && thenExpression.Assignment.Variable != nil {
+			variable := thenExpression.Assignment.Variable
+			if variable.Variable != nil && len(variable.LocalOf) == 0 && rootVariable(variable).Name == name {
+
+				return true
+			}
+		}
+		if assignsInIfStatement(thenExpression.IfStatement, name) {
+
+			return true
+		}
+		if statement := thenExpression.ForStatement; statement != nil {
+			if assignsTo(statement.Body, name) {
+
+				return true
+			}
+			if statement.Collection != nil && statement.Collection.Variable != nil &&
+				rootVariable(statement.Collection.Variable).Name == name && assignsTo(statement.Body, statement.VariableName) {
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func assignsInIfStatement(statement *IfStatement, name string) bool {
+	if statement == nil {
+
+		return false
+	}
+
+	return assignsTo(statement.Then, name) || assignsInIfStatement(statement.ElseIf, name) || assignsTo(statement.Else, name)
+}
+
+// rootVariable returns the variable the variable is a field or an item of, e.g. Order for Order.Items[0].Price.
+func rootVariable(variable *Variable) *Variable {
+	for variable.Variable != nil {
+		variable = variable.Variable
+	}
+
+	return variable
+}
+
+// resetThenExpressionList marks all the expressions of the list as not evaluated, so they are evaluated again
+// for the next item.
+func resetThenExpressionList(memory *WorkingMemory, list *ThenExpressionList) {
+	if list == nil {
+
+		return
+	}
+	for _, thenExpression := range list.ThenExpressions {
+		if thenExpression.Assignment != nil {
+			resetVariable(memory, thenExpression.Assignment.Variable)
+			resetExpression(memory, thenExpression.Assignment.Expression)
+		}
+		resetExpressionAtom(memory, thenExpression.ExpressionAtom)
+		resetIfStatement(memory, thenExpression.IfStatement)
+		if thenExpression.ForStatement != nil {
+			resetExpressionAtom(memory, thenExpression.ForStatement.Collection)
+			resetThenExpressionList(memory, thenExpression.ForStatement.Body)
+		}
+	}
+}
+
+func resetIfStatement(memory *WorkingMemory, statement *IfStatement) {
+	if statement == nil {
+
+		return
+	}
+	resetExpression(memory, statement.Condition)
+	resetThenExpressionList(memory, statement.Then)
+	resetIfStatement(memory, statement.ElseIf)
+	resetThenExpressionList(memory, statement.Else)
+}
+
+// resetExpression marks the expression and all the nodes under it as not evaluated, so they are evaluated again
+// for the next item.
+func resetExpression(memory *WorkingMemory, expression *Expression) {
+	if expression == nil {
+
+		return
+	}
+	memory.state(expression.slot, expression).evaluated.Store(false)
+	resetExpression(memory, expression.LeftExpression)
+	resetExpression(memory, expression.RightExpression)
+	resetExpression(memory, expression.SingleExpression)
+	resetExpressionAtom(memory, expression.ExpressionAtom)
+}
+
+func resetExpressionAtom(memory *WorkingMemory, atom *ExpressionAtom) {
+	if atom == nil {
+
+		return
+	}
+	memory.state(atom.slot, atom).evaluated.Store(false)
+	resetExpressionAtom(memory, atom.ExpressionAtom)
+	resetVariable(memory, atom.Variable)
+	if atom.ArrayMapSelector != nil {
+		resetExpression(memory, atom.ArrayMapSelector.Expression)
+	}
+	if atom.FunctionCall != nil && atom.FunctionCall.ArgumentList != nil {
+		for _, argument := range atom.FunctionCall.ArgumentList.Arguments {
+			resetExpression(memory, argument)
+		}
+	}
+	if collection := atom.CollectionExpression; collection != nil {
+		resetExpressionAtom(memory, collection.Collection)
+		resetExpression(memory, collection.Filter)
+		resetExpression(memory, collection.Expression)
+	}
+}
+
+func resetVariable(memory *WorkingMemory, variable *Variable) {
+	if variable == nil {
+
+		return
+	}
+	resetVariable(memory, variable.Variable)
+	if variable.ArrayMapSelector != nil {
+		resetExpression(memory, variable.ArrayMapSelector.Expression)
+	}
+}
@@ -0,0 +1,105 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperjumptech/grule-rule-engine/model"
+)
+
+/*
+The AST of a knowledge base is read-only once it is built, it is shared by the blueprint and all its instances.
+What changes while the rules are executed, the values of the expressions, expression atoms and variables and the
+retracted rule entries, is held by the frame of the working memory of each instance.
+The nodes registered into the working memory are given a slot, the index of their state in the frame.
+*/
+
+// nodeState is the evaluation state of an AST node.
+type nodeState struct {
+	// lock guards value and valueNode, as a node can be shared by rule entries evaluated concurrently.
+	lock      sync.Mutex
+	evaluated atomic.Bool
+	value     reflect.Value
+	valueNode model.ValueNode
+}
+
+// frame holds the state of the AST nodes for the executions of a knowledge base instance.
+type frame struct {
+	// states are indexed by the slot of the nodes.
+	states []nodeState
+
+	lock sync.Mutex
+	// detached are the states of the nodes without a slot in states, e.g. nodes not registered into the working memory.
+	detached map[Node]*nodeState
+	// retracted are the names of the rule entries retracted from the execution.
+	retracted map[string]bool
+}
+
+// reset forgets the state of all the nodes, and makes room for size slots. The retracted rule entries are kept.
+func (f *frame) reset(size int) {
+	if len(f.states) == size {
+		clear(f.states)
+	} else {
+		f.states = make([]nodeState, size)
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.detached = nil
+}
+
+// state returns the state of the node in the slot.
+func (f *frame) state(slot int, node Node) *nodeState {
+	if slot > 0 && slot < len(f.states) {
+
+		return &f.states[slot]
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.detached == nil {
+		f.detached = make(map[Node]*nodeState)
+	}
+	state, ok := f.detached[node]
+	if !ok {
+		state = &nodeState{}
+		f.detached[node] = state
+	}
+
+	return state
+}
+
+func (f *frame) retract(ruleName string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.retracted == nil {
+		f.retracted = make(map[string]bool)
+	}
+	f.retracted[ruleName] = true
+}
+
+func (f *frame) isRetracted(ruleName string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.retracted[ruleName]
+}
+
+func (f *frame) clearRetracted() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.retracted = nil
+}
@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
@@ -36,9 +37,11 @@ type FunctionCall struct {
 	AstID   string
 	GrlText string
 
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
 	FunctionName string
 	ArgumentList *ArgumentList
-	Value        reflect.Value
 }
 
 // MakeCatalog will create a catalog entry from FunctionCall node.
@@ -64,6 +67,7 @@ func (e *FunctionCall) Clone(cloneTable *pkg.CloneTable) *FunctionCall {
 	clone := &FunctionCall{
 		AstID:        unique.NewID(),
 		GrlText:      e.GrlText,
+		Position:     e.Position,
 		FunctionName: e.FunctionName,
 	}
 
@@ -127,7 +131,13 @@ func (e *FunctionCall) AcceptArgumentList(argList *ArgumentList) error {
 
 // EvaluateArgumentList will evaluate all arguments and ensure it can be passed into function.
 func (e *FunctionCall) EvaluateArgumentList(dataContext IDataContext, memory *WorkingMemory) ([]reflect.Value, error) {
-	args, err := e.ArgumentList.Evaluate(dataContext, memory)
+
+	return e.EvaluateArgumentListWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateArgumentListWithContext is EvaluateArgumentList with the context of the execution, which carries its limits and function registries.
+func (e *FunctionCall) EvaluateArgumentListWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) ([]reflect.Value, error) {
+	args, err := e.ArgumentList.EvaluateWithContext(ctx, dataContext, memory)
 	if err != nil {
 
 		return nil, err
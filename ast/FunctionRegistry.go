@@ -0,0 +1,391 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+var (
+	functionNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	contextType         = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType           = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NewFunctionRegistry creates a new empty FunctionRegistry.
+func NewFunctionRegistry() *FunctionRegistry {
+
+	return &FunctionRegistry{
+		functions:  make(map[string]*RegisteredFunction),
+		namespaces: make(map[string]int),
+	}
+}
+
+// FunctionRegistry holds Go functions the rules can call without attaching them to a fact in every DataContext.
+// A function registered under a simple name is called like a built-in function, e.g. HashSha256(x), while a function
+// registered under a namespaced name is called like a fact's method, e.g. Geo.Distance(a, b). A fact added into the
+// DataContext with the same name as a namespace hides that namespace.
+//
+// A registry is attached to a KnowledgeLibrary, for all the knowledge bases of that library, or to the engine.
+// Functions are looked up in the engine's registry first.
+type FunctionRegistry struct {
+	lock       sync.RWMutex
+	functions  map[string]*RegisteredFunction
+	namespaces map[string]int
+}
+
+// Register will add a Go function into this registry, under a simple name or a "Namespace.Name" name.
+// The function may be variadic. If its first parameter is a context.Context, the context of the running execution
+// is passed to it. It may return nothing, a value, an error, or a value and an error. A returned error fails the rule
+// being evaluated or executed.
+func (r *FunctionRegistry) Register(name string, function interface{}) error {
+	fn := reflect.ValueOf(function)
+	if fn.Kind() != reflect.Func || fn.IsNil() {
+
+		return fmt.Errorf("can not register %s, %T is not a function", name, function)
+	}
+	registered, err := newRegisteredFunction(name, fn)
+	if err != nil {
+
+		return err
+	}
+
+	return r.add(registered)
+}
+
+// MustRegister will add a Go function into this registry, it panics if the function can not be registered.
+func (r *FunctionRegistry) MustRegister(name string, function interface{}) {
+	if err := r.Register(name, function); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterNamespace will add all the exported methods of the object into this registry, under the namespace.
+// e.g. the method Distance of the object registered as namespace Geo is called as Geo.Distance(a, b).
+func (r *FunctionRegistry) RegisterNamespace(namespace string, object interface{}) error {
+	obj := reflect.ValueOf(object)
+	if !pkg.IsStruct(obj) {
+
+		return fmt.Errorf("can not register namespace %s, %T is not a struct", namespace, object)
+	}
+	methods, err := pkg.GetFunctionList(obj)
+	if err != nil {
+
+		return err
+	}
+	registered := make([]*RegisteredFunction, 0, len(methods))
+	for _, method := range methods {
+		function, err := newRegisteredFunction(namespace+"."+method, obj.MethodByName(method))
+		if err != nil {
+
+			return err
+		}
+		function.receiver = obj
+		function.method = method
+		registered = append(registered, function)
+	}
+	for _, function := range registered {
+		if err := r.add(function); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Unregister will remove a function from this registry.
+func (r *FunctionRegistry) Unregister(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.functions[name]; !ok {
+
+		return
+	}
+	delete(r.functions, name)
+	if namespace, _, ok := strings.Cut(name, "."); ok {
+		r.namespaces[namespace]--
+		if r.namespaces[namespace] == 0 {
+			delete(r.namespaces, namespace)
+		}
+	}
+}
+
+// GetFunction returns the function registered under the name, e.g. "HashSha256" or "Geo.Distance".
+func (r *FunctionRegistry) GetFunction(name string) (*RegisteredFunction, bool) {
+	if r == nil {
+
+		return nil, false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	function, ok := r.functions[name]
+
+	return function, ok
+}
+
+// HasNamespace checks if at least one function is registered under the namespace.
+func (r *FunctionRegistry) HasNamespace(namespace string) bool {
+	if r == nil {
+
+		return false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.namespaces[namespace] > 0
+}
+
+// functionRegistriesKey is the context key of the function registries of an execution.
+type functionRegistriesKey struct{}
+
+// WithFunctionRegistries returns a copy of the context carrying the registries searched, in order, for the functions
+// that are not attached to a fact. Nil registries are ignored.
+func WithFunctionRegistries(ctx context.Context, registries ...*FunctionRegistry) context.Context {
+
+	return context.WithValue(ctx, functionRegistriesKey{}, registries)
+}
+
+// registeredFunctionOf returns the registered function with that name from the first registry of the context having it.
+func registeredFunctionOf(ctx context.Context, name string) (*RegisteredFunction, bool) {
+	if ctx == nil {
+
+		return nil, false
+	}
+	registries, _ := ctx.Value(functionRegistriesKey{}).([]*FunctionRegistry)
+	for _, registry := range registries {
+		if function, ok := registry.GetFunction(name); ok {
+
+			return function, true
+		}
+	}
+
+	return nil, false
+}
+
+// hasFunctionNamespace checks if any of the registries of the context has functions registered under the namespace.
+func hasFunctionNamespace(ctx context.Context, namespace string) bool {
+	if ctx == nil {
+
+		return false
+	}
+	registries, _ := ctx.Value(functionRegistriesKey{}).([]*FunctionRegistry)
+	for _, registry := range registries {
+		if registry.HasNamespace(namespace) {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetFunctionNames returns the names of all the registered functions, sorted.
+func (r *FunctionRegistry) GetFunctionNames() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.functions))
+	for name := range r.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *FunctionRegistry) add(function *RegisteredFunction) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.functions[function.Name]; ok {
+
+		return fmt.Errorf("function %s is already registered", function.Name)
+	}
+	r.functions[function.Name] = function
+	if namespace, _, ok := strings.Cut(function.Name, "."); ok {
+		r.namespaces[namespace]++
+	}
+
+	return nil
+}
+
+func newRegisteredFunction(name string, fn reflect.Value) (*RegisteredFunction, error) {
+	namespace, simpleName, namespaced := strings.Cut(name, ".")
+	if !namespaced {
+		simpleName = namespace
+	}
+	if !functionNamePattern.MatchString(simpleName) || (namespaced && !functionNamePattern.MatchString(namespace)) {
+
+		return nil, fmt.Errorf("can not register %s, a function name is either Name or Namespace.Name", name)
+	}
+	if _, ok := reflect.TypeOf(&BuiltInFunctions{}).MethodByName(name); ok && !namespaced {
+
+		return nil, fmt.Errorf("can not register %s, it is a built-in function", name)
+	}
+	registered := &RegisteredFunction{
+		Name:     name,
+		function: fn,
+	}
+	typ := fn.Type()
+	registered.withContext = typ.NumIn() > 0 && typ.In(0) == contextType
+	switch {
+	case typ.NumOut() == 1 && typ.Out(0) == errorType:
+		registered.withError = true
+	case typ.NumOut() == 2 && typ.Out(1) == errorType:
+		registered.withError = true
+		registered.withValue = true
+	case typ.NumOut() == 1:
+		registered.withValue = true
+	case typ.NumOut() > 1:
+
+		return nil, fmt.Errorf("can not register %s, a function returns at most a value and an error", name)
+	}
+
+	return registered, nil
+}
+
+// RegisteredFunction is a Go function added into a FunctionRegistry.
+type RegisteredFunction struct {
+	// Name is the name the function is called with in the rules.
+	Name string
+
+	function reflect.Value
+	// receiver and method are set for the functions added by RegisterNamespace.
+	receiver reflect.Value
+	method   string
+	// withContext is set if the first parameter is a context.Context, it is not an argument given in the rules.
+	withContext bool
+	withValue   bool
+	withError   bool
+}
+
+// GetParameterTypes returns the types of the arguments given in the rules, and whether the function is variadic.
+// The context.Context parameter, if any, is not included.
+func (f *RegisteredFunction) GetParameterTypes() ([]reflect.Type, bool, error) {
+	var types []reflect.Type
+	variadic := f.function.Type().IsVariadic()
+	if f.receiver.IsValid() {
+		var err error
+		types, variadic, err = pkg.GetFunctionParameterTypes(f.receiver, f.method)
+		if err != nil {
+
+			return nil, false, err
+		}
+	} else {
+		typ := f.function.Type()
+		types = make([]reflect.Type, typ.NumIn())
+		for i := range types {
+			types[i] = typ.In(i)
+		}
+	}
+	if f.withContext {
+		types = types[1:]
+	}
+
+	return types, variadic, nil
+}
+
+// CheckArity checks if the function can be called with that number of arguments.
+func (f *RegisteredFunction) CheckArity(argumentCount int) error {
+	types, variadic, err := f.GetParameterTypes()
+	if err != nil {
+
+		return err
+	}
+	_, err = checkArity(f.Name, len(types), variadic, argumentCount, nil)
+
+	return err
+}
+
+// Call will call the function with the arguments evaluated from the rules. Numeric arguments are converted to
+// the numeric type of the parameter, e.g. an integer constant can be given to a float64 parameter.
+func (f *RegisteredFunction) Call(ctx context.Context, args []reflect.Value) (retVal reflect.Value, err error) {
+	if err := f.CheckArity(len(args)); err != nil {
+
+		return reflect.Value{}, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			retVal = reflect.Value{}
+			err = fmt.Errorf("error when calling function %s. got %v", f.Name, r)
+		}
+	}()
+
+	typ := f.function.Type()
+	params := make([]reflect.Value, 0, len(args)+1)
+	offset := 0
+	if f.withContext {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		params = append(params, reflect.ValueOf(ctx))
+		offset = 1
+	}
+	for i, arg := range args {
+		param, err := f.argument(i+1, arg, parameterType(typ, i+offset))
+		if err != nil {
+
+			return reflect.Value{}, err
+		}
+		params = append(params, param)
+	}
+
+	rets := f.function.Call(params)
+	if f.withError && !rets[len(rets)-1].IsNil() {
+
+		return reflect.Value{}, fmt.Errorf("function %s returned an error. got %w", f.Name, rets[len(rets)-1].Interface().(error))
+	}
+	if f.withValue {
+
+		return rets[0], nil
+	}
+
+	return reflect.Value{}, nil
+}
+
+func (f *RegisteredFunction) argument(index int, arg reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	switch {
+	case !arg.IsValid():
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+
+			return reflect.Zero(typ), nil
+		}
+	case arg.Type().AssignableTo(typ):
+
+		return arg, nil
+	case pkg.IsNumber(arg) && pkg.IsNumber(reflect.Zero(typ)):
+
+		return arg.Convert(typ), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("argument %d of function %s is of type %s, not %s", index, f.Name, valueTypeName(arg), typ)
+}
+
+func valueTypeName(value reflect.Value) string {
+	if !value.IsValid() {
+
+		return "nil"
+	}
+
+	return value.Type().String()
+}
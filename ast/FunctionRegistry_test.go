@@ -0,0 +1,132 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type registryKey struct{}
+
+type RegistryGeo struct {
+	Scale float64
+}
+
+func (g *RegistryGeo) Distance(a, b float64) float64 {
+
+	return (b - a) * g.Scale
+}
+
+func (g *RegistryGeo) Origin() float64 {
+
+	return 0
+}
+
+func TestFunctionRegistry_Register(t *testing.T) {
+	registry := NewFunctionRegistry()
+	assert.NoError(t, registry.Register("Double", func(i int64) int64 { return i * 2 }))
+	assert.NoError(t, registry.Register("Str.Join", func(sep string, items ...string) string { return "" }))
+
+	assert.Error(t, registry.Register("Double", func(i int64) int64 { return i }))
+	assert.Error(t, registry.Register("NotAFunction", 10))
+	assert.Error(t, registry.Register("Bad Name", func() {}))
+	assert.Error(t, registry.Register("A.B.C", func() {}))
+	assert.Error(t, registry.Register("Now", func() {}))
+	assert.Error(t, registry.Register("Pair", func() (int, int) { return 0, 0 }))
+
+	assert.Equal(t, []string{"Double", "Str.Join"}, registry.GetFunctionNames())
+	assert.True(t, registry.HasNamespace("Str"))
+	assert.False(t, registry.HasNamespace("Double"))
+
+	registry.Unregister("Str.Join")
+	assert.False(t, registry.HasNamespace("Str"))
+	_, ok := registry.GetFunction("Str.Join")
+	assert.False(t, ok)
+}
+
+func TestFunctionRegistry_RegisterNamespace(t *testing.T) {
+	registry := NewFunctionRegistry()
+	assert.NoError(t, registry.RegisterNamespace("Geo", &RegistryGeo{Scale: 2}))
+	assert.Error(t, registry.RegisterNamespace("Bad", "not a struct"))
+	assert.Equal(t, []string{"Geo.Distance", "Geo.Origin"}, registry.GetFunctionNames())
+
+	function, ok := registry.GetFunction("Geo.Distance")
+	if assert.True(t, ok) {
+		types, variadic, err := function.GetParameterTypes()
+		assert.NoError(t, err)
+		assert.False(t, variadic)
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(0.0), reflect.TypeOf(0.0)}, types)
+
+		// integer arguments are converted to the float64 parameters.
+		ret, err := function.Call(context.Background(), []reflect.Value{reflect.ValueOf(int64(1)), reflect.ValueOf(4.5)})
+		assert.NoError(t, err)
+		assert.Equal(t, 7.0, ret.Float())
+	}
+}
+
+func TestRegisteredFunction_Call(t *testing.T) {
+	registry := NewFunctionRegistry()
+	registry.MustRegister("FromContext", func(ctx context.Context, prefix string) string {
+		value, _ := ctx.Value(registryKey{}).(string)
+
+		return prefix + value
+	})
+	registry.MustRegister("Sum", func(values ...int64) int64 {
+		var sum int64
+		for _, value := range values {
+			sum += value
+		}
+
+		return sum
+	})
+	registry.MustRegister("Fail", func(fail bool) (string, error) {
+		if fail {
+
+			return "", errors.New("failed")
+		}
+
+		return "ok", nil
+	})
+
+	fromContext, _ := registry.GetFunction("FromContext")
+	types, _, _ := fromContext.GetParameterTypes()
+	assert.Len(t, types, 1)
+	ctx := context.WithValue(context.Background(), registryKey{}, "value")
+	ret, err := fromContext.Call(ctx, []reflect.Value{reflect.ValueOf("the ")})
+	assert.NoError(t, err)
+	assert.Equal(t, "the value", ret.String())
+
+	sum, _ := registry.GetFunction("Sum")
+	assert.NoError(t, sum.CheckArity(0))
+	ret, err = sum.Call(context.Background(), []reflect.Value{reflect.ValueOf(int64(1)), reflect.ValueOf(2), reflect.ValueOf(int64(3))})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), ret.Int())
+
+	fail, _ := registry.GetFunction("Fail")
+	ret, err = fail.Call(context.Background(), []reflect.Value{reflect.ValueOf(false)})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", ret.String())
+	_, err = fail.Call(context.Background(), []reflect.Value{reflect.ValueOf(true)})
+	assert.EqualError(t, err, "function Fail returned an error. got failed")
+	_, err = fail.Call(context.Background(), nil)
+	assert.EqualError(t, err, "function Fail requires 1 argument(s), got 0")
+	_, err = fail.Call(context.Background(), []reflect.Value{reflect.ValueOf("true")})
+	assert.EqualError(t, err, "argument 1 of function Fail is of type string, not bool")
+}
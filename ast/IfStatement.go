@@ -0,0 +1,247 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+// NewIfStatement creates new instance of IfStatement
+func NewIfStatement() *IfStatement {
+
+	return &IfStatement{
+		AstID: unique.NewID(),
+	}
+}
+
+// IfStatement AST graph node. It executes the Then list if the Condition is true, otherwise the ElseIf statement
+// or the Else list, e.g. if Order.Total > 100 { ... } else if Order.Total > 50 { ... } else { ... }
+type IfStatement struct {
+	AstID   string
+	GrlText string
+
+	Condition *Expression
+	Then      *ThenExpressionList
+	// ElseIf is the if statement following else, nil if there is none.
+	ElseIf *IfStatement
+	// Else is the list following else, nil if there is none.
+	Else *ThenExpressionList
+}
+
+// MakeCatalog will create a catalog entry from IfStatement node.
+func (e *IfStatement) MakeCatalog(cat *Catalog) {
+	meta := &IfStatementMeta{
+		NodeMeta: NodeMeta{
+			AstID:    e.AstID,
+			GrlText:  e.GrlText,
+			Snapshot: e.GetSnapshot(),
+		},
+	}
+	if cat.AddMeta(e.AstID, meta) {
+		if e.Condition != nil {
+			meta.ConditionID = e.Condition.AstID
+			e.Condition.MakeCatalog(cat)
+		}
+		if e.Then != nil {
+			meta.ThenID = e.Then.AstID
+			e.Then.MakeCatalog(cat)
+		}
+		if e.ElseIf != nil {
+			meta.ElseIfID = e.ElseIf.AstID
+			e.ElseIf.MakeCatalog(cat)
+		}
+		if e.Else != nil {
+			meta.ElseID = e.Else.AstID
+			e.Else.MakeCatalog(cat)
+		}
+	}
+}
+
+// Clone will clone this IfStatement. The new clone will have an identical structure
+func (e *IfStatement) Clone(cloneTable *pkg.CloneTable) *IfStatement {
+	clone := &IfStatement{
+		AstID:   unique.NewID(),
+		GrlText: e.GrlText,
+	}
+
+	if e.Condition != nil {
+		if cloneTable.IsCloned(e.Condition.AstID) {
+			clone.Condition = cloneTable.Records[e.Condition.AstID].CloneInstance.(*Expression)
+		} else {
+			cloned := e.Condition.Clone(cloneTable)
+			clone.Condition = cloned
+			cloneTable.MarkCloned(e.Condition.AstID, cloned.AstID, e.Condition, cloned)
+		}
+	}
+
+	if e.Then != nil {
+		if cloneTable.IsCloned(e.Then.AstID) {
+			clone.Then = cloneTable.Records[e.Then.AstID].CloneInstance.(*ThenExpressionList)
+		} else {
+			cloned := e.Then.Clone(cloneTable)
+			clone.Then = cloned
+			cloneTable.MarkCloned(e.Then.AstID, cloned.AstID, e.Then, cloned)
+		}
+	}
+
+	if e.ElseIf != nil {
+		if cloneTable.IsCloned(e.ElseIf.AstID) {
+			clone.ElseIf = cloneTable.Records[e.ElseIf.AstID].CloneInstance.(*IfStatement)
+		} else {
+			cloned := e.ElseIf.Clone(cloneTable)
+			clone.ElseIf = cloned
+			cloneTable.MarkCloned(e.ElseIf.AstID, cloned.AstID, e.ElseIf, cloned)
+		}
+	}
+
+	if e.Else != nil {
+		if cloneTable.IsCloned(e.Else.AstID) {
+			clone.Else = cloneTable.Records[e.Else.AstID].CloneInstance.(*ThenExpressionList)
+		} else {
+			cloned := e.Else.Clone(cloneTable)
+			clone.Else = cloned
+			cloneTable.MarkCloned(e.Else.AstID, cloned.AstID, e.Else, cloned)
+		}
+	}
+
+	return clone
+}
+
+// IfStatementReceiver should be implemented by AST graph node to receive an IfStatement AST graph node
+type IfStatementReceiver interface {
+	AcceptIfStatement(statement *IfStatement) error
+}
+
+// AcceptExpression will accept the condition Expression AST graph into this ast graph
+func (e *IfStatement) AcceptExpression(exp *Expression) error {
+	if e.Condition != nil {
+
+		return errors.New("condition for IfStatement already assigned")
+	}
+	e.Condition = exp
+
+	return nil
+}
+
+// AcceptThenExpressionList will accept the Then list, then the Else list into this ast graph
+func (e *IfStatement) AcceptThenExpressionList(list *ThenExpressionList) error {
+	if e.Then == nil {
+		e.Then = list
+
+		return nil
+	}
+	if e.Else != nil || e.ElseIf != nil {
+
+		return errors.New("else for IfStatement already assigned")
+	}
+	e.Else = list
+
+	return nil
+}
+
+// AcceptIfStatement will accept the else if statement into this ast graph
+func (e *IfStatement) AcceptIfStatement(statement *IfStatement) error {
+	if e.Else != nil || e.ElseIf != nil {
+
+		return errors.New("else for IfStatement already assigned")
+	}
+	e.ElseIf = statement
+
+	return nil
+}
+
+// GetAstID get the UUID asigned for this AST graph node
+func (e *IfStatement) GetAstID() string {
+
+	return e.AstID
+}
+
+// GetGrlText get the expression syntax related to this graph when it wast constructed
+func (e *IfStatement) GetGrlText() string {
+
+	return e.GrlText
+}
+
+// GetSnapshot will create a structure signature or AST graph
+func (e *IfStatement) GetSnapshot() string {
+	var buff bytes.Buffer
+	buff.WriteString(IFSTATEMENT)
+	buff.WriteString("(")
+	if e.Condition != nil {
+		buff.WriteString(e.Condition.GetSnapshot())
+	}
+	if e.Then != nil {
+		buff.WriteString(",then:")
+		buff.WriteString(e.Then.GetSnapshot())
+	}
+	if e.ElseIf != nil {
+		buff.WriteString(",elseif:")
+		buff.WriteString(e.ElseIf.GetSnapshot())
+	}
+	if e.Else != nil {
+		buff.WriteString(",else:")
+		buff.WriteString(e.Else.GetSnapshot())
+	}
+	buff.WriteString(")")
+
+	return buff.String()
+}
+
+// SetGrlText set the expression syntax related to this graph when it was constructed. Only ANTLR4 listener should
+// call this function.
+func (e *IfStatement) SetGrlText(grlText string) {
+	e.GrlText = grlText
+}
+
+// Execute will execute this graph in the Then scope
+func (e *IfStatement) Execute(dataContext IDataContext, memory *WorkingMemory) error {
+
+	return e.ExecuteWithContext(context.Background(), dataContext, memory)
+}
+
+// ExecuteWithContext is Execute with the context of the execution, which carries its limits and function registries.
+func (e *IfStatement) ExecuteWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
+	condition, err := e.Condition.EvaluateWithContext(ctx, dataContext, memory)
+	if err != nil {
+
+		return err
+	}
+	if condition.Kind() != reflect.Bool {
+
+		return fmt.Errorf("condition of %s is not a boolean", e.GrlText)
+	}
+	switch {
+	case condition.Bool():
+		if e.Then != nil {
+
+			return e.Then.ExecuteWithContext(ctx, dataContext, memory)
+		}
+	case e.ElseIf != nil:
+
+		return e.ElseIf.ExecuteWithContext(ctx, dataContext, memory)
+	case e.Else != nil:
+
+		return e.Else.ExecuteWithContext(ctx, dataContext, memory)
+	}
+
+	return nil
+}
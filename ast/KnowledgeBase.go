@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
@@ -31,47 +32,86 @@ import (
 func NewKnowledgeLibrary() *KnowledgeLibrary {
 
 	return &KnowledgeLibrary{
-		Library: make(map[string]*KnowledgeBase),
+		Library:          make(map[string]*KnowledgeBase),
+		FunctionRegistry: NewFunctionRegistry(),
 	}
 }
 
 // KnowledgeLibrary is a knowledgebase store.
 type KnowledgeLibrary struct {
 	Library map[string]*KnowledgeBase
+	// FunctionRegistry holds the functions callable by the rules of all the knowledge bases in this library.
+	FunctionRegistry *FunctionRegistry
+
+	// lock guards Library, so blueprints can be swapped while instances are created.
+	lock sync.RWMutex
 }
 
 // GetKnowledgeBase will get the actual KnowledgeBase blue print that will be used to create instances.
 // Although this KnowledgeBase blueprint works, It SHOULD NOT be used directly in the engine.
 // You should obtain KnowledgeBase instance by calling NewKnowledgeBaseInstance
 func (lib *KnowledgeLibrary) GetKnowledgeBase(name, version string) *KnowledgeBase {
+	lib.lock.Lock()
+	defer lib.lock.Unlock()
 	knowledgeBase, ok := lib.Library[GetKnowledgeBaseKey(name, version)]
 	if ok {
 
 		return knowledgeBase
 	}
 	knowledgeBase = &KnowledgeBase{
-		Name:          name,
-		Version:       version,
-		RuleEntries:   make(map[string]*RuleEntry),
-		WorkingMemory: NewWorkingMemory(name, version),
+		Name:             name,
+		Version:          version,
+		RuleEntries:      make(map[string]*RuleEntry),
+		WorkingMemory:    NewWorkingMemory(name, version),
+		FunctionRegistry: lib.FunctionRegistry,
 	}
 	lib.Library[GetKnowledgeBaseKey(name, version)] = knowledgeBase
 
 	return knowledgeBase
 }
 
+// LookupKnowledgeBase will get the KnowledgeBase blue print identified by its name and version, if it exists.
+// Unlike GetKnowledgeBase, it does not create an empty one.
+func (lib *KnowledgeLibrary) LookupKnowledgeBase(name, version string) (*KnowledgeBase, bool) {
+	lib.lock.RLock()
+	defer lib.lock.RUnlock()
+	knowledgeBase, ok := lib.Library[GetKnowledgeBaseKey(name, version)]
+
+	return knowledgeBase, ok
+}
+
+// SwapKnowledgeBase will replace the KnowledgeBase blue print having the same name and version with this one,
+// and return the replaced one, nil if there is none. The instances created from the replaced blue print are not
+// changed, so their executions finish on it, the instances created afterward are created from this one.
+func (lib *KnowledgeLibrary) SwapKnowledgeBase(knowledgeBase *KnowledgeBase) *KnowledgeBase {
+	lib.lock.Lock()
+	defer lib.lock.Unlock()
+	knowledgeBase.FunctionRegistry = lib.FunctionRegistry
+	key := GetKnowledgeBaseKey(knowledgeBase.Name, knowledgeBase.Version)
+	previous := lib.Library[key]
+	lib.Library[key] = knowledgeBase
+
+	return previous
+}
+
 // RemoveRuleEntry mark the rule entry as deleted
 func (lib *KnowledgeLibrary) RemoveRuleEntry(ruleName, name string, version string) {
-	nameVersion := GetKnowledgeBaseKey(name, version)
-	_, ok := lib.Library[nameVersion]
+	knowledgeBase, ok := lib.LookupKnowledgeBase(name, version)
+	if !ok {
+
+		return
+	}
+	// the rule entries of the blue print are guarded by its own lock, which is held while instances are created from it.
+	knowledgeBase.lock.Lock()
+	defer knowledgeBase.lock.Unlock()
+	ruleEntry, ok := knowledgeBase.RuleEntries[ruleName]
 	if ok {
-		ruleEntry, ok := lib.Library[nameVersion].RuleEntries[ruleName]
-		if ok {
-			lib.Library[nameVersion].RuleEntries[ruleName].RuleName = fmt.Sprintf("Deleted_%s", uuid.New().String())
-			lib.Library[nameVersion].RuleEntries[ruleName].Deleted = true
-			delete(lib.Library[nameVersion].RuleEntries, ruleName)
-			lib.Library[nameVersion].RuleEntries[ruleEntry.RuleName] = ruleEntry
-		}
+		deleted := *ruleEntry
+		deleted.RuleName = fmt.Sprintf("Deleted_%s", uuid.New().String())
+		deleted.Deleted = true
+		delete(knowledgeBase.RuleEntries, ruleName)
+		knowledgeBase.RuleEntries[deleted.RuleName] = &deleted
+		knowledgeBase.generation.Add(1)
 	}
 }
 
@@ -97,6 +137,9 @@ func (lib *KnowledgeLibrary) LoadKnowledgeBaseFromReader(reader io.Reader, overw
 	if err != nil {
 		return nil, err
 	}
+	knowledgeBase.FunctionRegistry = lib.FunctionRegistry
+	lib.lock.Lock()
+	defer lib.lock.Unlock()
 	if overwrite {
 		lib.Library[GetKnowledgeBaseKey(knowledgeBase.Name,knowledgeBase.Version)] = knowledgeBase
 
@@ -130,26 +173,45 @@ func (lib *KnowledgeLibrary) StoreKnowledgeBaseToWriter(writer io.Writer, name,
 // NewKnowledgeBaseInstance will create a new instance based on KnowledgeBase blue print
 // identified by its name and version
 func (lib *KnowledgeLibrary) NewKnowledgeBaseInstance(name, version string) (*KnowledgeBase, error) {
-	knowledgeBase, ok := lib.Library[GetKnowledgeBaseKey(name, version)]
+	knowledgeBase, ok := lib.LookupKnowledgeBase(name, version)
 	if ok {
-		newClone, err := knowledgeBase.Clone(pkg.NewCloneTable())
-		if err != nil {
-			return nil, err
-		}
-		if knowledgeBase.IsIdentical(newClone) {
-			AstLog.Debugf("Successfully create instance [%s:%s]", newClone.Name, newClone.Version)
 
-			return newClone, nil
-		}
-		AstLog.Fatalf("ORIGIN   : %s", knowledgeBase.GetSnapshot())
-		AstLog.Fatalf("CLONE    : %s", newClone.GetSnapshot())
-
-		return nil, fmt.Errorf("the clone is not identical")
+		return lib.newInstance(knowledgeBase)
 	}
 
 	return nil, fmt.Errorf("specified knowledge base name and version not exist")
 }
 
+// newInstance will create a new instance based on the KnowledgeBase blue print. The instance shares the when and then
+// scopes of the blue print, which are not changed by the executions, and has a working memory of its own holding
+// the evaluation state.
+func (lib *KnowledgeLibrary) newInstance(knowledgeBase *KnowledgeBase) (*KnowledgeBase, error) {
+	knowledgeBase.lock.Lock()
+	defer knowledgeBase.lock.Unlock()
+	if knowledgeBase.WorkingMemory == nil {
+
+		return nil, fmt.Errorf("knowledge base %s version %s has no working memory", knowledgeBase.Name, knowledgeBase.Version)
+	}
+	instance := &KnowledgeBase{
+		Name:             knowledgeBase.Name,
+		Version:          knowledgeBase.Version,
+		RuleEntries:      make(map[string]*RuleEntry, len(knowledgeBase.RuleEntries)),
+		WorkingMemory:    knowledgeBase.WorkingMemory.Instance(),
+		FunctionRegistry: lib.FunctionRegistry,
+		compiled:         knowledgeBase.compiled,
+	}
+	// the rule entries are copied, so their metadata can be changed without changing the other instances,
+	// while their scopes are shared.
+	for name, entry := range knowledgeBase.RuleEntries {
+		copied := *entry
+		copied.Annotations = copyAnnotations(entry.Annotations)
+		instance.RuleEntries[name] = &copied
+	}
+	AstLog.Debugf("Successfully create instance [%s:%s]", instance.Name, instance.Version)
+
+	return instance, nil
+}
+
 // KnowledgeBase is a collection of RuleEntries. It has a name and version.
 type KnowledgeBase struct {
 	lock          sync.Mutex
@@ -158,6 +220,18 @@ type KnowledgeBase struct {
 	DataContext   IDataContext
 	WorkingMemory *WorkingMemory
 	RuleEntries   map[string]*RuleEntry
+	// FunctionRegistry holds the functions callable by the rules, it is shared with the KnowledgeLibrary.
+	FunctionRegistry *FunctionRegistry
+
+	// focusStack holds the agenda groups that were given the focus, the last one has the focus.
+	focusStack []string
+
+	// compiled is true once Compile is called, the rule entries added afterward are compiled as well.
+	compiled bool
+
+	// generation is increased by every change of the rule entries, the pools of this knowledge base use it to
+	// tell their instances are outdated.
+	generation atomic.Uint64
 }
 
 // MakeCatalog will create a catalog entry for all AST Nodes under the KnowledgeBase
@@ -216,11 +290,13 @@ func (e *KnowledgeBase) GetSnapshot() string {
 }
 
 // Clone will clone this instance of KnowledgeBase and produce another (structure wise) identical instance.
+// Unlike the instances created by NewKnowledgeBaseInstance, the clone does not share the AST.
 func (e *KnowledgeBase) Clone(cloneTable *pkg.CloneTable) (*KnowledgeBase, error) {
 	clone := &KnowledgeBase{
-		Name:        e.Name,
-		Version:     e.Version,
-		RuleEntries: make(map[string]*RuleEntry),
+		Name:             e.Name,
+		Version:          e.Version,
+		RuleEntries:      make(map[string]*RuleEntry),
+		FunctionRegistry: e.FunctionRegistry,
 	}
 	if e.RuleEntries != nil {
 		for k, entry := range e.RuleEntries {
@@ -240,6 +316,10 @@ func (e *KnowledgeBase) Clone(cloneTable *pkg.CloneTable) (*KnowledgeBase, error
 		}
 		clone.WorkingMemory = wm
 	}
+	// the compiled closures refer to the AST nodes they are compiled from, the clone compiles its own nodes.
+	if e.compiled {
+		clone.Compile()
+	}
 
 	return clone, nil
 }
@@ -251,13 +331,49 @@ func (e *KnowledgeBase) AddRuleEntry(entry *RuleEntry) error {
 	defer e.lock.Unlock()
 	if e.ContainsRuleEntry(entry.RuleName) {
 
-		return fmt.Errorf("rule entry %s already exist", entry.RuleName)
+		return &RuleEntryExistError{RuleName: entry.RuleName}
 	}
 	e.RuleEntries[entry.RuleName] = entry
+	if e.compiled {
+		entry.compile()
+	}
+	e.generation.Add(1)
 
 	return nil
 }
 
+// Compile will compile the when and then scope of all rule entries into Go closures, so executing them does not
+// need to walk the AST. The operations are specialized for the types of their operands on their first execution.
+// Function calls and the parts the compiler does not support are still evaluated by the AST, as well as the
+// whole knowledge base when its execution is traced. The clones of a compiled knowledge base are compiled too.
+// Compiling an instance does not compile the other instances of its blueprint, while the instances created from
+// a compiled blueprint are compiled.
+// Compile must not be called while the knowledge base is executed.
+func (e *KnowledgeBase) Compile() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for _, entry := range e.RuleEntries {
+		entry.compile()
+	}
+	e.compiled = true
+	if e.WorkingMemory != nil {
+		e.WorkingMemory.compiled = true
+	}
+	e.generation.Add(1)
+}
+
+// Generation is increased by every change of the rule entries of this knowledge base, e.g. when rules are added to it.
+func (e *KnowledgeBase) Generation() uint64 {
+
+	return e.generation.Load()
+}
+
+// IsCompiled tells whether Compile has been called on this knowledge base.
+func (e *KnowledgeBase) IsCompiled() bool {
+
+	return e.compiled
+}
+
 // ContainsRuleEntry will check if a rule with such name is already exist in this knowledge base.
 func (e *KnowledgeBase) ContainsRuleEntry(name string) bool {
 	_, ok := e.RuleEntries[name]
@@ -272,14 +388,36 @@ func (e *KnowledgeBase) RemoveRuleEntry(name string) {
 	if e.ContainsRuleEntry(name) {
 		//mark the rule as deleted and change the rule name to DELETED_XXX_XXXXX to avoid duplicate rule entry issue
 		//Note: This is a workaround, will improve this logic a bit in near future
-		ruleEntry := e.RuleEntries[name]
-		e.RuleEntries[name].RuleName = fmt.Sprintf("Deleted_%s", ruleEntry.RuleName)
-		e.RuleEntries[name].Deleted = true
+		//The rule entry may be shared with a clone of this knowledge base, the deleted one is a copy.
+		deleted := *e.RuleEntries[name]
+		deleted.RuleName = fmt.Sprintf("Deleted_%s", name)
+		deleted.Deleted = true
 		delete(e.RuleEntries, name)
-		e.RuleEntries[ruleEntry.RuleName] = ruleEntry
+		e.RuleEntries[deleted.RuleName] = &deleted
+		e.generation.Add(1)
 	}
 }
 
+// GetRuleEntriesByAnnotation returns the rule entries having the annotation, sorted by their name.
+// If values are given, only the rule entries having the annotation with at least one of the values are returned,
+// e.g. GetRuleEntriesByAnnotation("tags", "kyc") returns the rule entries annotated with @tags("kyc", ...).
+func (e *KnowledgeBase) GetRuleEntriesByAnnotation(name string, values ...string) []*RuleEntry {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	entries := make([]*RuleEntry, 0)
+	for _, entry := range e.RuleEntries {
+		if !entry.Deleted && entry.HasAnnotation(name, values...) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+
+		return entries[i].RuleName < entries[j].RuleName
+	})
+
+	return entries
+}
+
 // InitializeContext will initialize this AST graph with data context and working memory before running rule on them.
 func (e *KnowledgeBase) InitializeContext(dataCtx IDataContext) {
 	e.DataContext = dataCtx
@@ -287,32 +425,52 @@ func (e *KnowledgeBase) InitializeContext(dataCtx IDataContext) {
 
 // RetractRule will retract the selected rule for execution on the next cycle.
 func (e *KnowledgeBase) RetractRule(ruleName string) {
-	for _, re := range e.RuleEntries {
-		if re.RuleName == ruleName {
-			re.Retracted = true
-		}
-	}
+	e.WorkingMemory.RetractRule(ruleName)
 }
 
 // IsRuleRetracted will check if a certain rule denoted by its rule name is currently retracted
 func (e *KnowledgeBase) IsRuleRetracted(ruleName string) bool {
-	for _, re := range e.RuleEntries {
-		if re.RuleName == ruleName {
 
-			return re.Retracted
-		}
-	}
-
-	return false
+	return e.WorkingMemory.IsRuleRetracted(ruleName)
 }
 
 // Reset will restore all rule in the knowledge
 func (e *KnowledgeBase) Reset() {
-	for _, re := range e.RuleEntries {
-		if re.Retracted {
-			re.Retracted = false
-		}
+	e.WorkingMemory.ClearRetracted()
+	e.focusStack = nil
+}
+
+// SetFocus will give the focus to the specified agenda group, only rule entries of the focused agenda group
+// are candidates for execution. The previously focused agenda group regains the focus once this one is popped.
+func (e *KnowledgeBase) SetFocus(agendaGroup string) {
+	if e.GetFocus() == agendaGroup {
+
+		return
 	}
+	e.focusStack = append(e.focusStack, agendaGroup)
+}
+
+// PopFocus will remove the focus from the currently focused agenda group and give it back to the
+// previously focused one. It returns false if there is no agenda group to pop, in which case
+// the DefaultAgendaGroup keeps the focus.
+func (e *KnowledgeBase) PopFocus() bool {
+	if len(e.focusStack) == 0 {
+
+		return false
+	}
+	e.focusStack = e.focusStack[:len(e.focusStack)-1]
+
+	return true
+}
+
+// GetFocus will return the agenda group that currently has the focus.
+func (e *KnowledgeBase) GetFocus() string {
+	if len(e.focusStack) == 0 {
+
+		return DefaultAgendaGroup
+	}
+
+	return e.focusStack[len(e.focusStack)-1]
 }
 
 // GetKnowledgeBaseKey returns the key corresponding to the knowledgeBase in the KnowledgeLibrary
@@ -0,0 +1,266 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// KnowledgeBasePoolOptions are the limits of a KnowledgeBasePool.
+type KnowledgeBasePoolOptions struct {
+	// MinIdle is the number of instances created in advance, ready to be taken by Get.
+	MinIdle int
+	// MaxIdle is the maximum number of idle instances, the instances given back beyond it are dropped.
+	// Zero means MinIdle.
+	MaxIdle int
+	// MaxSize is the maximum number of instances, idle or taken. Get waits for an instance to be given back
+	// once it is reached. Zero means no limit.
+	MaxSize int
+}
+
+// KnowledgeBasePoolStats tells how many instances a KnowledgeBasePool holds.
+type KnowledgeBasePoolStats struct {
+	Idle  int
+	InUse int
+}
+
+// NewKnowledgeBasePool creates a pool of instances of the KnowledgeBase blueprint identified by its name and version.
+// The pool is invalidated when the blueprint is changed, e.g. rules are added to it by a RuleBuilder or it is
+// replaced by LoadKnowledgeBaseFromReader or SwapKnowledgeBase: its idle instances are dropped, the taken ones are dropped when given
+// back, and MinIdle new instances are created from the changed blueprint in the background.
+// The blueprint must not be changed while the pool creates instances from it.
+func (lib *KnowledgeLibrary) NewKnowledgeBasePool(name, version string, options KnowledgeBasePoolOptions) (*KnowledgeBasePool, error) {
+	if options.MinIdle < 0 || options.MaxIdle < 0 || options.MaxSize < 0 {
+
+		return nil, fmt.Errorf("knowledge base pool limits must not be negative")
+	}
+	if options.MaxIdle == 0 {
+		options.MaxIdle = options.MinIdle
+	}
+	if options.MaxIdle < options.MinIdle {
+
+		return nil, fmt.Errorf("knowledge base pool MaxIdle %d is less than MinIdle %d", options.MaxIdle, options.MinIdle)
+	}
+	if options.MaxSize > 0 && options.MaxSize < options.MaxIdle {
+
+		return nil, fmt.Errorf("knowledge base pool MaxSize %d is less than MaxIdle %d", options.MaxSize, options.MaxIdle)
+	}
+	pool := &KnowledgeBasePool{
+		lib:      lib,
+		name:     name,
+		version:  version,
+		options:  options,
+		taken:    make(map[*KnowledgeBase]poolOrigin),
+		released: make(chan struct{}),
+	}
+	err := pool.Warm()
+	if err != nil {
+
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// KnowledgeBasePool holds instances of a KnowledgeBase blueprint, so they are not cloned for every execution.
+// An instance is taken by Get and must be given back by Put once the execution is done.
+// A KnowledgeBasePool is safe for concurrent use.
+type KnowledgeBasePool struct {
+	lib     *KnowledgeLibrary
+	name    string
+	version string
+	options KnowledgeBasePoolOptions
+
+	lock sync.Mutex
+	// origin is the blueprint the idle instances are created from.
+	origin poolOrigin
+	idle   []*KnowledgeBase
+	// taken holds the blueprint each taken instance is created from.
+	taken map[*KnowledgeBase]poolOrigin
+	// creating is the number of instances being created, and returning the number of instances being reset
+	// by Put, they count in the MaxSize.
+	creating  int
+	returning int
+	// released is closed, and replaced, when an instance is given back or dropped.
+	released chan struct{}
+}
+
+// poolOrigin identifies a blueprint, as it was when an instance is created from it.
+type poolOrigin struct {
+	blueprint  *KnowledgeBase
+	generation uint64
+}
+
+// Warm creates instances until MinIdle instances are idle, within MaxSize.
+func (pool *KnowledgeBasePool) Warm() error {
+	for {
+		pool.lock.Lock()
+		err := pool.refresh()
+		if err != nil {
+			pool.lock.Unlock()
+
+			return err
+		}
+		if len(pool.idle)+pool.creating >= pool.options.MinIdle || pool.isFull() {
+			pool.lock.Unlock()
+
+			return nil
+		}
+		pool.creating++
+		origin := pool.origin
+		pool.lock.Unlock()
+
+		instance, err := pool.lib.newInstance(origin.blueprint)
+		pool.lock.Lock()
+		pool.creating--
+		if err == nil && origin == pool.origin {
+			pool.idle = append(pool.idle, instance)
+		}
+		pool.lock.Unlock()
+		if err != nil {
+
+			return err
+		}
+	}
+}
+
+// Get takes an idle instance, or creates a new one if none is idle. If MaxSize instances are already taken,
+// it waits for one of them to be given back, until the context is done.
+func (pool *KnowledgeBasePool) Get(ctx context.Context) (*KnowledgeBase, error) {
+	for {
+		pool.lock.Lock()
+		err := pool.refresh()
+		if err != nil {
+			pool.lock.Unlock()
+
+			return nil, err
+		}
+		if len(pool.idle) > 0 {
+			instance := pool.idle[len(pool.idle)-1]
+			pool.idle = pool.idle[:len(pool.idle)-1]
+			pool.taken[instance] = pool.origin
+			pool.lock.Unlock()
+
+			return instance, nil
+		}
+		if !pool.isFull() {
+			pool.creating++
+			origin := pool.origin
+			pool.lock.Unlock()
+
+			instance, err := pool.lib.newInstance(origin.blueprint)
+			pool.lock.Lock()
+			pool.creating--
+			if err == nil {
+				pool.taken[instance] = origin
+			}
+			pool.release()
+			pool.lock.Unlock()
+
+			return instance, err
+		}
+		released := pool.released
+		pool.lock.Unlock()
+		select {
+		case <-released:
+		case <-ctx.Done():
+
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Put gives back an instance taken by Get. The instance is reset, and dropped if MaxIdle instances are already idle
+// or the blueprint has changed since it was created.
+func (pool *KnowledgeBasePool) Put(instance *KnowledgeBase) error {
+	// the instance is not taken anymore once Put starts, so it can not be given back twice while it is reset.
+	pool.lock.Lock()
+	origin, ok := pool.taken[instance]
+	if ok {
+		delete(pool.taken, instance)
+		pool.returning++
+	}
+	pool.lock.Unlock()
+	if !ok {
+
+		return fmt.Errorf("knowledge base instance %s version %s is not taken from this pool", instance.Name, instance.Version)
+	}
+	// the reset waits for the evaluations still running in the background, the pool is not locked meanwhile.
+	instance.WorkingMemory.ResetAll()
+	instance.WorkingMemory.ClearLocals()
+	instance.Reset()
+	instance.DataContext = nil
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	pool.returning--
+	defer pool.release()
+	if pool.refresh() != nil || origin != pool.origin || len(pool.idle) >= pool.options.MaxIdle {
+
+		return nil
+	}
+	pool.idle = append(pool.idle, instance)
+
+	return nil
+}
+
+// Stats tells how many instances are idle and taken.
+func (pool *KnowledgeBasePool) Stats() KnowledgeBasePoolStats {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	return KnowledgeBasePoolStats{
+		Idle:  len(pool.idle),
+		InUse: len(pool.taken) + pool.returning,
+	}
+}
+
+// refresh drops the idle instances if the blueprint has changed, new ones are created in the background.
+// The lock must be held.
+func (pool *KnowledgeBasePool) refresh() error {
+	blueprint, ok := pool.lib.LookupKnowledgeBase(pool.name, pool.version)
+	if !ok {
+		pool.idle = nil
+
+		return fmt.Errorf("knowledge base %s version %s not exist", pool.name, pool.version)
+	}
+	origin := poolOrigin{blueprint: blueprint, generation: blueprint.Generation()}
+	if origin != pool.origin {
+		warm := pool.origin.blueprint != nil && pool.options.MinIdle > 0
+		pool.origin = origin
+		pool.idle = nil
+		if warm {
+			go func() {
+				_ = pool.Warm()
+			}()
+		}
+	}
+
+	return nil
+}
+
+// isFull tells whether MaxSize instances exist. The lock must be held.
+func (pool *KnowledgeBasePool) isFull() bool {
+
+	return pool.options.MaxSize > 0 && len(pool.idle)+len(pool.taken)+pool.creating+pool.returning >= pool.options.MaxSize
+}
+
+// release wakes up the Get calls waiting for an instance. The lock must be held.
+func (pool *KnowledgeBasePool) release() {
+	close(pool.released)
+	pool.released = make(chan struct{})
+}
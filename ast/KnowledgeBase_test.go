@@ -0,0 +1,48 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKnowledgeBase_FocusStack(t *testing.T) {
+	kb := NewKnowledgeLibrary().GetKnowledgeBase("Focus", "0.0.1")
+	assert.Equal(t, DefaultAgendaGroup, kb.GetFocus())
+	kb.SetFocus("a")
+	kb.SetFocus("a")
+	kb.SetFocus("b")
+	assert.Equal(t, "b", kb.GetFocus())
+	assert.True(t, kb.PopFocus())
+	assert.Equal(t, "a", kb.GetFocus())
+	assert.True(t, kb.PopFocus())
+	assert.False(t, kb.PopFocus())
+	assert.Equal(t, DefaultAgendaGroup, kb.GetFocus())
+	kb.SetFocus("c")
+	kb.Reset()
+	assert.Equal(t, DefaultAgendaGroup, kb.GetFocus())
+}
+
+func TestKnowledgeBase_AddRuleEntryExist(t *testing.T) {
+	kb := NewKnowledgeLibrary().GetKnowledgeBase("Exist", "0.0.1")
+	entry := NewRuleEntry()
+	entry.RuleName = "Twice"
+	assert.NoError(t, kb.AddRuleEntry(entry))
+	err := kb.AddRuleEntry(entry)
+	var existErr *RuleEntryExistError
+	assert.ErrorAs(t, err, &existErr)
+	assert.Equal(t, "Twice", existErr.RuleName)
+}
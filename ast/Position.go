@@ -0,0 +1,46 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+// Position locates a node in the GRL it is built from. Lines start from 1 and columns start from 0,
+// as in pkg.Diagnostic, the end is right after the last character of the node.
+// The position is not known, and StartLine is 0, if the node is not built from a GRL, e.g. it is loaded from a catalog.
+type Position struct {
+	Resource    string
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+}
+
+// IsKnown tells whether the position is known.
+func (p Position) IsKnown() bool {
+
+	return p.StartLine > 0
+}
+
+// Contains tells whether the other position is known and lies within this one, in the same resource.
+func (p Position) Contains(other Position) bool {
+	if !p.IsKnown() || !other.IsKnown() || p.Resource != other.Resource {
+
+		return false
+	}
+	if other.StartLine < p.StartLine || (other.StartLine == p.StartLine && other.StartColumn < p.StartColumn) {
+
+		return false
+	}
+
+	return other.EndLine < p.EndLine || (other.EndLine == p.EndLine && other.EndColumn <= p.EndColumn)
+}
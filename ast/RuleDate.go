@@ -0,0 +1,73 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DateEffective is the attribute of the time a rule entry becomes active
+	DateEffective = "date-effective"
+	// DateExpires is the attribute of the time a rule entry stops being active
+	DateExpires = "date-expires"
+)
+
+// dateLayouts are the accepted layouts of the rule dates, a date without time zone is in the local time zone.
+var dateLayouts = []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02 15:04:05", time.RFC3339}
+
+// NewRuleDate create new RuleDate AST object of the attribute, DateEffective or DateExpires
+func NewRuleDate(attribute string) *RuleDate {
+
+	return &RuleDate{
+		Attribute: attribute,
+	}
+}
+
+// RuleDate is a simple AST object that stores the date-effective or date-expires of a rule entry
+type RuleDate struct {
+	Attribute string
+	Text      string
+}
+
+// RuleDateReceiver must be implemented by any AST object that stores rule dates
+type RuleDateReceiver interface {
+	AcceptRuleDate(date *RuleDate) error
+}
+
+// AcceptStringLiteral accept the assigned string
+func (rd *RuleDate) AcceptStringLiteral(lit *StringLiteral) {
+	rd.Text = lit.String
+}
+
+// Time parses the date. A date-expires without time is the end of that day, so the rule entry is still active on it.
+func (rd *RuleDate) Time() (time.Time, error) {
+	for i, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, rd.Text, time.Local)
+		if err != nil {
+
+			continue
+		}
+		if i == 0 && rd.Attribute == DateExpires {
+
+			return t.AddDate(0, 0, 1), nil
+		}
+
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s \"%s\", expecting 2006-01-02, 2006-01-02 15:04:05 or RFC 3339", rd.Attribute, rd.Text)
+}
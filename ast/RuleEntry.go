@@ -17,9 +17,12 @@ package ast
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"reflect"
+	"sort"
+	"time"
 
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
 )
@@ -32,6 +35,7 @@ func NewRuleEntry() *RuleEntry {
 		RuleName:        "No Name",
 		Salience:        0,
 		RuleDescription: "No Description",
+		AgendaGroup:     DefaultAgendaGroup,
 	}
 }
 
@@ -43,9 +47,27 @@ type RuleEntry struct {
 	RuleName        string
 	RuleDescription string
 	Salience        int
+	AgendaGroup     string
+	NoLoop          bool   // If this is true, the modifications made by this rule do not re-activate it
+	LockOnActive    bool   // If this is true, this rule is executed at most once per execution
+	Extends         string // The name of the parent rule entry whose when scope is inherited, empty if there is none
 	WhenScope       *WhenScope
 	ThenScope       *ThenScope
-
+	// Annotations are the values of the annotations of the rule, e.g. @tags("kyc", "eu"), by annotation name.
+	Annotations map[string][]string
+	// DateEffective is the time the rule entry becomes active, zero if it is always active.
+	DateEffective time.Time
+	// DateExpires is the time the rule entry stops being active, zero if it never expires.
+	DateExpires time.Time
+	// Enabled is the expression that must be true for the rule entry to be a candidate, nil if there is none.
+	Enabled *Expression
+	// Position locates the whole rule entry in the GRL it is built from, NamePosition locates its name.
+	Position     Position
+	NamePosition Position
+
+	// Retracted is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use KnowledgeBase.IsRuleRetracted.
 	Retracted bool
 	Deleted   bool //If this is true, it will be ignored while execution and fetching the matching rules
 }
@@ -71,6 +93,17 @@ func (e *RuleEntry) MakeCatalog(cat *Catalog) {
 		meta.RuleName = e.RuleName
 		meta.RuleDescription = e.RuleDescription
 		meta.Salience = e.Salience
+		meta.AgendaGroup = e.AgendaGroup
+		meta.NoLoop = e.NoLoop
+		meta.LockOnActive = e.LockOnActive
+		meta.Extends = e.Extends
+		meta.Annotations = copyAnnotations(e.Annotations)
+		meta.DateEffective = e.DateEffective
+		meta.DateExpires = e.DateExpires
+		if e.Enabled != nil {
+			meta.EnabledID = e.Enabled.AstID
+			e.Enabled.MakeCatalog(cat)
+		}
 	}
 }
 
@@ -86,6 +119,130 @@ func (e *RuleEntry) AcceptSalience(salience *Salience) error {
 	return nil
 }
 
+// AcceptAgendaGroup will accept the agenda group of this rule entry
+func (e *RuleEntry) AcceptAgendaGroup(agendaGroup *AgendaGroup) error {
+	e.AgendaGroup = agendaGroup.GroupName
+
+	return nil
+}
+
+// AcceptRuleDate will accept the date-effective or the date-expires of this rule entry
+func (e *RuleEntry) AcceptRuleDate(date *RuleDate) error {
+	t, err := date.Time()
+	if err != nil {
+
+		return err
+	}
+	switch date.Attribute {
+	case DateEffective:
+		e.DateEffective = t
+	case DateExpires:
+		e.DateExpires = t
+	}
+	if !e.DateEffective.IsZero() && !e.DateExpires.IsZero() && !e.DateExpires.After(e.DateEffective) {
+
+		return fmt.Errorf("%s %s is not after %s %s", DateExpires, e.DateExpires.Format(time.RFC3339), DateEffective, e.DateEffective.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// AcceptExpression will accept the enabled expression of this rule entry
+func (e *RuleEntry) AcceptExpression(exp *Expression) error {
+	if e.Enabled != nil {
+
+		return errors.New("enabled expression for RuleEntry already assigned")
+	}
+	e.Enabled = exp
+
+	return nil
+}
+
+// IsActiveAt checks if the time is within the date-effective and the date-expires of this rule entry.
+func (e *RuleEntry) IsActiveAt(now time.Time) bool {
+	if !e.DateEffective.IsZero() && now.Before(e.DateEffective) {
+
+		return false
+	}
+
+	return e.DateExpires.IsZero() || now.Before(e.DateExpires)
+}
+
+// AcceptAnnotation will accept an annotation of this rule entry. The values of an annotation given more than once
+// are appended.
+func (e *RuleEntry) AcceptAnnotation(annotation *Annotation) error {
+	if e.Annotations == nil {
+		e.Annotations = make(map[string][]string)
+	}
+	values, ok := e.Annotations[annotation.Name]
+	if !ok {
+		values = make([]string, 0, len(annotation.Values))
+	}
+	e.Annotations[annotation.Name] = append(values, annotation.Values...)
+
+	return nil
+}
+
+// GetAnnotation returns the values of the annotation, and whether the rule entry has the annotation.
+func (e *RuleEntry) GetAnnotation(name string) ([]string, bool) {
+	values, ok := e.Annotations[name]
+
+	return values, ok
+}
+
+// HasAnnotation checks if the rule entry has the annotation. If values are given, the annotation must have at least
+// one of them.
+func (e *RuleEntry) HasAnnotation(name string, values ...string) bool {
+	annotationValues, ok := e.Annotations[name]
+	if !ok || len(values) == 0 {
+
+		return ok
+	}
+	for _, value := range values {
+		for _, annotationValue := range annotationValues {
+			if value == annotationValue {
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func copyAnnotations(annotations map[string][]string) map[string][]string {
+	if annotations == nil {
+
+		return nil
+	}
+	cp := make(map[string][]string, len(annotations))
+	for name, values := range annotations {
+		cp[name] = append(make([]string, 0, len(values)), values...)
+	}
+
+	return cp
+}
+
+// Inherit will make the when scope of this rule entry the AND of the when scope of the parent and its own.
+// The local variables declared in the when scope of the parent are evaluated first, they stay local to the parent.
+func (e *RuleEntry) Inherit(parent *RuleEntry, memory *WorkingMemory) {
+	if parent.WhenScope == nil || parent.WhenScope.Expression == nil || e.WhenScope == nil || e.WhenScope.Expression == nil {
+
+		return
+	}
+	own := e.WhenScope.Expression
+	expression := NewExpression()
+	expression.GrlText = fmt.Sprintf("(%s)&&(%s)", parent.WhenScope.Expression.GrlText, own.GrlText)
+	expression.LeftExpression = parent.WhenScope.Expression
+	expression.RightExpression = own
+	expression.Operator = OpAnd
+	e.WhenScope.Expression = memory.AddExpression(expression)
+
+	lets := make([]*Assignment, 0, len(parent.WhenScope.Lets)+len(e.WhenScope.Lets))
+	lets = append(lets, parent.WhenScope.Lets...)
+	e.WhenScope.Lets = append(lets, e.WhenScope.Lets...)
+}
+
 // AcceptWhenScope will accept WhenScope AST Graph into this AST Graph
 func (e *RuleEntry) AcceptWhenScope(when *WhenScope) error {
 	e.WhenScope = when
@@ -108,7 +265,15 @@ func (e *RuleEntry) Clone(cloneTable *pkg.CloneTable) *RuleEntry {
 		RuleName:        e.RuleName,
 		RuleDescription: e.RuleDescription,
 		Salience:        e.Salience,
-		Retracted:       false,
+		AgendaGroup:     e.AgendaGroup,
+		NoLoop:          e.NoLoop,
+		LockOnActive:    e.LockOnActive,
+		Extends:         e.Extends,
+		Annotations:     copyAnnotations(e.Annotations),
+		DateEffective:   e.DateEffective,
+		DateExpires:     e.DateExpires,
+		Position:        e.Position,
+		NamePosition:    e.NamePosition,
 		Deleted:         e.Deleted,
 	}
 	if e.WhenScope != nil {
@@ -121,6 +286,16 @@ func (e *RuleEntry) Clone(cloneTable *pkg.CloneTable) *RuleEntry {
 		}
 	}
 
+	if e.Enabled != nil {
+		if cloneTable.IsCloned(e.Enabled.AstID) {
+			clone.Enabled = cloneTable.Records[e.Enabled.AstID].CloneInstance.(*Expression)
+		} else {
+			cloned := e.Enabled.Clone(cloneTable)
+			clone.Enabled = cloned
+			cloneTable.MarkCloned(e.Enabled.AstID, cloned.AstID, e.Enabled, cloned)
+		}
+	}
+
 	if e.ThenScope != nil {
 		if cloneTable.IsCloned(e.ThenScope.AstID) {
 			clone.ThenScope = cloneTable.Records[e.ThenScope.AstID].CloneInstance.(*ThenScope)
@@ -151,7 +326,29 @@ func (e *RuleEntry) GetSnapshot() string {
 	var buff bytes.Buffer
 	buff.WriteString(RULEENTRY)
 	buff.WriteString("(")
-	buff.WriteString(fmt.Sprintf("N:%s DEC:\"%s\" SAL:%d W:%s T:%s}", e.RuleName, e.RuleDescription, e.Salience, e.WhenScope.GetSnapshot(), e.ThenScope.GetSnapshot()))
+	if len(e.Extends) > 0 {
+		buff.WriteString(fmt.Sprintf("EX:%s ", e.Extends))
+	}
+	if len(e.Annotations) > 0 {
+		names := make([]string, 0, len(e.Annotations))
+		for name := range e.Annotations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			buff.WriteString(fmt.Sprintf("@%s%q ", name, e.Annotations[name]))
+		}
+	}
+	if !e.DateEffective.IsZero() {
+		buff.WriteString(fmt.Sprintf("DE:%s ", e.DateEffective.Format(time.RFC3339)))
+	}
+	if !e.DateExpires.IsZero() {
+		buff.WriteString(fmt.Sprintf("DX:%s ", e.DateExpires.Format(time.RFC3339)))
+	}
+	if e.Enabled != nil {
+		buff.WriteString(fmt.Sprintf("EN:%s ", e.Enabled.GetSnapshot()))
+	}
+	buff.WriteString(fmt.Sprintf("N:%s DEC:\"%s\" SAL:%d AG:\"%s\" NL:%v LOA:%v W:%s T:%s}", e.RuleName, e.RuleDescription, e.Salience, e.AgendaGroup, e.NoLoop, e.LockOnActive, e.WhenScope.GetSnapshot(), e.ThenScope.GetSnapshot()))
 	buff.WriteString(")")
 
 	return buff.String()
@@ -175,19 +372,34 @@ func (e *RuleEntry) Evaluate(ctx context.Context, dataContext IDataContext, memo
 			can = false
 		}
 	}()
-	if e.Retracted {
+	if memory.IsRuleRetracted(e.RuleName) {
 
 		return false, nil
 	}
-	val, err := e.WhenScope.Evaluate(dataContext, memory)
+	if e.Enabled != nil {
+		enabled, err := e.Enabled.EvaluateWithContext(ctx, dataContext, memory)
+		if err != nil {
+
+			return false, fmt.Errorf("evaluating the enabled expression of rule '%s' raised an error. got %w", e.RuleName, err)
+		}
+		if enabled.Kind() != reflect.Bool {
+
+			return false, fmt.Errorf("evaluating the enabled expression of rule '%s', it is not a boolean expression : %s", e.RuleName, e.Enabled.GetGrlText())
+		}
+		if !enabled.Bool() {
+
+			return false, nil
+		}
+	}
+	val, err := e.WhenScope.EvaluateWithContext(ctx, dataContext, memory)
 	if err != nil {
 		AstLog.Errorf("Error while evaluating rule %s, got %v", e.RuleName, err)
 
-		return false, fmt.Errorf("evaluating expression in rule '%s' the when raised an error. got %v", dataContext.GetRuleEntry().RuleName, err)
+		return false, fmt.Errorf("evaluating expression in rule '%s' the when raised an error. got %w", e.RuleName, err)
 	}
 	if val.Kind() != reflect.Bool {
 
-		return false, fmt.Errorf("evaluating expression in rule '%s', the when is not a boolean expression : %s", dataContext.GetRuleEntry().RuleName, e.WhenScope.Expression.GetGrlText())
+		return false, fmt.Errorf("evaluating expression in rule '%s', the when is not a boolean expression : %s", e.RuleName, e.WhenScope.Expression.GetGrlText())
 	}
 
 	return val.Bool(), nil
@@ -209,5 +421,5 @@ func (e *RuleEntry) Execute(ctx context.Context, dataContext IDataContext, memor
 		}
 	}()
 
-	return e.ThenScope.Execute(dataContext, memory)
+	return e.ThenScope.ExecuteWithContext(ctx, dataContext, memory)
 }
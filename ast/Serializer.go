@@ -23,6 +23,8 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
+	"time"
 )
 
 // NodeType is to label a Meta information within catalog
@@ -58,6 +60,12 @@ const (
 	TypeVariable
 	// TypeWhenScope meta type of WhenScope
 	TypeWhenScope
+	// TypeCollectionExpression meta type of CollectionExpression
+	TypeCollectionExpression
+	// TypeIfStatement meta type of IfStatement
+	TypeIfStatement
+	// TypeForStatement meta type of ForStatement
+	TypeForStatement
 
 	// TypeString variable type string label
 	TypeString ValueType = iota
@@ -69,7 +77,7 @@ const (
 	TypeBoolean
 
 	// Version will be written to the stream and used for compatibility check
-	Version = "1.8"
+	Version = "1.16"
 )
 
 // Catalog used to catalog all AST nodes in a KnowledgeBase.
@@ -151,6 +159,7 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 				IsMinusAssign: amet.IsMinusAssign,
 				IsDivAssign:   amet.IsDivAssign,
 				IsMulAssign:   amet.IsMulAssign,
+				IsLet:         amet.IsLet,
 			}
 			importTable[amet.AstID] = assignment
 		case TypeExpression:
@@ -231,6 +240,13 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 				RuleName:        amet.RuleName,
 				RuleDescription: amet.RuleDescription,
 				Salience:        amet.Salience,
+				AgendaGroup:     amet.AgendaGroup,
+				NoLoop:          amet.NoLoop,
+				LockOnActive:    amet.LockOnActive,
+				Extends:         amet.Extends,
+				Annotations:     copyAnnotations(amet.Annotations),
+				DateEffective:   amet.DateEffective,
+				DateExpires:     amet.DateExpires,
 				WhenScope:       nil,
 				ThenScope:       nil,
 			}
@@ -243,6 +259,8 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 				GrlText:        amet.GrlText,
 				Assignment:     nil,
 				ExpressionAtom: nil,
+				IfStatement:    nil,
+				ForStatement:   nil,
 			}
 			importTable[amet.AstID] = thenExp
 		case TypeThenExpressionList:
@@ -269,6 +287,7 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 				Name:             amet.Name,
 				Variable:         nil,
 				ArrayMapSelector: nil,
+				LocalOf:          amet.LocalOf,
 			}
 			importTable[amet.AstID] = variable
 		case TypeWhenScope:
@@ -279,6 +298,39 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 				Expression: nil,
 			}
 			importTable[amet.AstID] = n
+		case TypeCollectionExpression:
+			amet := meta.(*CollectionExpressionMeta)
+			collection := &CollectionExpression{
+				AstID:        amet.AstID,
+				GrlText:      amet.GrlText,
+				Operator:     amet.Operator,
+				VariableName: amet.VariableName,
+				Collection:   nil,
+				Filter:       nil,
+				Expression:   nil,
+			}
+			importTable[amet.AstID] = collection
+		case TypeIfStatement:
+			amet := meta.(*IfStatementMeta)
+			statement := &IfStatement{
+				AstID:     amet.AstID,
+				GrlText:   amet.GrlText,
+				Condition: nil,
+				Then:      nil,
+				ElseIf:    nil,
+				Else:      nil,
+			}
+			importTable[amet.AstID] = statement
+		case TypeForStatement:
+			amet := meta.(*ForStatementMeta)
+			statement := &ForStatement{
+				AstID:        amet.AstID,
+				GrlText:      amet.GrlText,
+				VariableName: amet.VariableName,
+				Collection:   nil,
+				Body:         nil,
+			}
+			importTable[amet.AstID] = statement
 		default:
 			return nil, fmt.Errorf("unrecognized meta type")
 		}
@@ -348,6 +400,9 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 			if len(amet.ArrayMapSelectorID) > 0 {
 				expressAtm.ArrayMapSelector = importTable[amet.ArrayMapSelectorID].(*ArrayMapSelector)
 			}
+			if len(amet.CollectionExpressionID) > 0 {
+				expressAtm.CollectionExpression = importTable[amet.CollectionExpressionID].(*CollectionExpression)
+			}
 		case TypeFunctionCall:
 			funcCall := node.(*FunctionCall)
 			amet := meta.(*FunctionCallMeta)
@@ -360,6 +415,9 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 			if len(amet.WhenScopeID) > 0 {
 				ruleEntry.WhenScope = importTable[amet.WhenScopeID].(*WhenScope)
 			}
+			if len(amet.EnabledID) > 0 {
+				ruleEntry.Enabled = importTable[amet.EnabledID].(*Expression)
+			}
 			if len(amet.ThenScopeID) > 0 {
 				ruleEntry.ThenScope = importTable[amet.ThenScopeID].(*ThenScope)
 			}
@@ -372,6 +430,12 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 			if len(amet.ExpressionAtomID) > 0 {
 				thenExpr.ExpressionAtom = importTable[amet.ExpressionAtomID].(*ExpressionAtom)
 			}
+			if len(amet.IfStatementID) > 0 {
+				thenExpr.IfStatement = importTable[amet.IfStatementID].(*IfStatement)
+			}
+			if len(amet.ForStatementID) > 0 {
+				thenExpr.ForStatement = importTable[amet.ForStatementID].(*ForStatement)
+			}
 		case TypeThenExpressionList:
 			ThenExprList := node.(*ThenExpressionList)
 			amet := meta.(*ThenExpressionListMeta)
@@ -406,6 +470,49 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 			if len(amet.ExpressionID) > 0 {
 				whenScope.Expression = importTable[amet.ExpressionID].(*Expression)
 			}
+			if len(amet.LetIDs) > 0 {
+				whenScope.Lets = make([]*Assignment, len(amet.LetIDs))
+				for k, v := range amet.LetIDs {
+					whenScope.Lets[k] = importTable[v].(*Assignment)
+				}
+			}
+		case TypeCollectionExpression:
+			collection := node.(*CollectionExpression)
+			amet := meta.(*CollectionExpressionMeta)
+			if len(amet.CollectionID) > 0 {
+				collection.Collection = importTable[amet.CollectionID].(*ExpressionAtom)
+			}
+			if len(amet.FilterID) > 0 {
+				collection.Filter = importTable[amet.FilterID].(*Expression)
+				collection.HasFilter = true
+			}
+			if len(amet.ExpressionID) > 0 {
+				collection.Expression = importTable[amet.ExpressionID].(*Expression)
+			}
+		case TypeIfStatement:
+			statement := node.(*IfStatement)
+			amet := meta.(*IfStatementMeta)
+			if len(amet.ConditionID) > 0 {
+				statement.Condition = importTable[amet.ConditionID].(*Expression)
+			}
+			if len(amet.ThenID) > 0 {
+				statement.Then = importTable[amet.ThenID].(*ThenExpressionList)
+			}
+			if len(amet.ElseIfID) > 0 {
+				statement.ElseIf = importTable[amet.ElseIfID].(*IfStatement)
+			}
+			if len(amet.ElseID) > 0 {
+				statement.Else = importTable[amet.ElseID].(*ThenExpressionList)
+			}
+		case TypeForStatement:
+			statement := node.(*ForStatement)
+			amet := meta.(*ForStatementMeta)
+			if len(amet.CollectionID) > 0 {
+				statement.Collection = importTable[amet.CollectionID].(*ExpressionAtom)
+			}
+			if len(amet.BodyID) > 0 {
+				statement.Body = importTable[amet.BodyID].(*ThenExpressionList)
+			}
 		default:
 			return nil, fmt.Errorf("unknown AST type")
 		}
@@ -449,6 +556,7 @@ func (cat *Catalog) BuildKnowledgeBase() (*KnowledgeBase, error) {
 			}
 		}
 	}
+	workingMem.assignSlots()
 
 	return knowledgeBase, nil
 }
@@ -639,6 +747,12 @@ func (cat *Catalog) ReadCatalogFromReader(reader io.Reader) error {
 			meta = &VariableMeta{}
 		case TypeWhenScope:
 			meta = &WhenScopeMeta{}
+		case TypeCollectionExpression:
+			meta = &CollectionExpressionMeta{}
+		case TypeIfStatement:
+			meta = &IfStatementMeta{}
+		case TypeForStatement:
+			meta = &ForStatementMeta{}
 		default:
 
 			return fmt.Errorf("unknown meta number %d", metaType)
@@ -1272,6 +1386,7 @@ type AssigmentMeta struct {
 	IsMinusAssign bool
 	IsDivAssign   bool
 	IsMulAssign   bool
+	IsLet         bool
 }
 
 // Equals basic function to test equality of two MetaNode
@@ -1309,6 +1424,10 @@ func (meta *AssigmentMeta) Equals(that Meta) bool {
 
 			return false
 		}
+		if meta.IsLet != ins.IsLet {
+
+			return false
+		}
 
 		return true
 	}
@@ -1368,6 +1487,11 @@ func (meta *AssigmentMeta) WriteMetaTo(writer io.Writer) error {
 
 		return err
 	}
+	err = WriteBoolToWriter(writer, meta.IsLet)
+	if err != nil {
+
+		return err
+	}
 
 	return nil
 }
@@ -1430,6 +1554,13 @@ func (meta *AssigmentMeta) ReadMetaFrom(reader io.Reader) error {
 	}
 	meta.IsMulAssign = boolReaded
 
+	boolReaded, err = ReadBoolFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.IsLet = boolReaded
+
 	return nil
 }
 
@@ -1706,13 +1837,14 @@ func (meta *ExpressionMeta) ReadMetaFrom(reader io.Reader) error {
 // ExpressionAtomMeta meta data for an ExpressionAtom node
 type ExpressionAtomMeta struct {
 	NodeMeta
-	VariableName       string
-	ConstantID         string
-	FunctionCallID     string
-	VariableID         string
-	Negated            bool
-	ExpressionAtomID   string
-	ArrayMapSelectorID string
+	VariableName           string
+	ConstantID             string
+	FunctionCallID         string
+	VariableID             string
+	Negated                bool
+	ExpressionAtomID       string
+	ArrayMapSelectorID     string
+	CollectionExpressionID string
 }
 
 // Equals basic function to test equality of two MetaNode
@@ -1751,6 +1883,10 @@ func (meta *ExpressionAtomMeta) Equals(that Meta) bool {
 
 			return false
 		}
+		if meta.CollectionExpressionID != ins.CollectionExpressionID {
+
+			return false
+		}
 
 		return true
 	}
@@ -1808,6 +1944,11 @@ func (meta *ExpressionAtomMeta) WriteMetaTo(writer io.Writer) error {
 
 		return err
 	}
+	err = WriteStringToWriter(writer, meta.CollectionExpressionID)
+	if err != nil {
+
+		return err
+	}
 
 	return nil
 }
@@ -1863,29 +2004,50 @@ func (meta *ExpressionAtomMeta) ReadMetaFrom(reader io.Reader) error {
 		return err
 	}
 	meta.ArrayMapSelectorID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.CollectionExpressionID = stringFromReader
 
 	return nil
 }
 
-// FunctionCallMeta meta data for an FunctionCall node
-type FunctionCallMeta struct {
+// CollectionExpressionMeta meta data for a CollectionExpression node
+type CollectionExpressionMeta struct {
 	NodeMeta
-	FunctionName   string
-	ArgumentListID string
+	Operator     int
+	VariableName string
+	CollectionID string
+	FilterID     string
+	ExpressionID string
 }
 
 // Equals basic function to test equality of two MetaNode
-func (meta *FunctionCallMeta) Equals(that Meta) bool {
-	if ins, ok := that.(*FunctionCallMeta); ok {
+func (meta *CollectionExpressionMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*CollectionExpressionMeta); ok {
 		if !meta.NodeMeta.Equals(that) {
 
 			return false
 		}
-		if meta.FunctionName != ins.FunctionName {
+		if meta.Operator != ins.Operator {
 
 			return false
 		}
-		if meta.ArgumentListID != ins.ArgumentListID {
+		if meta.VariableName != ins.VariableName {
+
+			return false
+		}
+		if meta.CollectionID != ins.CollectionID {
+
+			return false
+		}
+		if meta.FilterID != ins.FilterID {
+
+			return false
+		}
+		if meta.ExpressionID != ins.ExpressionID {
 
 			return false
 		}
@@ -1897,26 +2059,41 @@ func (meta *FunctionCallMeta) Equals(that Meta) bool {
 }
 
 // GetASTType returns the meta type of this AST Node
-func (meta *FunctionCallMeta) GetASTType() NodeType {
+func (meta *CollectionExpressionMeta) GetASTType() NodeType {
 
-	return TypeFunctionCall
+	return TypeCollectionExpression
 }
 
 // WriteMetaTo write basic AST Node information meta data into writer.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *FunctionCallMeta) WriteMetaTo(writer io.Writer) error {
+func (meta *CollectionExpressionMeta) WriteMetaTo(writer io.Writer) error {
 	err := meta.NodeMeta.WriteMetaTo(writer)
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.FunctionName)
+	err = WriteIntToWriter(writer, uint64(meta.Operator))
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.ArgumentListID)
+	err = WriteStringToWriter(writer, meta.VariableName)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.CollectionID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.FilterID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.ExpressionID)
 	if err != nil {
 
 		return err
@@ -1928,63 +2105,75 @@ func (meta *FunctionCallMeta) WriteMetaTo(writer io.Writer) error {
 // ReadMetaFrom write basic AST Node information meta data from reader.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *FunctionCallMeta) ReadMetaFrom(reader io.Reader) error {
+func (meta *CollectionExpressionMeta) ReadMetaFrom(reader io.Reader) error {
 	err := meta.NodeMeta.ReadMetaFrom(reader)
 	if err != nil {
 
 		return err
 	}
+	i, err := ReadIntFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.Operator = int(i)
 	stringFromReader, err := ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.FunctionName = stringFromReader
+	meta.VariableName = stringFromReader
 	stringFromReader, err = ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.ArgumentListID = stringFromReader
+	meta.CollectionID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.FilterID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.ExpressionID = stringFromReader
 
 	return nil
 }
 
-// RuleEntryMeta meta data for an RuleEntry node
-type RuleEntryMeta struct {
+// IfStatementMeta meta data for an IfStatement node
+type IfStatementMeta struct {
 	NodeMeta
-
-	RuleName        string
-	RuleDescription string
-	Salience        int
-	WhenScopeID     string
-	ThenScopeID     string
+	ConditionID string
+	ThenID      string
+	ElseIfID    string
+	ElseID      string
 }
 
 // Equals basic function to test equality of two MetaNode
-func (meta *RuleEntryMeta) Equals(that Meta) bool {
-	if ins, ok := that.(*RuleEntryMeta); ok {
+func (meta *IfStatementMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*IfStatementMeta); ok {
 		if !meta.NodeMeta.Equals(that) {
 
 			return false
 		}
-		if meta.RuleName != ins.RuleName {
-
-			return false
-		}
-		if meta.RuleDescription != ins.RuleDescription {
+		if meta.ConditionID != ins.ConditionID {
 
 			return false
 		}
-		if meta.Salience != ins.Salience {
+		if meta.ThenID != ins.ThenID {
 
 			return false
 		}
-		if meta.WhenScopeID != ins.WhenScopeID {
+		if meta.ElseIfID != ins.ElseIfID {
 
 			return false
 		}
-		if meta.ThenScopeID != ins.ThenScopeID {
+		if meta.ElseID != ins.ElseID {
 
 			return false
 		}
@@ -1996,41 +2185,36 @@ func (meta *RuleEntryMeta) Equals(that Meta) bool {
 }
 
 // GetASTType returns the meta type of this AST Node
-func (meta *RuleEntryMeta) GetASTType() NodeType {
+func (meta *IfStatementMeta) GetASTType() NodeType {
 
-	return TypeRuleEntry
+	return TypeIfStatement
 }
 
 // WriteMetaTo write basic AST Node information meta data into writer.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *RuleEntryMeta) WriteMetaTo(writer io.Writer) error {
+func (meta *IfStatementMeta) WriteMetaTo(writer io.Writer) error {
 	err := meta.NodeMeta.WriteMetaTo(writer)
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.RuleName)
-	if err != nil {
-
-		return err
-	}
-	err = WriteStringToWriter(writer, meta.RuleDescription)
+	err = WriteStringToWriter(writer, meta.ConditionID)
 	if err != nil {
 
 		return err
 	}
-	err = WriteIntToWriter(writer, uint64(meta.Salience))
+	err = WriteStringToWriter(writer, meta.ThenID)
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.WhenScopeID)
+	err = WriteStringToWriter(writer, meta.ElseIfID)
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.ThenScopeID)
+	err = WriteStringToWriter(writer, meta.ElseID)
 	if err != nil {
 
 		return err
@@ -2042,7 +2226,7 @@ func (meta *RuleEntryMeta) WriteMetaTo(writer io.Writer) error {
 // ReadMetaFrom write basic AST Node information meta data from reader.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *RuleEntryMeta) ReadMetaFrom(reader io.Reader) error {
+func (meta *IfStatementMeta) ReadMetaFrom(reader io.Reader) error {
 	err := meta.NodeMeta.ReadMetaFrom(reader)
 	if err != nil {
 
@@ -2053,55 +2237,53 @@ func (meta *RuleEntryMeta) ReadMetaFrom(reader io.Reader) error {
 
 		return err
 	}
-	meta.RuleName = stringFromReader
+	meta.ConditionID = stringFromReader
 	stringFromReader, err = ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.RuleDescription = stringFromReader
-	i, err := ReadIntFromReader(reader)
-	if err != nil {
-
-		return err
-	}
-	meta.Salience = int(i)
+	meta.ThenID = stringFromReader
 	stringFromReader, err = ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.WhenScopeID = stringFromReader
+	meta.ElseIfID = stringFromReader
 	stringFromReader, err = ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.ThenScopeID = stringFromReader
+	meta.ElseID = stringFromReader
 
 	return nil
 }
 
-// ThenExpressionMeta meta data for an ThenExpression node
-type ThenExpressionMeta struct {
+// ForStatementMeta meta data for a ForStatement node
+type ForStatementMeta struct {
 	NodeMeta
-
-	AssignmentID     string
-	ExpressionAtomID string
+	VariableName string
+	CollectionID string
+	BodyID       string
 }
 
 // Equals basic function to test equality of two MetaNode
-func (meta *ThenExpressionMeta) Equals(that Meta) bool {
-	if ins, ok := that.(*ThenExpressionMeta); ok {
+func (meta *ForStatementMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*ForStatementMeta); ok {
 		if !meta.NodeMeta.Equals(that) {
 
 			return false
 		}
-		if meta.AssignmentID != ins.AssignmentID {
+		if meta.VariableName != ins.VariableName {
 
 			return false
 		}
-		if meta.ExpressionAtomID != ins.ExpressionAtomID {
+		if meta.CollectionID != ins.CollectionID {
+
+			return false
+		}
+		if meta.BodyID != ins.BodyID {
 
 			return false
 		}
@@ -2113,26 +2295,31 @@ func (meta *ThenExpressionMeta) Equals(that Meta) bool {
 }
 
 // GetASTType returns the meta type of this AST Node
-func (meta *ThenExpressionMeta) GetASTType() NodeType {
+func (meta *ForStatementMeta) GetASTType() NodeType {
 
-	return TypeThenExpression
+	return TypeForStatement
 }
 
 // WriteMetaTo write basic AST Node information meta data into writer.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *ThenExpressionMeta) WriteMetaTo(writer io.Writer) error {
+func (meta *ForStatementMeta) WriteMetaTo(writer io.Writer) error {
 	err := meta.NodeMeta.WriteMetaTo(writer)
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.AssignmentID)
+	err = WriteStringToWriter(writer, meta.VariableName)
 	if err != nil {
 
 		return err
 	}
-	err = WriteStringToWriter(writer, meta.ExpressionAtomID)
+	err = WriteStringToWriter(writer, meta.CollectionID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.BodyID)
 	if err != nil {
 
 		return err
@@ -2144,51 +2331,55 @@ func (meta *ThenExpressionMeta) WriteMetaTo(writer io.Writer) error {
 // ReadMetaFrom write basic AST Node information meta data from reader.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *ThenExpressionMeta) ReadMetaFrom(reader io.Reader) error {
+func (meta *ForStatementMeta) ReadMetaFrom(reader io.Reader) error {
 	err := meta.NodeMeta.ReadMetaFrom(reader)
 	if err != nil {
 
 		return err
 	}
-	theString, err := ReadStringFromReader(reader)
+	stringFromReader, err := ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.AssignmentID = theString
-	theString, err = ReadStringFromReader(reader)
+	meta.VariableName = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
-	meta.ExpressionAtomID = theString
+	meta.CollectionID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.BodyID = stringFromReader
 
 	return nil
 }
 
-// ThenExpressionListMeta meta data for an ThenExpressionList node
-type ThenExpressionListMeta struct {
+// FunctionCallMeta meta data for an FunctionCall node
+type FunctionCallMeta struct {
 	NodeMeta
-
-	ThenExpressionIDs []string
+	FunctionName   string
+	ArgumentListID string
 }
 
 // Equals basic function to test equality of two MetaNode
-func (meta *ThenExpressionListMeta) Equals(that Meta) bool {
-	if ins, ok := that.(*ThenExpressionListMeta); ok {
+func (meta *FunctionCallMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*FunctionCallMeta); ok {
 		if !meta.NodeMeta.Equals(that) {
 
 			return false
 		}
-		if len(meta.ThenExpressionIDs) != len(ins.ThenExpressionIDs) {
+		if meta.FunctionName != ins.FunctionName {
 
 			return false
 		}
-		for k, v := range meta.ThenExpressionIDs {
-			if ins.ThenExpressionIDs[k] != v {
+		if meta.ArgumentListID != ins.ArgumentListID {
 
-				return false
-			}
+			return false
 		}
 
 		return true
@@ -2198,33 +2389,29 @@ func (meta *ThenExpressionListMeta) Equals(that Meta) bool {
 }
 
 // GetASTType returns the meta type of this AST Node
-func (meta *ThenExpressionListMeta) GetASTType() NodeType {
+func (meta *FunctionCallMeta) GetASTType() NodeType {
 
-	return TypeThenExpressionList
+	return TypeFunctionCall
 }
 
 // WriteMetaTo write basic AST Node information meta data into writer.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *ThenExpressionListMeta) WriteMetaTo(writer io.Writer) error {
+func (meta *FunctionCallMeta) WriteMetaTo(writer io.Writer) error {
 	err := meta.NodeMeta.WriteMetaTo(writer)
 	if err != nil {
 
 		return err
 	}
-
-	err = WriteIntToWriter(writer, uint64(len(meta.ThenExpressionIDs)))
+	err = WriteStringToWriter(writer, meta.FunctionName)
 	if err != nil {
 
 		return err
 	}
+	err = WriteStringToWriter(writer, meta.ArgumentListID)
+	if err != nil {
 
-	for _, v := range meta.ThenExpressionIDs {
-		err = WriteStringToWriter(writer, v)
-		if err != nil {
-
-			return err
-		}
+		return err
 	}
 
 	return nil
@@ -2233,39 +2420,558 @@ func (meta *ThenExpressionListMeta) WriteMetaTo(writer io.Writer) error {
 // ReadMetaFrom write basic AST Node information meta data from reader.
 // One should not use this function directly, unless for testing
 // serialization of single ASTNode.
-func (meta *ThenExpressionListMeta) ReadMetaFrom(reader io.Reader) error {
+func (meta *FunctionCallMeta) ReadMetaFrom(reader io.Reader) error {
 	err := meta.NodeMeta.ReadMetaFrom(reader)
 	if err != nil {
 
 		return err
 	}
-
-	count, err := ReadIntFromReader(reader)
+	stringFromReader, err := ReadStringFromReader(reader)
 	if err != nil {
 
 		return err
 	}
+	meta.FunctionName = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
 
-	meta.ThenExpressionIDs = make([]string, count)
-	for index := uint64(0); index < count; index++ {
-		s, err := ReadStringFromReader(reader)
-		if err != nil {
-
-			return err
-		}
-		meta.ThenExpressionIDs[index] = s
+		return err
 	}
+	meta.ArgumentListID = stringFromReader
 
 	return nil
 }
 
-// ThenScopeMeta meta data for an ThenScope node
-type ThenScopeMeta struct {
+// RuleEntryMeta meta data for an RuleEntry node
+type RuleEntryMeta struct {
 	NodeMeta
-	ThenExpressionListID string
-}
 
-// Equals basic function to test equality of two MetaNode
+	RuleName        string
+	RuleDescription string
+	Salience        int
+	AgendaGroup     string
+	NoLoop          bool
+	LockOnActive    bool
+	Extends         string
+	Annotations     map[string][]string
+	DateEffective   time.Time
+	DateExpires     time.Time
+	EnabledID       string
+	WhenScopeID     string
+	ThenScopeID     string
+}
+
+// Equals basic function to test equality of two MetaNode
+func (meta *RuleEntryMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*RuleEntryMeta); ok {
+		if !meta.NodeMeta.Equals(that) {
+
+			return false
+		}
+		if meta.RuleName != ins.RuleName {
+
+			return false
+		}
+		if meta.RuleDescription != ins.RuleDescription {
+
+			return false
+		}
+		if meta.Salience != ins.Salience {
+
+			return false
+		}
+		if meta.AgendaGroup != ins.AgendaGroup {
+
+			return false
+		}
+		if meta.NoLoop != ins.NoLoop {
+
+			return false
+		}
+		if meta.LockOnActive != ins.LockOnActive {
+
+			return false
+		}
+		if meta.Extends != ins.Extends {
+
+			return false
+		}
+		if len(meta.Annotations) != len(ins.Annotations) {
+
+			return false
+		}
+		for name, values := range meta.Annotations {
+			insValues, ok := ins.Annotations[name]
+			if !ok || len(values) != len(insValues) {
+
+				return false
+			}
+			for i := range values {
+				if values[i] != insValues[i] {
+
+					return false
+				}
+			}
+		}
+		if !meta.DateEffective.Equal(ins.DateEffective) || !meta.DateExpires.Equal(ins.DateExpires) {
+
+			return false
+		}
+		if meta.EnabledID != ins.EnabledID {
+
+			return false
+		}
+		if meta.WhenScopeID != ins.WhenScopeID {
+
+			return false
+		}
+		if meta.ThenScopeID != ins.ThenScopeID {
+
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// GetASTType returns the meta type of this AST Node
+func (meta *RuleEntryMeta) GetASTType() NodeType {
+
+	return TypeRuleEntry
+}
+
+// WriteMetaTo write basic AST Node information meta data into writer.
+// One should not use this function directly, unless for testing
+// serialization of single ASTNode.
+func (meta *RuleEntryMeta) WriteMetaTo(writer io.Writer) error {
+	err := meta.NodeMeta.WriteMetaTo(writer)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.RuleName)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.RuleDescription)
+	if err != nil {
+
+		return err
+	}
+	err = WriteIntToWriter(writer, uint64(meta.Salience))
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.AgendaGroup)
+	if err != nil {
+
+		return err
+	}
+	err = WriteBoolToWriter(writer, meta.NoLoop)
+	if err != nil {
+
+		return err
+	}
+	err = WriteBoolToWriter(writer, meta.LockOnActive)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.Extends)
+	if err != nil {
+
+		return err
+	}
+	names := make([]string, 0, len(meta.Annotations))
+	for name := range meta.Annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	err = WriteIntToWriter(writer, uint64(len(names)))
+	if err != nil {
+
+		return err
+	}
+	for _, name := range names {
+		err = WriteStringToWriter(writer, name)
+		if err != nil {
+
+			return err
+		}
+		err = WriteIntToWriter(writer, uint64(len(meta.Annotations[name])))
+		if err != nil {
+
+			return err
+		}
+		for _, value := range meta.Annotations[name] {
+			err = WriteStringToWriter(writer, value)
+			if err != nil {
+
+				return err
+			}
+		}
+	}
+	err = writeTimeToWriter(writer, meta.DateEffective)
+	if err != nil {
+
+		return err
+	}
+	err = writeTimeToWriter(writer, meta.DateExpires)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.EnabledID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.WhenScopeID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.ThenScopeID)
+	if err != nil {
+
+		return err
+	}
+
+	return nil
+}
+
+// ReadMetaFrom write basic AST Node information meta data from reader.
+// One should not use this function directly, unless for testing
+// serialization of single ASTNode.
+func (meta *RuleEntryMeta) ReadMetaFrom(reader io.Reader) error {
+	err := meta.NodeMeta.ReadMetaFrom(reader)
+	if err != nil {
+
+		return err
+	}
+	stringFromReader, err := ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.RuleName = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.RuleDescription = stringFromReader
+	i, err := ReadIntFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.Salience = int(i)
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.AgendaGroup = stringFromReader
+	boolReaded, err := ReadBoolFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.NoLoop = boolReaded
+	boolReaded, err = ReadBoolFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.LockOnActive = boolReaded
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.Extends = stringFromReader
+	count, err := ReadIntFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	if count > 0 {
+		meta.Annotations = make(map[string][]string, count)
+	}
+	for n := uint64(0); n < count; n++ {
+		name, err := ReadStringFromReader(reader)
+		if err != nil {
+
+			return err
+		}
+		valueCount, err := ReadIntFromReader(reader)
+		if err != nil {
+
+			return err
+		}
+		values := make([]string, valueCount)
+		for v := range values {
+			values[v], err = ReadStringFromReader(reader)
+			if err != nil {
+
+				return err
+			}
+		}
+		meta.Annotations[name] = values
+	}
+	meta.DateEffective, err = readTimeFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.DateExpires, err = readTimeFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.EnabledID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.WhenScopeID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.ThenScopeID = stringFromReader
+
+	return nil
+}
+
+// ThenExpressionMeta meta data for an ThenExpression node
+type ThenExpressionMeta struct {
+	NodeMeta
+
+	AssignmentID     string
+	ExpressionAtomID string
+	IfStatementID    string
+	ForStatementID   string
+}
+
+// Equals basic function to test equality of two MetaNode
+func (meta *ThenExpressionMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*ThenExpressionMeta); ok {
+		if !meta.NodeMeta.Equals(that) {
+
+			return false
+		}
+		if meta.AssignmentID != ins.AssignmentID {
+
+			return false
+		}
+		if meta.ExpressionAtomID != ins.ExpressionAtomID {
+
+			return false
+		}
+		if meta.IfStatementID != ins.IfStatementID {
+
+			return false
+		}
+		if meta.ForStatementID != ins.ForStatementID {
+
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// GetASTType returns the meta type of this AST Node
+func (meta *ThenExpressionMeta) GetASTType() NodeType {
+
+	return TypeThenExpression
+}
+
+// WriteMetaTo write basic AST Node information meta data into writer.
+// One should not use this function directly, unless for testing
+// serialization of single ASTNode.
+func (meta *ThenExpressionMeta) WriteMetaTo(writer io.Writer) error {
+	err := meta.NodeMeta.WriteMetaTo(writer)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.AssignmentID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.ExpressionAtomID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.IfStatementID)
+	if err != nil {
+
+		return err
+	}
+	err = WriteStringToWriter(writer, meta.ForStatementID)
+	if err != nil {
+
+		return err
+	}
+
+	return nil
+}
+
+// ReadMetaFrom write basic AST Node information meta data from reader.
+// One should not use this function directly, unless for testing
+// serialization of single ASTNode.
+func (meta *ThenExpressionMeta) ReadMetaFrom(reader io.Reader) error {
+	err := meta.NodeMeta.ReadMetaFrom(reader)
+	if err != nil {
+
+		return err
+	}
+	theString, err := ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.AssignmentID = theString
+	theString, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.ExpressionAtomID = theString
+	theString, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.IfStatementID = theString
+	theString, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.ForStatementID = theString
+
+	return nil
+}
+
+// ThenExpressionListMeta meta data for an ThenExpressionList node
+type ThenExpressionListMeta struct {
+	NodeMeta
+
+	ThenExpressionIDs []string
+}
+
+// Equals basic function to test equality of two MetaNode
+func (meta *ThenExpressionListMeta) Equals(that Meta) bool {
+	if ins, ok := that.(*ThenExpressionListMeta); ok {
+		if !meta.NodeMeta.Equals(that) {
+
+			return false
+		}
+		if len(meta.ThenExpressionIDs) != len(ins.ThenExpressionIDs) {
+
+			return false
+		}
+		for k, v := range meta.ThenExpressionIDs {
+			if ins.ThenExpressionIDs[k] != v {
+
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// GetASTType returns the meta type of this AST Node
+func (meta *ThenExpressionListMeta) GetASTType() NodeType {
+
+	return TypeThenExpressionList
+}
+
+// WriteMetaTo write basic AST Node information meta data into writer.
+// One should not use this function directly, unless for testing
+// serialization of single ASTNode.
+func (meta *ThenExpressionListMeta) WriteMetaTo(writer io.Writer) error {
+	err := meta.NodeMeta.WriteMetaTo(writer)
+	if err != nil {
+
+		return err
+	}
+
+	err = WriteIntToWriter(writer, uint64(len(meta.ThenExpressionIDs)))
+	if err != nil {
+
+		return err
+	}
+
+	for _, v := range meta.ThenExpressionIDs {
+		err = WriteStringToWriter(writer, v)
+		if err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadMetaFrom write basic AST Node information meta data from reader.
+// One should not use this function directly, unless for testing
+// serialization of single ASTNode.
+func (meta *ThenExpressionListMeta) ReadMetaFrom(reader io.Reader) error {
+	err := meta.NodeMeta.ReadMetaFrom(reader)
+	if err != nil {
+
+		return err
+	}
+
+	count, err := ReadIntFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+
+	meta.ThenExpressionIDs = make([]string, count)
+	for index := uint64(0); index < count; index++ {
+		s, err := ReadStringFromReader(reader)
+		if err != nil {
+
+			return err
+		}
+		meta.ThenExpressionIDs[index] = s
+	}
+
+	return nil
+}
+
+// ThenScopeMeta meta data for an ThenScope node
+type ThenScopeMeta struct {
+	NodeMeta
+	ThenExpressionListID string
+}
+
+// Equals basic function to test equality of two MetaNode
 func (meta *ThenScopeMeta) Equals(that Meta) bool {
 	if ins, ok := that.(*ThenScopeMeta); ok {
 		if !meta.NodeMeta.Equals(that) {
@@ -2333,6 +3039,7 @@ type VariableMeta struct {
 	Name               string
 	VariableID         string
 	ArrayMapSelectorID string
+	LocalOf            string
 }
 
 // Equals basic function to test equality of two MetaNode
@@ -2354,6 +3061,10 @@ func (meta *VariableMeta) Equals(that Meta) bool {
 
 			return false
 		}
+		if meta.LocalOf != ins.LocalOf {
+
+			return false
+		}
 
 		return true
 	}
@@ -2391,6 +3102,11 @@ func (meta *VariableMeta) WriteMetaTo(writer io.Writer) error {
 
 		return err
 	}
+	err = WriteStringToWriter(writer, meta.LocalOf)
+	if err != nil {
+
+		return err
+	}
 
 	return nil
 }
@@ -2422,6 +3138,12 @@ func (meta *VariableMeta) ReadMetaFrom(reader io.Reader) error {
 		return err
 	}
 	meta.ArrayMapSelectorID = stringFromReader
+	stringFromReader, err = ReadStringFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.LocalOf = stringFromReader
 
 	return nil
 }
@@ -2430,6 +3152,7 @@ func (meta *VariableMeta) ReadMetaFrom(reader io.Reader) error {
 type WhenScopeMeta struct {
 	NodeMeta
 	ExpressionID string
+	LetIDs       []string
 }
 
 // Equals basic function to test equality of two MetaNode
@@ -2443,6 +3166,16 @@ func (meta *WhenScopeMeta) Equals(that Meta) bool {
 
 			return false
 		}
+		if len(meta.LetIDs) != len(ins.LetIDs) {
+
+			return false
+		}
+		for k, v := range meta.LetIDs {
+			if ins.LetIDs[k] != v {
+
+				return false
+			}
+		}
 
 		return true
 	}
@@ -2470,6 +3203,18 @@ func (meta *WhenScopeMeta) WriteMetaTo(writer io.Writer) error {
 
 		return err
 	}
+	err = WriteIntToWriter(writer, uint64(len(meta.LetIDs)))
+	if err != nil {
+
+		return err
+	}
+	for _, v := range meta.LetIDs {
+		err = WriteStringToWriter(writer, v)
+		if err != nil {
+
+			return err
+		}
+	}
 
 	return nil
 }
@@ -2489,6 +3234,20 @@ func (meta *WhenScopeMeta) ReadMetaFrom(reader io.Reader) error {
 		return err
 	}
 	meta.ExpressionID = s
+	integer, err := ReadIntFromReader(reader)
+	if err != nil {
+
+		return err
+	}
+	meta.LetIDs = make([]string, integer)
+	for index := uint64(0); index < integer; index++ {
+		s, err := ReadStringFromReader(reader)
+		if err != nil {
+
+			return err
+		}
+		meta.LetIDs[index] = s
+	}
 
 	return nil
 }
@@ -2564,6 +3323,27 @@ func ReadStringFromReader(reader io.Reader) (string, error) {
 	return string(strByte), nil
 }
 
+// writeTimeToWriter write a time into writer, as an empty string for the zero time.
+func writeTimeToWriter(writer io.Writer, t time.Time) error {
+	if t.IsZero() {
+
+		return WriteStringToWriter(writer, "")
+	}
+
+	return WriteStringToWriter(writer, t.Format(time.RFC3339Nano))
+}
+
+// readTimeFromReader read a time from reader.
+func readTimeFromReader(reader io.Reader) (time.Time, error) {
+	str, err := ReadStringFromReader(reader)
+	if err != nil || len(str) == 0 {
+
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339Nano, str)
+}
+
 // WriteIntToWriter write a 64 bit integer into writer.
 func WriteIntToWriter(w io.Writer, i uint64) error {
 	data := make([]byte, 8)
@@ -62,6 +62,36 @@ func TestAssigmentMetaReadWrite(t *testing.T) {
 	assert.True(t, assigment.Equals(assigment2))
 }
 
+func TestRuleEntryMetaReadWrite(t *testing.T) {
+	ruleEntry := &RuleEntryMeta{
+		NodeMeta: NodeMeta{
+			AstID:    uuid.New().String(),
+			GrlText:  uuid.New().String(),
+			Snapshot: uuid.New().String(),
+		},
+		RuleName:        uuid.New().String(),
+		RuleDescription: uuid.New().String(),
+		Salience:        10,
+		AgendaGroup:     uuid.New().String(),
+		NoLoop:          true,
+		LockOnActive:    true,
+		WhenScopeID:     uuid.New().String(),
+		ThenScopeID:     uuid.New().String(),
+	}
+	buff := &bytes.Buffer{}
+	err := ruleEntry.WriteMetaTo(buff)
+	assert.Nil(t, err)
+
+	buff2 := bytes.NewBuffer(buff.Bytes())
+
+	ruleEntry2 := &RuleEntryMeta{}
+	err = ruleEntry2.ReadMetaFrom(buff2)
+	assert.Nil(t, err)
+
+	assert.True(t, ruleEntry.Equals(ruleEntry2))
+	assert.Equal(t, ruleEntry.AgendaGroup, ruleEntry2.AgendaGroup)
+}
+
 func TestSerialization(t *testing.T) {
 	cat := &Catalog{
 		KnowledgeBaseName:    uuid.New().String(),
@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
 )
@@ -35,6 +36,8 @@ type ThenExpression struct {
 
 	Assignment     *Assignment
 	ExpressionAtom *ExpressionAtom
+	IfStatement    *IfStatement
+	ForStatement   *ForStatement
 }
 
 // MakeCatalog create a catalog entry for this AST Node
@@ -55,6 +58,14 @@ func (e *ThenExpression) MakeCatalog(cat *Catalog) {
 			meta.ExpressionAtomID = e.ExpressionAtom.AstID
 			e.ExpressionAtom.MakeCatalog(cat)
 		}
+		if e.IfStatement != nil {
+			meta.IfStatementID = e.IfStatement.AstID
+			e.IfStatement.MakeCatalog(cat)
+		}
+		if e.ForStatement != nil {
+			meta.ForStatementID = e.ForStatement.AstID
+			e.ForStatement.MakeCatalog(cat)
+		}
 	}
 }
 
@@ -90,6 +101,26 @@ func (e *ThenExpression) Clone(cloneTable *pkg.CloneTable) *ThenExpression {
 		}
 	}
 
+	if e.IfStatement != nil {
+		if cloneTable.IsCloned(e.IfStatement.AstID) {
+			clone.IfStatement = cloneTable.Records[e.IfStatement.AstID].CloneInstance.(*IfStatement)
+		} else {
+			cloned := e.IfStatement.Clone(cloneTable)
+			clone.IfStatement = cloned
+			cloneTable.MarkCloned(e.IfStatement.AstID, cloned.AstID, e.IfStatement, cloned)
+		}
+	}
+
+	if e.ForStatement != nil {
+		if cloneTable.IsCloned(e.ForStatement.AstID) {
+			clone.ForStatement = cloneTable.Records[e.ForStatement.AstID].CloneInstance.(*ForStatement)
+		} else {
+			cloned := e.ForStatement.Clone(cloneTable)
+			clone.ForStatement = cloned
+			cloneTable.MarkCloned(e.ForStatement.AstID, cloned.AstID, e.ForStatement, cloned)
+		}
+	}
+
 	return clone
 }
 
@@ -107,6 +138,20 @@ func (e *ThenExpression) AcceptExpressionAtom(exp *ExpressionAtom) error {
 	return nil
 }
 
+// AcceptIfStatement will accept an IfStatement AST graph into this ast graph
+func (e *ThenExpression) AcceptIfStatement(statement *IfStatement) error {
+	e.IfStatement = statement
+
+	return nil
+}
+
+// AcceptForStatement will accept a ForStatement AST graph into this ast graph
+func (e *ThenExpression) AcceptForStatement(statement *ForStatement) error {
+	e.ForStatement = statement
+
+	return nil
+}
+
 // GetAstID get the UUID asigned for this AST graph node
 func (e *ThenExpression) GetAstID() string {
 
@@ -130,6 +175,12 @@ func (e *ThenExpression) GetSnapshot() string {
 	if e.ExpressionAtom != nil {
 		buff.WriteString(e.ExpressionAtom.GetSnapshot())
 	}
+	if e.IfStatement != nil {
+		buff.WriteString(e.IfStatement.GetSnapshot())
+	}
+	if e.ForStatement != nil {
+		buff.WriteString(e.ForStatement.GetSnapshot())
+	}
 	buff.WriteString(")")
 
 	return buff.String()
@@ -143,8 +194,14 @@ func (e *ThenExpression) SetGrlText(grlText string) {
 
 // Execute will execute this graph in the Then scope
 func (e *ThenExpression) Execute(dataContext IDataContext, memory *WorkingMemory) error {
+
+	return e.ExecuteWithContext(context.Background(), dataContext, memory)
+}
+
+// ExecuteWithContext is Execute with the context of the execution, which carries its limits and function registries.
+func (e *ThenExpression) ExecuteWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
 	if e.Assignment != nil {
-		err := e.Assignment.Execute(dataContext, memory)
+		err := e.Assignment.ExecuteWithContext(ctx, dataContext, memory)
 		if err != nil {
 			AstLog.Errorf("error while executing assignment %s. got %s", e.Assignment.GrlText, err.Error())
 		} else {
@@ -154,7 +211,7 @@ func (e *ThenExpression) Execute(dataContext IDataContext, memory *WorkingMemory
 		return err
 	}
 	if e.ExpressionAtom != nil {
-		_, err := e.ExpressionAtom.Evaluate(dataContext, memory)
+		_, err := e.ExpressionAtom.EvaluateWithContext(ctx, dataContext, memory)
 		if err != nil {
 			AstLog.Errorf("error while executing expression %s. got %s", e.ExpressionAtom.GrlText, err.Error())
 
@@ -164,6 +221,14 @@ func (e *ThenExpression) Execute(dataContext IDataContext, memory *WorkingMemory
 
 		return nil
 	}
+	if e.IfStatement != nil {
+
+		return e.IfStatement.ExecuteWithContext(ctx, dataContext, memory)
+	}
+	if e.ForStatement != nil {
+
+		return e.ForStatement.ExecuteWithContext(ctx, dataContext, memory)
+	}
 
 	return nil
 }
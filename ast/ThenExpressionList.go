@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
 )
@@ -133,8 +134,14 @@ func (e *ThenExpressionList) SetGrlText(grlText string) {
 
 // Execute will execute this graph in the Then scope
 func (e *ThenExpressionList) Execute(dataContext IDataContext, memory *WorkingMemory) error {
+
+	return e.ExecuteWithContext(context.Background(), dataContext, memory)
+}
+
+// ExecuteWithContext is Execute with the context of the execution, which carries its limits and function registries.
+func (e *ThenExpressionList) ExecuteWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
 	for _, es := range e.ThenExpressions {
-		err := es.Execute(dataContext, memory)
+		err := es.ExecuteWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return err
@@ -16,8 +16,10 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"sync/atomic"
 )
 
 // NewThenScope will create new instance of ThenScope
@@ -34,6 +36,11 @@ type ThenScope struct {
 	GrlText string
 
 	ThenExpressionList *ThenExpressionList
+
+	// compiled is the compiled ThenExpressionList, nil until the knowledge base is compiled. It is shared by the
+	// instances of the knowledge base, which may be compiled while others are executed, but only the compiled ones
+	// run it.
+	compiled atomic.Pointer[compiledStatements]
 }
 
 // MakeCatalog create a catalog entry for this AST Node
@@ -118,9 +125,19 @@ func (e *ThenScope) SetGrlText(grlText string) {
 
 // Execute will execute this graph in the Then scope
 func (e *ThenScope) Execute(dataContext IDataContext, memory *WorkingMemory) error {
+
+	return e.ExecuteWithContext(context.Background(), dataContext, memory)
+}
+
+// ExecuteWithContext is Execute with the context of the execution, which carries its limits and function registries.
+func (e *ThenScope) ExecuteWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) error {
 	if e.ThenExpressionList == nil {
 		AstLog.Warnf("Can not execute nil expression list")
 	}
+	if compiled := e.compiled.Load(); compiled != nil && memory.runsCompiled() {
+
+		return (*compiled)(ctx, dataContext, memory)
+	}
 
-	return e.ThenExpressionList.Execute(dataContext, memory)
+	return e.ThenExpressionList.ExecuteWithContext(ctx, dataContext, memory)
 }
@@ -0,0 +1,1066 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// NewTypeChecker creates a new TypeChecker. The built-in functions are always known to the type checker
+// as the DEFUNC fact, just like the engine adds them into every data context.
+func NewTypeChecker() *TypeChecker {
+	checker := &TypeChecker{
+		facts: make(map[string]*StaticType),
+	}
+	checker.AddFact("DEFUNC", &BuiltInFunctions{})
+
+	return checker
+}
+
+// TypeChecker infers the type of the expressions of rule entries from the types of the facts they are going
+// to be executed with. The inferred types are set into the Type of the Expression, ExpressionAtom and Variable nodes.
+type TypeChecker struct {
+	facts     map[string]*StaticType
+	functions []*FunctionRegistry
+}
+
+// AddFact will register the type of the specified fact instance under the fact name used in the rules.
+func (checker *TypeChecker) AddFact(name string, fact interface{}) {
+	checker.AddFactType(name, reflect.TypeOf(fact))
+}
+
+// AddFactType will register a Go type under the fact name used in the rules.
+func (checker *TypeChecker) AddFactType(name string, typ reflect.Type) {
+	checker.facts[name] = newGoStaticType(typ)
+}
+
+// AddJSONSchema will register a JSON fact, described by a JSON schema, under the fact name used in the rules.
+// Only the "type", "properties" and "items" keywords of the schema are used.
+func (checker *TypeChecker) AddJSONSchema(name string, schema []byte) error {
+	jsonSchema := &jsonSchema{}
+	if err := json.Unmarshal(schema, jsonSchema); err != nil {
+
+		return fmt.Errorf("invalid JSON schema for fact %s. got %w", name, err)
+	}
+	checker.facts[name] = newJSONStaticType(jsonSchema)
+
+	return nil
+}
+
+// AddFunctionRegistry will make the functions of the registry known to the type checker.
+func (checker *TypeChecker) AddFunctionRegistry(registry *FunctionRegistry) {
+	checker.functions = append(checker.functions, registry)
+}
+
+func (checker *TypeChecker) registeredFunction(name string) (*RegisteredFunction, bool) {
+	for _, registry := range checker.functions {
+		if function, ok := registry.GetFunction(name); ok {
+
+			return function, true
+		}
+	}
+
+	return nil, false
+}
+
+// TypeCheckResult contains the problems and the implicit conversions found by the TypeChecker.
+type TypeCheckResult struct {
+	Errors      []*TypeError
+	Conversions []*ImplicitConversion
+}
+
+// TypeError is a type problem found by the TypeChecker in a rule entry.
+type TypeError struct {
+	RuleName string
+	// GrlText is the part of the rule having the problem.
+	GrlText string
+	// Position locates the problem in the GRL, it is not known if the rule entry is not built from a GRL.
+	Position Position
+	Message  string
+}
+
+// Error returns the rule name and the description of the problem.
+func (e *TypeError) Error() string {
+
+	return fmt.Sprintf("rule %s : %s", e.RuleName, e.Message)
+}
+
+// ImplicitConversion is a value converted by the engine into another type while executing a rule entry,
+// such as an integer promoted to a float, or a number concatenated to a string.
+type ImplicitConversion struct {
+	RuleName string
+	// GrlText is the part of the rule where the conversion happens.
+	GrlText string
+	// Position locates the conversion in the GRL, it is not known if the rule entry is not built from a GRL.
+	Position Position
+	From     *StaticType
+	To       *StaticType
+}
+
+// String returns the description of the conversion.
+func (c *ImplicitConversion) String() string {
+
+	return fmt.Sprintf("rule %s : %s is converted to %s in \"%s\"", c.RuleName, c.From, c.To, c.GrlText)
+}
+
+// CheckKnowledgeBase will check all the rule entries in the knowledge base, in the order of their names.
+func (checker *TypeChecker) CheckKnowledgeBase(knowledgeBase *KnowledgeBase) *TypeCheckResult {
+	names := make([]string, 0, len(knowledgeBase.RuleEntries))
+	for name := range knowledgeBase.RuleEntries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &TypeCheckResult{}
+	for _, name := range names {
+		entryResult := checker.CheckRuleEntry(knowledgeBase.RuleEntries[name])
+		result.Errors = append(result.Errors, entryResult.Errors...)
+		result.Conversions = append(result.Conversions, entryResult.Conversions...)
+	}
+
+	return result
+}
+
+// CheckRuleEntry will infer the types of the when and then scope of a single rule entry.
+func (checker *TypeChecker) CheckRuleEntry(ruleEntry *RuleEntry) *TypeCheckResult {
+	check := &typeCheck{
+		checker:   checker,
+		ruleEntry: ruleEntry,
+		result:    &TypeCheckResult{},
+		lets:      make(map[string]*StaticType),
+	}
+	if ruleEntry.Enabled != nil {
+		typ := check.expression(ruleEntry.Enabled)
+		if !typ.IsBool() && !typ.IsAny() {
+			check.typeError(ruleEntry.Enabled.GrlText, ruleEntry.Enabled.Position, "enabled is of type %s, not a boolean", typ)
+		}
+	}
+	if ruleEntry.WhenScope != nil {
+		for _, let := range ruleEntry.WhenScope.Lets {
+			check.let(let)
+		}
+	}
+	if ruleEntry.WhenScope != nil && ruleEntry.WhenScope.Expression != nil {
+		typ := check.expression(ruleEntry.WhenScope.Expression)
+		if !typ.IsBool() && !typ.IsAny() {
+			check.typeError(ruleEntry.WhenScope.GrlText, ruleEntry.WhenScope.Expression.Position, "when scope is of type %s, not a boolean", typ)
+		}
+	}
+	if ruleEntry.ThenScope != nil {
+		check.thenExpressionList(ruleEntry.ThenScope.ThenExpressionList)
+	}
+
+	return check.result
+}
+
+type typeCategory int
+
+const (
+	categoryAny typeCategory = iota
+	categoryNil
+	categoryNumber
+	categoryString
+	categoryBool
+	categoryTime
+	categoryOther
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// StaticType is the static type of a value in a rule, either backed by a Go type or by a JSON schema.
+type StaticType struct {
+	// GoType is the Go type of the value, nil for JSON values and for values of unknown type.
+	GoType reflect.Type
+	// Kind is the kind of the value with its pointers removed, reflect.Interface when its type is unknown
+	// and reflect.Invalid for nil. JSON objects are maps and JSON arrays are slices.
+	Kind reflect.Kind
+	// Properties are the properties of a JSON object, nil if they are not known.
+	Properties map[string]*StaticType
+	// Items is the type of the items of a JSON array, nil if it is not known.
+	Items *StaticType
+	// Unexported is true if the value is an unexported field of a struct.
+	Unexported bool
+}
+
+var (
+	anyType  = &StaticType{Kind: reflect.Interface}
+	nilType  = &StaticType{Kind: reflect.Invalid}
+	boolType = &StaticType{Kind: reflect.Bool}
+	intType  = &StaticType{Kind: reflect.Int64}
+	realType = &StaticType{Kind: reflect.Float64}
+	strType  = &StaticType{Kind: reflect.String}
+)
+
+func newGoStaticType(typ reflect.Type) *StaticType {
+	if typ == nil {
+
+		return anyType
+	}
+	elem := typ
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	return &StaticType{GoType: typ, Kind: elem.Kind()}
+}
+
+// elem returns the Go type with the pointers removed.
+func (t *StaticType) elem() reflect.Type {
+	typ := t.GoType
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+func (t *StaticType) category() typeCategory {
+	switch t.Kind {
+	case reflect.Interface:
+
+		return categoryAny
+	case reflect.Invalid:
+
+		return categoryNil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+
+		return categoryNumber
+	case reflect.String:
+
+		return categoryString
+	case reflect.Bool:
+
+		return categoryBool
+	}
+	if t.elem() == timeType {
+
+		return categoryTime
+	}
+
+	return categoryOther
+}
+
+// IsAny returns true if the type of the value is not known, so it is not checked.
+func (t *StaticType) IsAny() bool {
+
+	return t.category() == categoryAny
+}
+
+// IsNumber returns true if the value is an integer or a float.
+func (t *StaticType) IsNumber() bool {
+
+	return t.category() == categoryNumber
+}
+
+// IsReal returns true if the value is a float.
+func (t *StaticType) IsReal() bool {
+
+	return t.Kind == reflect.Float32 || t.Kind == reflect.Float64
+}
+
+// IsString returns true if the value is a string.
+func (t *StaticType) IsString() bool {
+
+	return t.category() == categoryString
+}
+
+// IsBool returns true if the value is a boolean.
+func (t *StaticType) IsBool() bool {
+
+	return t.category() == categoryBool
+}
+
+// IsTime returns true if the value is a time.Time.
+func (t *StaticType) IsTime() bool {
+
+	return t.category() == categoryTime
+}
+
+// String returns the name of the type.
+func (t *StaticType) String() string {
+	switch {
+	case t.GoType != nil:
+
+		return t.GoType.String()
+	case t.Kind == reflect.Interface:
+
+		return "any"
+	case t.Kind == reflect.Invalid:
+
+		return "nil"
+	case t.Kind == reflect.Map:
+
+		return "object"
+	case t.Kind == reflect.Slice:
+
+		return "array"
+	}
+
+	return t.Kind.String()
+}
+
+// field resolves the type of a field of this type.
+func (t *StaticType) field(name string) (*StaticType, error) {
+	switch {
+	case t.Kind == reflect.Interface:
+
+		return anyType, nil
+	case t.Properties != nil:
+		if property, ok := t.Properties[name]; ok {
+
+			return property, nil
+		}
+
+		return nil, fmt.Errorf("%s has no property named %s", t, name)
+	case t.Kind == reflect.Map && t.GoType == nil:
+
+		return anyType, nil
+	case t.Kind == reflect.Struct && t.GoType != nil:
+		field, ok := t.elem().FieldByName(name)
+		if !ok {
+
+			return nil, fmt.Errorf("%s has no field named %s", t, name)
+		}
+		fieldType := newGoStaticType(field.Type)
+		fieldType.Unexported = len(field.PkgPath) > 0
+
+		return fieldType, nil
+	}
+
+	return nil, fmt.Errorf("%s is not an object, it has no field named %s", t, name)
+}
+
+// element resolves the type of the elements of this array or map type.
+func (t *StaticType) element() (*StaticType, error) {
+	switch {
+	case t.Kind == reflect.Interface:
+
+		return anyType, nil
+	case t.GoType == nil && t.Kind == reflect.Slice:
+		if t.Items == nil {
+
+			return anyType, nil
+		}
+
+		return t.Items, nil
+	case t.GoType == nil && t.Kind == reflect.Map:
+
+		return anyType, nil
+	case t.Kind == reflect.Slice || t.Kind == reflect.Array || t.Kind == reflect.Map:
+
+		return newGoStaticType(t.elem().Elem()), nil
+	}
+
+	return nil, fmt.Errorf("%s is not an array nor map", t)
+}
+
+// stringFunctions are the functions the engine provides on string values, with their number of arguments.
+// A negative number of arguments means the function is variadic.
+var stringFunctions = map[string]struct {
+	arity   int
+	returns *StaticType
+}{
+	"In":          {-1, boolType},
+	"Compare":     {1, intType},
+	"Contains":    {1, boolType},
+	"Count":       {1, intType},
+	"HasPrefix":   {1, boolType},
+	"HasSuffix":   {1, boolType},
+	"Index":       {1, intType},
+	"LastIndex":   {1, intType},
+	"Repeat":      {1, strType},
+	"Replace":     {2, strType},
+	"Split":       {1, newGoStaticType(reflect.TypeOf([]string{}))},
+	"ToLower":     {0, strType},
+	"ToUpper":     {0, strType},
+	"Trim":        {0, strType},
+	"Len":         {0, intType},
+	"MatchString": {1, boolType},
+}
+
+// method resolves the return type of a method call on this type, checking the number of arguments.
+func (t *StaticType) method(name string, arguments []*StaticType) (*StaticType, error) {
+	argumentCount := len(arguments)
+	switch t.category() {
+	case categoryAny:
+
+		return anyType, nil
+	case categoryString:
+		function, ok := stringFunctions[name]
+		if !ok {
+
+			return nil, fmt.Errorf("function %s is not supported for string", name)
+		}
+		if function.arity >= 0 && function.arity != argumentCount {
+
+			return nil, fmt.Errorf("function %s requires %d argument(s), got %d", name, function.arity, argumentCount)
+		}
+
+		return function.returns, nil
+	case categoryNumber, categoryBool:
+
+		return nil, fmt.Errorf("function %s is not supported for type %s", name, t)
+	}
+	if t.GoType == nil {
+		// JSON arrays and objects are not checked.
+		return anyType, nil
+	}
+	switch t.Kind {
+	case reflect.Slice, reflect.Array:
+		switch name {
+		case "Len":
+
+			return checkArity(name, 0, false, argumentCount, intType)
+		case "Append":
+
+			return anyType, nil
+		}
+
+		return nil, fmt.Errorf("function %s is not supported for array", name)
+	case reflect.Map:
+		if name == "Len" {
+
+			return checkArity(name, 0, false, argumentCount, intType)
+		}
+
+		return nil, fmt.Errorf("function %s is not supported for map", name)
+	}
+
+	// methods with a pointer receiver are callable as the facts are added as pointers.
+	typ := t.GoType
+	if typ.Kind() != reflect.Ptr {
+		typ = reflect.PointerTo(typ)
+	}
+	meth, ok := typ.MethodByName(name)
+	if !ok {
+
+		return nil, fmt.Errorf("%s has no function named %s", t, name)
+	}
+	if meth.Type.NumOut() > 1 {
+
+		return nil, fmt.Errorf("function %s of %s returns multiple values, multiple value returns are not supported", name, t)
+	}
+	returns := anyType
+	if meth.Type.NumOut() == 1 {
+		returns = newGoStaticType(meth.Type.Out(0))
+	}
+
+	if _, err := checkArity(name, meth.Type.NumIn()-1, meth.Type.IsVariadic(), argumentCount, returns); err != nil {
+
+		return nil, err
+	}
+	for i, argument := range arguments {
+		param := parameterType(meth.Type, i+1)
+		if !argument.assignableTo(param) {
+
+			return nil, fmt.Errorf("argument %d of function %s is of type %s, not %s", i+1, name, argument, param)
+		}
+	}
+
+	return returns, nil
+}
+
+// parameterType returns the type of the parameter at the index of the function, taking the variadic parameter into account.
+func parameterType(function reflect.Type, index int) reflect.Type {
+	if function.IsVariadic() && index >= function.NumIn()-1 {
+
+		return function.In(function.NumIn() - 1).Elem()
+	}
+
+	return function.In(index)
+}
+
+// assignableTo checks if a value of this type can be passed as a function argument of the Go type.
+func (t *StaticType) assignableTo(typ reflect.Type) bool {
+	switch {
+	case t.IsAny() || t.category() == categoryNil:
+
+		return true
+	case t.GoType != nil:
+
+		return t.GoType.AssignableTo(typ)
+	case typ.Kind() == reflect.Interface:
+
+		return typ.NumMethod() == 0
+	}
+
+	return t.Kind == typ.Kind()
+}
+
+func checkArity(name string, arity int, variadic bool, argumentCount int, returns *StaticType) (*StaticType, error) {
+	if variadic && argumentCount < arity-1 {
+
+		return nil, fmt.Errorf("function %s requires at least %d argument(s), got %d", name, arity-1, argumentCount)
+	}
+	if !variadic && argumentCount != arity {
+
+		return nil, fmt.Errorf("function %s requires %d argument(s), got %d", name, arity, argumentCount)
+	}
+
+	return returns, nil
+}
+
+// jsonSchema is the part of a JSON schema the type checker understands.
+type jsonSchema struct {
+	Type       interface{}            `json:"type"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Items      *jsonSchema            `json:"items"`
+}
+
+func newJSONStaticType(schema *jsonSchema) *StaticType {
+	if schema == nil {
+
+		return anyType
+	}
+	// a list of types, e.g. ["string","null"], uses the first one.
+	typeName, _ := schema.Type.(string)
+	if types, ok := schema.Type.([]interface{}); ok && len(types) > 0 {
+		typeName, _ = types[0].(string)
+	}
+	switch typeName {
+	case "object":
+		if len(schema.Properties) == 0 {
+			// an object without declared properties can hold anything.
+			return &StaticType{Kind: reflect.Map}
+		}
+		typ := &StaticType{Kind: reflect.Map, Properties: make(map[string]*StaticType)}
+		for name, property := range schema.Properties {
+			typ.Properties[name] = newJSONStaticType(property)
+		}
+
+		return typ
+	case "array":
+
+		return &StaticType{Kind: reflect.Slice, Items: newJSONStaticType(schema.Items)}
+	case "string":
+
+		return strType
+	case "integer", "number":
+		// JSON numbers are always decoded as float64.
+		return realType
+	case "boolean":
+
+		return boolType
+	case "null":
+
+		return nilType
+	}
+
+	return anyType
+}
+
+// typeCheck walks the AST of a single rule entry, annotating its nodes and collecting the result.
+type typeCheck struct {
+	checker   *TypeChecker
+	ruleEntry *RuleEntry
+	result    *TypeCheckResult
+	// locals are the types of the items of the collection expressions and for statements being checked, they hide the facts.
+	locals map[string]*StaticType
+	// lets are the types of the local variables declared with let so far, by rule entry and name.
+	lets map[string]*StaticType
+}
+
+func (c *typeCheck) typeError(grlText string, position Position, format string, args ...interface{}) {
+	c.result.Errors = append(c.result.Errors, &TypeError{
+		RuleName: c.ruleEntry.RuleName,
+		GrlText:  grlText,
+		Position: position,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *typeCheck) conversion(grlText string, position Position, from, to *StaticType) {
+	c.result.Conversions = append(c.result.Conversions, &ImplicitConversion{
+		RuleName: c.ruleEntry.RuleName,
+		GrlText:  grlText,
+		Position: position,
+		From:     from,
+		To:       to,
+	})
+}
+
+// promote records the conversion of the integer operand when an integer is used with a float.
+func (c *typeCheck) promote(grlText string, position Position, left, right *StaticType) {
+	if left.IsReal() && !right.IsReal() {
+		c.conversion(grlText, position, right, realType)
+	}
+	if right.IsReal() && !left.IsReal() {
+		c.conversion(grlText, position, left, realType)
+	}
+}
+
+func (c *typeCheck) thenExpressionList(list *ThenExpressionList) {
+	if list == nil {
+
+		return
+	}
+	for _, thenExpression := range list.ThenExpressions {
+		switch {
+		case thenExpression.Assignment != nil && thenExpression.Assignment.IsLet:
+			c.let(thenExpression.Assignment)
+		case thenExpression.Assignment != nil:
+			c.assignment(thenExpression.Assignment)
+		case thenExpression.ExpressionAtom != nil:
+			c.expressionAtom(thenExpression.ExpressionAtom)
+		case thenExpression.IfStatement != nil:
+			c.ifStatement(thenExpression.IfStatement)
+		case thenExpression.ForStatement != nil:
+			c.forStatement(thenExpression.ForStatement)
+		}
+	}
+}
+
+func (c *typeCheck) ifStatement(statement *IfStatement) {
+	if typ := c.expression(statement.Condition); !typ.IsBool() && !typ.IsAny() {
+		c.typeError(statement.Condition.GrlText, statement.Condition.Position, "condition is of type %s, not a boolean", typ)
+	}
+	c.thenExpressionList(statement.Then)
+	if statement.ElseIf != nil {
+		c.ifStatement(statement.ElseIf)
+	}
+	c.thenExpressionList(statement.Else)
+}
+
+// forStatement checks the body with the item variable typed as the collection elements.
+func (c *typeCheck) forStatement(statement *ForStatement) {
+	item, err := c.expressionAtom(statement.Collection).element()
+	if err != nil {
+		c.typeError(statement.GrlText, statement.Collection.Position, "%s", err.Error())
+		item = anyType
+	}
+	defer c.hide(statement.VariableName, item)()
+	c.thenExpressionList(statement.Body)
+}
+
+// hide types the item variable until the returned function is called, hiding a fact or an item with the same name.
+func (c *typeCheck) hide(name string, item *StaticType) func() {
+	if c.locals == nil {
+		c.locals = make(map[string]*StaticType)
+	}
+	hidden, isHiding := c.locals[name]
+	c.locals[name] = item
+
+	return func() {
+		if isHiding {
+			c.locals[name] = hidden
+		} else {
+			delete(c.locals, name)
+		}
+	}
+}
+
+// let types the local variable declared by the assignment with the type of its expression.
+func (c *typeCheck) let(assignment *Assignment) {
+	typ := c.expression(assignment.Expression)
+	c.lets[assignment.Variable.LocalOf+"."+assignment.Variable.Name] = typ
+	assignment.Variable.Type = typ
+}
+
+func (c *typeCheck) assignment(assignment *Assignment) {
+	target := c.variable(assignment.Variable)
+	value := c.expression(assignment.Expression)
+	if target.Unexported {
+		c.typeError(assignment.GrlText, assignment.Position, "can not assign to unexported field %s", assignment.Variable.Name)
+
+		return
+	}
+	switch {
+	case assignment.IsPlusAssign:
+		value = c.arithmetic(assignment.GrlText, assignment.Position, OpAdd, target, value)
+	case assignment.IsMinusAssign:
+		value = c.arithmetic(assignment.GrlText, assignment.Position, OpSub, target, value)
+	case assignment.IsMulAssign:
+		value = c.arithmetic(assignment.GrlText, assignment.Position, OpMul, target, value)
+	case assignment.IsDivAssign:
+		value = c.arithmetic(assignment.GrlText, assignment.Position, OpDiv, target, value)
+	}
+	targetCategory, valueCategory := target.category(), value.category()
+	if targetCategory == categoryAny || valueCategory == categoryAny || valueCategory == categoryNil || targetCategory == categoryOther {
+
+		return
+	}
+	if targetCategory != valueCategory {
+		c.typeError(assignment.GrlText, assignment.Position, "can not assign type %s to %s", value, target)
+
+		return
+	}
+	if targetCategory == categoryNumber && target.IsReal() != value.IsReal() {
+		c.conversion(assignment.GrlText, assignment.Position, value, target)
+	}
+}
+
+func (c *typeCheck) expression(expression *Expression) *StaticType {
+	typ := c.inferExpression(expression)
+	expression.Type = typ
+
+	return typ
+}
+
+func (c *typeCheck) inferExpression(expression *Expression) *StaticType {
+	switch {
+	case expression.ExpressionAtom != nil:
+
+		return c.expressionAtom(expression.ExpressionAtom)
+	case expression.SingleExpression != nil:
+		typ := c.expression(expression.SingleExpression)
+		if expression.Negated && !typ.IsBool() && !typ.IsAny() {
+			c.typeError(expression.GrlText, expression.Position, "can not negate type %s", typ)
+		}
+
+		return typ
+	case expression.LeftExpression != nil && expression.RightExpression != nil:
+		left := c.expression(expression.LeftExpression)
+		right := c.expression(expression.RightExpression)
+
+		return c.operation(expression, left, right)
+	}
+
+	return anyType
+}
+
+func (c *typeCheck) operation(expression *Expression, left, right *StaticType) *StaticType {
+	leftCategory, rightCategory := left.category(), right.category()
+	unchecked := leftCategory == categoryAny || rightCategory == categoryAny
+	switch expression.Operator {
+	case OpAnd, OpOr:
+		for _, operand := range []*StaticType{left, right} {
+			if !operand.IsBool() && !operand.IsAny() {
+				c.typeError(expression.GrlText, expression.Position, "can not use type %s in a logical operation", operand)
+			}
+		}
+
+		return boolType
+	case OpGT, OpLT, OpGTE, OpLTE:
+		ordered := leftCategory == rightCategory &&
+			(leftCategory == categoryNumber || leftCategory == categoryString || leftCategory == categoryTime)
+		if !unchecked && !ordered {
+			c.typeError(expression.GrlText, expression.Position, "can not compare type %s with %s", left, right)
+		} else if leftCategory == categoryNumber && rightCategory == categoryNumber {
+			c.promote(expression.GrlText, expression.Position, left, right)
+		}
+
+		return boolType
+	case OpEq, OpNEq:
+		if !unchecked && leftCategory != categoryNil && rightCategory != categoryNil && leftCategory != rightCategory {
+			c.typeError(expression.GrlText, expression.Position, "can not compare type %s with %s", left, right)
+		} else if leftCategory == categoryNumber && rightCategory == categoryNumber {
+			c.promote(expression.GrlText, expression.Position, left, right)
+		}
+
+		return boolType
+	}
+
+	return c.arithmetic(expression.GrlText, expression.Position, expression.Operator, left, right)
+}
+
+func (c *typeCheck) arithmetic(grlText string, position Position, operator int, left, right *StaticType) *StaticType {
+	leftCategory, rightCategory := left.category(), right.category()
+	if leftCategory == categoryAny || rightCategory == categoryAny {
+
+		return anyType
+	}
+	if operator == OpAdd && (leftCategory == categoryString || rightCategory == categoryString) {
+		// the other operand is formatted into the string, except a nil or an object which can not be concatenated.
+		for _, operand := range []*StaticType{left, right} {
+			switch operand.category() {
+			case categoryString:
+			case categoryNumber, categoryBool, categoryTime:
+				c.conversion(grlText, position, operand, strType)
+			default:
+				c.typeError(grlText, position, "can not concatenate type %s to a string", operand)
+			}
+		}
+
+		return strType
+	}
+	if leftCategory != categoryNumber || rightCategory != categoryNumber {
+		c.typeError(grlText, position, "can not use type %s and %s in an arithmetic operation", left, right)
+
+		return anyType
+	}
+	switch operator {
+	case OpDiv:
+		// a division always gives a float.
+		if !left.IsReal() {
+			c.conversion(grlText, position, left, realType)
+		}
+		if !right.IsReal() {
+			c.conversion(grlText, position, right, realType)
+		}
+
+		return realType
+	case OpMod, OpBitAnd, OpBitOr:
+		if left.IsReal() || right.IsReal() {
+			c.typeError(grlText, position, "can not use type %s and %s in an integer operation", left, right)
+		}
+
+		return intType
+	}
+	if left.IsReal() || right.IsReal() {
+		c.promote(grlText, position, left, right)
+
+		return realType
+	}
+
+	return intType
+}
+
+func (c *typeCheck) expressionAtom(atom *ExpressionAtom) *StaticType {
+	typ := c.inferExpressionAtom(atom)
+	atom.Type = typ
+
+	return typ
+}
+
+func (c *typeCheck) inferExpressionAtom(atom *ExpressionAtom) *StaticType {
+	switch {
+	case atom.Constant != nil:
+		if atom.Constant.IsNil || !atom.Constant.Value.IsValid() {
+
+			return nilType
+		}
+
+		return newGoStaticType(atom.Constant.Value.Type())
+	case atom.Variable != nil:
+
+		return c.variable(atom.Variable)
+	case atom.CollectionExpression != nil:
+
+		return c.collectionExpression(atom.CollectionExpression)
+	case atom.ExpressionAtom == nil && atom.FunctionCall != nil:
+		if function, ok := c.checker.registeredFunction(atom.FunctionCall.FunctionName); ok {
+
+			return c.registeredFunctionCall(function, atom.FunctionCall)
+		}
+
+		return c.functionCall(c.checker.facts["DEFUNC"], atom.FunctionCall)
+	case atom.ExpressionAtom != nil && atom.FunctionCall != nil:
+		if namespace := atom.ExpressionAtom.Variable; namespace != nil && namespace.Variable == nil {
+			if _, isFact := c.fact(namespace.Name); !isFact {
+				if function, ok := c.checker.registeredFunction(namespace.Name + "." + atom.FunctionCall.FunctionName); ok {
+
+					return c.registeredFunctionCall(function, atom.FunctionCall)
+				}
+			}
+		}
+
+		return c.functionCall(c.expressionAtom(atom.ExpressionAtom), atom.FunctionCall)
+	case atom.ExpressionAtom != nil && len(atom.VariableName) > 0:
+		typ, err := c.expressionAtom(atom.ExpressionAtom).field(atom.VariableName)
+		if err != nil {
+			c.typeError(atom.GrlText, atom.Position, "%s", err.Error())
+
+			return anyType
+		}
+
+		return typ
+	case atom.ExpressionAtom != nil && atom.ArrayMapSelector != nil:
+
+		return c.selector(atom.GrlText, c.expressionAtom(atom.ExpressionAtom), atom.ArrayMapSelector)
+	case atom.ExpressionAtom != nil:
+		typ := c.expressionAtom(atom.ExpressionAtom)
+		if atom.Negated && !typ.IsBool() && !typ.IsAny() {
+			c.typeError(atom.GrlText, atom.Position, "can not negate type %s", typ)
+		}
+
+		return typ
+	}
+
+	return anyType
+}
+
+// fact returns the type of the fact, or of the collection item, with that name.
+func (c *typeCheck) fact(name string) (*StaticType, bool) {
+	if typ, ok := c.locals[name]; ok {
+
+		return typ, true
+	}
+	typ, ok := c.checker.facts[name]
+
+	return typ, ok
+}
+
+// collectionExpression checks the filter and the expression with the item variable typed as the collection elements.
+func (c *typeCheck) collectionExpression(collection *CollectionExpression) *StaticType {
+	item, err := c.expressionAtom(collection.Collection).element()
+	if err != nil {
+		c.typeError(collection.GrlText, collection.Position, "%s", err.Error())
+		item = anyType
+	}
+	defer c.hide(collection.VariableName, item)()
+
+	if collection.Filter != nil {
+		if typ := c.expression(collection.Filter); !typ.IsBool() && !typ.IsAny() {
+			c.typeError(collection.GrlText, collection.Position, "filter is of type %s, not a boolean", typ)
+		}
+	}
+	value := item
+	if collection.Expression != nil {
+		value = c.expression(collection.Expression)
+	}
+	switch collection.Operator {
+	case CollectionExists, CollectionForall, CollectionCount:
+		if collection.Expression != nil && !value.IsBool() && !value.IsAny() {
+			c.typeError(collection.GrlText, collection.Position, "predicate is of type %s, not a boolean", value)
+		}
+		if collection.Operator == CollectionCount {
+
+			return intType
+		}
+
+		return boolType
+	case CollectionSum:
+		switch {
+		case value.IsAny():
+
+			return anyType
+		case value.IsReal():
+
+			return realType
+		case value.IsNumber():
+
+			return intType
+		}
+		c.typeError(collection.GrlText, collection.Position, "can not sum type %s", value)
+
+		return anyType
+	}
+	switch value.category() {
+	case categoryAny, categoryNumber, categoryString, categoryTime:
+	default:
+		c.typeError(collection.GrlText, collection.Position, "can not compare type %s with %s", value, value)
+	}
+
+	return value
+}
+
+func (c *typeCheck) functionCall(receiver *StaticType, functionCall *FunctionCall) *StaticType {
+	arguments := make([]*StaticType, 0)
+	if functionCall.ArgumentList != nil {
+		for _, argument := range functionCall.ArgumentList.Arguments {
+			arguments = append(arguments, c.expression(argument))
+		}
+	}
+	typ, err := receiver.method(functionCall.FunctionName, arguments)
+	if err != nil {
+		c.typeError(functionCall.GrlText, functionCall.Position, "%s", err.Error())
+
+		return anyType
+	}
+
+	return typ
+}
+
+// registeredFunctionCall checks a call to a function of a FunctionRegistry, numeric arguments are converted to the
+// numeric type of the parameter.
+func (c *typeCheck) registeredFunctionCall(function *RegisteredFunction, functionCall *FunctionCall) *StaticType {
+	arguments := make([]*StaticType, 0)
+	if functionCall.ArgumentList != nil {
+		for _, argument := range functionCall.ArgumentList.Arguments {
+			arguments = append(arguments, c.expression(argument))
+		}
+	}
+	if err := function.CheckArity(len(arguments)); err != nil {
+		c.typeError(functionCall.GrlText, functionCall.Position, "%s", err.Error())
+
+		return anyType
+	}
+	types, variadic, _ := function.GetParameterTypes()
+	for i, argument := range arguments {
+		var param reflect.Type
+		if variadic && i >= len(types)-1 {
+			param = types[len(types)-1].Elem()
+		} else {
+			param = types[i]
+		}
+		paramType := newGoStaticType(param)
+		switch {
+		case argument.assignableTo(param):
+		case argument.IsNumber() && paramType.IsNumber():
+			c.conversion(functionCall.GrlText, functionCall.ArgumentList.Arguments[i].Position, argument, paramType)
+		default:
+			c.typeError(functionCall.GrlText, functionCall.ArgumentList.Arguments[i].Position, "argument %d of function %s is of type %s, not %s", i+1, function.Name, argument, param)
+		}
+	}
+	if !function.withValue {
+
+		return anyType
+	}
+
+	return newGoStaticType(function.function.Type().Out(0))
+}
+
+func (c *typeCheck) variable(variable *Variable) *StaticType {
+	typ := c.inferVariable(variable)
+	variable.Type = typ
+
+	return typ
+}
+
+func (c *typeCheck) inferVariable(variable *Variable) *StaticType {
+	switch {
+	case len(variable.LocalOf) > 0:
+		typ, ok := c.lets[variable.LocalOf+"."+variable.Name]
+		if !ok {
+
+			return anyType
+		}
+
+		return typ
+	case len(variable.Name) > 0 && variable.Variable == nil:
+		typ, ok := c.fact(variable.Name)
+		if !ok {
+			c.typeError(variable.GrlText, variable.Position, "unknown fact %s", variable.Name)
+
+			return anyType
+		}
+
+		return typ
+	case variable.Variable != nil && len(variable.Name) > 0:
+		typ, err := c.variable(variable.Variable).field(variable.Name)
+		if err != nil {
+			c.typeError(variable.GrlText, variable.Position, "%s", err.Error())
+
+			return anyType
+		}
+
+		return typ
+	case variable.Variable != nil && variable.ArrayMapSelector != nil:
+
+		return c.selector(variable.GrlText, c.variable(variable.Variable), variable.ArrayMapSelector)
+	}
+
+	return anyType
+}
+
+func (c *typeCheck) selector(grlText string, parent *StaticType, selector *ArrayMapSelector) *StaticType {
+	if selector.Expression != nil {
+		c.expression(selector.Expression)
+	}
+	typ, err := parent.element()
+	if err != nil {
+		c.typeError(grlText, selector.Position, "%s", err.Error())
+
+		return anyType
+	}
+
+	return typ
+}
@@ -0,0 +1,184 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TypeCheckedFact struct {
+	Count  int
+	Ratio  float64
+	Name   string
+	Scores []int
+	hidden int
+}
+
+func (f *TypeCheckedFact) Scale(factor float64) float64 {
+
+	return f.Ratio * factor
+}
+
+func typeCheckedField(field string) *Expression {
+
+	return &Expression{
+		GrlText: "Fact." + field,
+		ExpressionAtom: &ExpressionAtom{
+			GrlText: "Fact." + field,
+			Variable: &Variable{
+				GrlText:  "Fact." + field,
+				Name:     field,
+				Variable: &Variable{GrlText: "Fact", Name: "Fact"},
+			},
+		},
+	}
+}
+
+func typeCheckedConstant(value interface{}) *Expression {
+
+	return &Expression{ExpressionAtom: &ExpressionAtom{Constant: &Constant{Value: reflect.ValueOf(value)}}}
+}
+
+func typeCheckedOperation(operator int, left, right *Expression) *Expression {
+
+	return &Expression{GrlText: "operation", LeftExpression: left, RightExpression: right, Operator: operator}
+}
+
+func checkTypes(when *Expression) *TypeCheckResult {
+	checker := NewTypeChecker()
+	checker.AddFact("Fact", &TypeCheckedFact{})
+
+	return checker.CheckRuleEntry(&RuleEntry{RuleName: "Check", WhenScope: &WhenScope{Expression: when}})
+}
+
+func TestTypeChecker_Promotion(t *testing.T) {
+	count := typeCheckedField("Count")
+	sum := typeCheckedOperation(OpAdd, count, typeCheckedConstant(1.5))
+	when := typeCheckedOperation(OpGT, sum, typeCheckedField("Ratio"))
+
+	result := checkTypes(when)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, reflect.Bool, when.Type.Kind)
+	assert.Equal(t, reflect.Float64, sum.Type.Kind)
+	assert.Equal(t, reflect.Int, count.Type.Kind)
+	assert.Equal(t, reflect.Int, count.ExpressionAtom.Variable.Type.Kind)
+	assert.Equal(t, reflect.Struct, count.ExpressionAtom.Variable.Variable.Type.Kind)
+	if assert.Len(t, result.Conversions, 1) {
+		assert.Equal(t, "int", result.Conversions[0].From.String())
+		assert.Equal(t, "float64", result.Conversions[0].To.String())
+	}
+}
+
+func TestTypeChecker_Division(t *testing.T) {
+	division := typeCheckedOperation(OpDiv, typeCheckedField("Count"), typeCheckedConstant(int64(2)))
+	when := typeCheckedOperation(OpEq, division, typeCheckedConstant(1.5))
+
+	result := checkTypes(when)
+	assert.Empty(t, result.Errors)
+	assert.True(t, division.Type.IsReal())
+	assert.Len(t, result.Conversions, 2)
+}
+
+func TestTypeChecker_Concatenation(t *testing.T) {
+	concat := typeCheckedOperation(OpAdd, typeCheckedField("Name"), typeCheckedField("Count"))
+	when := typeCheckedOperation(OpEq, concat, typeCheckedConstant("Count5"))
+
+	result := checkTypes(when)
+	assert.Empty(t, result.Errors)
+	assert.True(t, concat.Type.IsString())
+	if assert.Len(t, result.Conversions, 1) {
+		assert.Equal(t, "int", result.Conversions[0].From.String())
+		assert.Equal(t, "string", result.Conversions[0].To.String())
+	}
+}
+
+func TestTypeChecker_Errors(t *testing.T) {
+	testData := []struct {
+		when    *Expression
+		message string
+	}{
+		{typeCheckedOperation(OpGT, typeCheckedConstant("a"), typeCheckedConstant(int64(3))), "can not compare type string with int64"},
+		{typeCheckedOperation(OpSub, typeCheckedField("Name"), typeCheckedConstant(int64(3))), "can not use type string and int64 in an arithmetic operation"},
+		{typeCheckedOperation(OpAnd, typeCheckedField("Count"), typeCheckedConstant(true)), "can not use type int in a logical operation"},
+		{typeCheckedField("Missing"), "TypeCheckedFact has no field named Missing"},
+		{typeCheckedField("Ratio"), "when scope is of type float64, not a boolean"},
+	}
+	for _, td := range testData {
+		result := checkTypes(td.when)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Contains(t, result.Errors[0].Message, td.message)
+			assert.Equal(t, "Check", result.Errors[0].RuleName)
+		}
+	}
+}
+
+func TestTypeChecker_Assignment(t *testing.T) {
+	checker := NewTypeChecker()
+	checker.AddFact("Fact", &TypeCheckedFact{})
+	assign := func(field string, value *Expression) *TypeCheckResult {
+		ruleEntry := &RuleEntry{
+			RuleName: "Assign",
+			ThenScope: &ThenScope{ThenExpressionList: &ThenExpressionList{ThenExpressions: []*ThenExpression{
+				{Assignment: &Assignment{Variable: typeCheckedField(field).ExpressionAtom.Variable, Expression: value, IsAssign: true}},
+			}}},
+		}
+
+		return checker.CheckRuleEntry(ruleEntry)
+	}
+
+	result := assign("Count", typeCheckedField("Ratio"))
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Conversions, 1)
+
+	result = assign("hidden", typeCheckedConstant(int64(1)))
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "can not assign to unexported field hidden", result.Errors[0].Message)
+	}
+
+	result = assign("Name", typeCheckedConstant(true))
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "can not assign type bool to string", result.Errors[0].Message)
+	}
+}
+
+func TestTypeChecker_FunctionCall(t *testing.T) {
+	call := &Expression{ExpressionAtom: &ExpressionAtom{
+		ExpressionAtom: &ExpressionAtom{Variable: &Variable{Name: "Fact"}},
+		FunctionCall: &FunctionCall{
+			FunctionName: "Scale",
+			ArgumentList: &ArgumentList{Arguments: []*Expression{typeCheckedField("Ratio")}},
+		},
+	}}
+	when := typeCheckedOperation(OpLT, call, typeCheckedConstant(1.0))
+
+	result := checkTypes(when)
+	assert.Empty(t, result.Errors)
+	assert.True(t, call.Type.IsReal())
+
+	call.ExpressionAtom.FunctionCall.ArgumentList.Arguments = []*Expression{typeCheckedField("Count")}
+	result = checkTypes(when)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "argument 1 of function Scale is of type int, not float64", result.Errors[0].Message)
+	}
+
+	call.ExpressionAtom.FunctionCall.ArgumentList.Arguments = nil
+	result = checkTypes(when)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "function Scale requires 1 argument(s), got 0", result.Errors[0].Message)
+	}
+}
@@ -16,6 +16,7 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/model"
@@ -37,12 +38,29 @@ type Variable struct {
 	AstID   string
 	GrlText string
 
+	// Position locates the node in the GRL, as the node is shared, at the first of its occurrences.
+	Position Position
+
 	Name             string
 	Variable         *Variable
 	ArrayMapSelector *ArrayMapSelector
+	// LocalOf is the name of the rule entry declaring this variable with let, empty if the variable is a fact.
+	LocalOf string
 
+	// ValueNode is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.ValueNodeOf.
 	ValueNode model.ValueNode
-	Value     reflect.Value
+	// Value is not set anymore, as the AST is shared by the instances of a knowledge base.
+	//
+	// Deprecated: use WorkingMemory.ValueOf.
+	Value reflect.Value
+
+	// Type is the type inferred by the TypeChecker, nil if the node is not checked.
+	Type *StaticType
+
+	// slot is the index of the evaluation state of this node in the frame of the working memory.
+	slot int
 }
 
 // MakeCatalog create a catalog entry for this AST Node
@@ -64,15 +82,19 @@ func (e *Variable) MakeCatalog(cat *Catalog) {
 			e.ArrayMapSelector.MakeCatalog(cat)
 		}
 		meta.Name = e.Name
+		meta.LocalOf = e.LocalOf
 	}
 }
 
 // Clone will clone this Variable. The new clone will have an identical structure
 func (e *Variable) Clone(cloneTable *pkg.CloneTable) *Variable {
 	clone := &Variable{
-		AstID:   unique.NewID(),
-		GrlText: e.GrlText,
-		Name:    e.Name,
+		AstID:    unique.NewID(),
+		GrlText:  e.GrlText,
+		Position: e.Position,
+		Name:     e.Name,
+		LocalOf:  e.LocalOf,
+		Type:     e.Type,
 	}
 
 	if e.Variable != nil {
@@ -143,7 +165,9 @@ func (e *Variable) GetSnapshot() string {
 	var buff bytes.Buffer
 	buff.WriteString(VARIABLE)
 	buff.WriteString("(")
-	if len(e.Name) > 0 && e.Variable == nil {
+	if len(e.LocalOf) > 0 {
+		buff.WriteString(fmt.Sprintf("L:%s:%s", e.LocalOf, e.Name))
+	} else if len(e.Name) > 0 && e.Variable == nil {
 		buff.WriteString("N:")
 		buff.WriteString(e.Name)
 	} else if e.Variable != nil && len(e.Name) > 0 {
@@ -164,6 +188,17 @@ func (e *Variable) SetGrlText(grlText string) {
 
 // Assign will assign the specified value to the variable
 func (e *Variable) Assign(newVal reflect.Value, dataContext IDataContext, memory *WorkingMemory) error {
+
+	return e.AssignWithContext(context.Background(), newVal, dataContext, memory)
+}
+
+// AssignWithContext is Assign with the context of the execution, which carries its limits and function registries.
+func (e *Variable) AssignWithContext(ctx context.Context, newVal reflect.Value, dataContext IDataContext, memory *WorkingMemory) error {
+	if len(e.LocalOf) > 0 {
+		memory.bindLocal(e, newVal)
+
+		return nil
+	}
 	if len(e.Name) > 0 && e.Variable == nil {
 		err := dataContext.Add(e.Name, pkg.ValueToInterface(newVal))
 		if err == nil {
@@ -173,11 +208,11 @@ func (e *Variable) Assign(newVal reflect.Value, dataContext IDataContext, memory
 		return err
 	}
 	if e.Variable != nil && len(e.Name) > 0 {
-		_, err := e.Variable.Evaluate(dataContext, memory)
+		_, parentValueNode, err := e.Variable.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 			return err
 		}
-		err = e.Variable.ValueNode.SetObjectValueByField(e.Name, newVal)
+		err = parentValueNode.SetObjectValueByField(e.Name, newVal)
 		if err == nil {
 			dataContext.IncrementVariableChangeCount()
 			memory.ResetVariable(e)
@@ -186,26 +221,26 @@ func (e *Variable) Assign(newVal reflect.Value, dataContext IDataContext, memory
 		return err
 	}
 	if e.Variable != nil && e.ArrayMapSelector != nil {
-		_, err := e.Variable.Evaluate(dataContext, memory)
+		_, parentValueNode, err := e.Variable.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return err
 		}
-		_, err = e.ArrayMapSelector.Evaluate(dataContext, memory)
+		selValue, err := e.ArrayMapSelector.EvaluateWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return err
 		}
-		if e.Variable.ValueNode.IsArray() {
-			err := e.Variable.ValueNode.SetArrayValueAt(int(e.ArrayMapSelector.Value.Int()), newVal)
+		if parentValueNode.IsArray() {
+			err := parentValueNode.SetArrayValueAt(int(selValue.Int()), newVal)
 			if err == nil {
 				memory.ResetVariable(e)
 			}
 
 			return err
 		}
-		if e.Variable.ValueNode.IsMap() {
-			err := e.Variable.ValueNode.SetMapValueAt(e.ArrayMapSelector.Value, newVal)
+		if parentValueNode.IsMap() {
+			err := parentValueNode.SetMapValueAt(selValue, newVal)
 			if err == nil {
 				memory.ResetVariable(e)
 			}
@@ -219,66 +254,100 @@ func (e *Variable) Assign(newVal reflect.Value, dataContext IDataContext, memory
 
 // Evaluate will evaluate this AST graph for when scope evaluation
 func (e *Variable) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *Variable) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	val, _, err := e.evaluateValueNode(ctx, dataContext, memory)
+
+	return val, err
+}
+
+// evaluateValueNode will evaluate this AST graph and return the value node of the result along with its value.
+// The value node must be read while the state is locked, as another rule entry sharing this node could be evaluating it.
+func (e *Variable) evaluateValueNode(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, model.ValueNode, error) {
+	state := memory.state(e.slot, e)
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	val, err := e.evaluate(ctx, state, dataContext, memory)
+
+	return val, state.valueNode, err
+}
+
+func (e *Variable) evaluate(ctx context.Context, state *nodeState, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	if len(e.LocalOf) > 0 {
+		valueNode, ok := memory.GetLocal(e.LocalOf, e.Name)
+		if !ok {
+
+			return reflect.Value{}, fmt.Errorf("local variable %s of rule %s is not bound", e.Name, e.LocalOf)
+		}
+		state.valueNode = valueNode
+		state.value = valueNode.Value()
+
+		return state.value, nil
+	}
 	if len(e.Name) > 0 && e.Variable == nil {
 		valueNode := dataContext.Get(e.Name)
 		if valueNode == nil {
 
-			return reflect.ValueOf(nil), fmt.Errorf("non existent key %s", e.Name)
+			return reflect.ValueOf(nil), &MissingFactError{FactName: e.Name}
 		}
-		e.ValueNode = valueNode
-		e.Value = valueNode.Value()
+		state.valueNode = valueNode
+		state.value = valueNode.Value()
 
-		return e.Value, nil
+		return state.value, nil
 	}
 	if e.Variable != nil && len(e.Name) > 0 {
-		_, err := e.Variable.Evaluate(dataContext, memory)
+		_, parentValueNode, err := e.Variable.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		valueNode, err := e.Variable.ValueNode.GetChildNodeByField(e.Name)
+		valueNode, err := parentValueNode.GetChildNodeByField(e.Name)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		e.ValueNode = valueNode
-		e.Value = valueNode.Value()
+		state.valueNode = valueNode
+		state.value = valueNode.Value()
 
-		return e.Value, nil
+		return state.value, nil
 	}
 	if e.Variable != nil && e.ArrayMapSelector != nil {
-		_, err := e.Variable.Evaluate(dataContext, memory)
+		_, parentValueNode, err := e.Variable.evaluateValueNode(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
-		selValue, err := e.ArrayMapSelector.Evaluate(dataContext, memory)
+		selValue, err := e.ArrayMapSelector.EvaluateWithContext(ctx, dataContext, memory)
 		if err != nil {
 
 			return reflect.Value{}, err
 		}
 		var valueNode model.ValueNode
-		if e.Variable.ValueNode.IsArray() {
-			valueNode, err = e.Variable.ValueNode.GetChildNodeByIndex(int(selValue.Int()))
+		if parentValueNode.IsArray() {
+			valueNode, err = parentValueNode.GetChildNodeByIndex(int(selValue.Int()))
 			if err != nil {
 
 				return reflect.Value{}, err
 			}
-		} else if e.Variable.ValueNode.IsMap() {
-			valueNode, err = e.Variable.ValueNode.GetChildNodeBySelector(selValue)
+		} else if parentValueNode.IsMap() {
+			valueNode, err = parentValueNode.GetChildNodeBySelector(selValue)
 			if err != nil {
 
 				return reflect.Value{}, err
 			}
 		} else {
 
-			return reflect.Value{}, fmt.Errorf("%s is not an array nor map", e.Variable.ValueNode.IdentifiedAs())
+			return reflect.Value{}, fmt.Errorf("%s is not an array nor map", parentValueNode.IdentifiedAs())
 		}
 
-		e.ValueNode = valueNode
-		e.Value = valueNode.Value()
+		state.valueNode = valueNode
+		state.value = valueNode.Value()
 
-		return e.Value, nil
+		return state.value, nil
 	}
 
 	return reflect.ValueOf(nil), fmt.Errorf("this code part should not be reached")
@@ -16,9 +16,11 @@ package ast
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"reflect"
+	"sync/atomic"
 
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
 )
@@ -36,7 +38,13 @@ type WhenScope struct {
 	AstID   string
 	GrlText string
 
+	// Lets are the local variables declared with let before the expression, bound in order on every evaluation.
+	Lets       []*Assignment
 	Expression *Expression
+
+	// compiled is the compiled Expression, nil until the knowledge base is compiled. It is shared by the instances
+	// of the knowledge base, which may be compiled while others are executed, but only the compiled ones run it.
+	compiled atomic.Pointer[compiledExpression]
 }
 
 // MakeCatalog create a catalog entry for this AST Node
@@ -49,6 +57,13 @@ func (e *WhenScope) MakeCatalog(cat *Catalog) {
 		},
 	}
 	if cat.AddMeta(e.AstID, meta) {
+		if len(e.Lets) > 0 {
+			meta.LetIDs = make([]string, len(e.Lets))
+			for i, let := range e.Lets {
+				meta.LetIDs[i] = let.AstID
+				let.MakeCatalog(cat)
+			}
+		}
 		if e.Expression != nil {
 			meta.ExpressionID = e.Expression.AstID
 			e.Expression.MakeCatalog(cat)
@@ -68,6 +83,19 @@ func (e *WhenScope) Clone(cloneTable *pkg.CloneTable) *WhenScope {
 		GrlText: e.GrlText,
 	}
 
+	if len(e.Lets) > 0 {
+		clone.Lets = make([]*Assignment, len(e.Lets))
+		for i, let := range e.Lets {
+			if cloneTable.IsCloned(let.AstID) {
+				clone.Lets[i] = cloneTable.Records[let.AstID].CloneInstance.(*Assignment)
+			} else {
+				cloned := let.Clone(cloneTable)
+				clone.Lets[i] = cloned
+				cloneTable.MarkCloned(let.AstID, cloned.AstID, let, cloned)
+			}
+		}
+	}
+
 	if e.Expression != nil {
 		if cloneTable.IsCloned(e.Expression.AstID) {
 			clone.Expression = cloneTable.Records[e.Expression.AstID].CloneInstance.(*Expression)
@@ -92,6 +120,17 @@ func (e *WhenScope) AcceptExpression(exp *Expression) error {
 	return errors.New("expression for when scope already assigned")
 }
 
+// AcceptAssignment will accept the let declaration of a local variable into this node
+func (e *WhenScope) AcceptAssignment(assignment *Assignment) error {
+	if !assignment.IsLet {
+
+		return errors.New("when scope can only declare local variables")
+	}
+	e.Lets = append(e.Lets, assignment)
+
+	return nil
+}
+
 // GetAstID get the UUID asigned for this AST graph node
 func (e *WhenScope) GetAstID() string {
 
@@ -109,6 +148,10 @@ func (e *WhenScope) GetSnapshot() string {
 	var buff bytes.Buffer
 	buff.WriteString(WHENSCOPE)
 	buff.WriteString("(")
+	for _, let := range e.Lets {
+		buff.WriteString(let.GetSnapshot())
+		buff.WriteString(";")
+	}
 	if e.Expression != nil {
 		buff.WriteString(e.Expression.GetSnapshot())
 	}
@@ -126,5 +169,21 @@ func (e *WhenScope) SetGrlText(grlText string) {
 // Evaluate will evaluate this AST graph for when scope evaluation
 func (e *WhenScope) Evaluate(dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
 
-	return e.Expression.Evaluate(dataContext, memory)
+	return e.EvaluateWithContext(context.Background(), dataContext, memory)
+}
+
+// EvaluateWithContext is Evaluate with the context of the execution, which carries its limits and function registries.
+func (e *WhenScope) EvaluateWithContext(ctx context.Context, dataContext IDataContext, memory *WorkingMemory) (reflect.Value, error) {
+	for _, let := range e.Lets {
+		if err := let.execute(ctx, dataContext, memory); err != nil {
+
+			return reflect.Value{}, err
+		}
+	}
+	if compiled := e.compiled.Load(); compiled != nil && memory.runsCompiled() {
+
+		return (*compiled)(ctx, dataContext, memory)
+	}
+
+	return e.Expression.EvaluateWithContext(ctx, dataContext, memory)
 }
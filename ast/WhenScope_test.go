@@ -71,6 +71,10 @@ func TestNewWhenScopeEvaluate(t *testing.T) {
 	val, err := expr1.Evaluate(dt, wm)
 	assert.NoError(t, err)
 	assert.Equal(t, 123, int(val.Int()))
+	assert.True(t, wm.IsEvaluated(expr1))
+	assert.Equal(t, 123, int(wm.ValueOf(expr1).Int()))
+	assert.Equal(t, 123, int(wm.ValueOf(expr1.SingleExpression.ExpressionAtom).Int()))
+	assert.False(t, wm.ValueOf(NewWhenScope()).IsValid())
 
 	ws := NewWhenScope()
 	assert.Nil(t, ws.AcceptExpression(expr1))
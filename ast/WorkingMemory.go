@@ -18,8 +18,12 @@ import (
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast/unique"
 	"github.com/hyperjumptech/grule-rule-engine/logger"
+	"github.com/hyperjumptech/grule-rule-engine/model"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -48,6 +52,31 @@ type WorkingMemory struct {
 	expressionVariableMap     map[*Variable][]*Expression
 	expressionAtomVariableMap map[*Variable][]*ExpressionAtom
 	ID                        string
+
+	// slots is the number of slots given to the registered nodes, see Frame.go.
+	slots int
+	// shared is set once the maps are shared with an instance, they are copied before being changed again.
+	shared atomic.Bool
+	// frame holds the evaluation state of the nodes.
+	frame frame
+
+	// changedVariables records the variables reset since the last call to TakeChanges.
+	changedVariables []*Variable
+	// changedAll is set when expressions are reset without knowing which variable caused it.
+	changedAll bool
+	// tracer receives the values computed during evaluation, if set.
+	tracer EvaluationTracer
+	// compiled tells whether the knowledge base of this working memory is compiled. The compiled closures are kept
+	// by the AST shared with the other instances of the knowledge base, only the compiled ones run them.
+	compiled bool
+	// background tracks the goroutines started by Go.
+	background sync.WaitGroup
+	// outer is the working memory the items of a collection are evaluated for, see itemMemory.
+	outer *WorkingMemory
+	// locals are the values of the local variables declared with let, by rule entry name then variable name.
+	locals map[string]map[string]model.ValueNode
+	// localsLock guards locals, as the when scopes of rule entries may be evaluated concurrently.
+	localsLock sync.RWMutex
 }
 
 // MakeCatalog create a catalog entry of this working memory
@@ -226,6 +255,7 @@ func (workingMem *WorkingMemory) Clone(cloneTable *pkg.CloneTable) (*WorkingMemo
 	}
 
 	if workingMem.Equals(clone) {
+		clone.assignSlots()
 		clone.DebugContent()
 
 		return clone, nil
@@ -247,6 +277,17 @@ func (workingMem *WorkingMemory) IndexVariables() {
 	workingMem.expressionVariableMap = make(map[*Variable][]*Expression)
 	workingMem.expressionAtomVariableMap = make(map[*Variable][]*ExpressionAtom)
 
+	// a collection expression reads the variables under its collection, e.g. Order.Items[0].Price is read by
+	// forall(i in Order.Items : i.Price > 0), so it is indexed, along with the expressions containing it,
+	// under those variables too.
+	collections := make(map[string][]string)
+	for exprAtmSnapshot, exprAtm := range workingMem.expressionAtomSnapshotMap {
+		if exprAtm.CollectionExpression != nil && exprAtm.CollectionExpression.Collection != nil && exprAtm.CollectionExpression.Collection.Variable != nil {
+			collectionSnapshot := exprAtm.CollectionExpression.Collection.Variable.GetSnapshot()
+			collections[collectionSnapshot] = append(collections[collectionSnapshot], exprAtmSnapshot)
+		}
+	}
+
 	for varSnapshot, variable := range workingMem.variableSnapshotMap {
 		if _, ok := workingMem.expressionVariableMap[variable]; ok == false {
 			workingMem.expressionVariableMap[variable] = make([]*Expression, 0)
@@ -254,21 +295,121 @@ func (workingMem *WorkingMemory) IndexVariables() {
 		if _, ok := workingMem.expressionAtomVariableMap[variable]; ok == false {
 			workingMem.expressionAtomVariableMap[variable] = make([]*ExpressionAtom, 0)
 		}
+		readers := []string{varSnapshot}
+		for collectionSnapshot, exprAtmSnapshots := range collections {
+			if varSnapshot != collectionSnapshot && strings.Contains(varSnapshot, collectionSnapshot) {
+				readers = append(readers, exprAtmSnapshots...)
+			}
+		}
 
 		for exprSnapshot, expr := range workingMem.expressionSnapshotMap {
-			if strings.Contains(exprSnapshot, varSnapshot) {
+			if containsAny(exprSnapshot, readers) {
 				workingMem.expressionVariableMap[variable] = append(workingMem.expressionVariableMap[variable], expr)
 			}
 		}
 		for exprAtmSnapshot, exprAtm := range workingMem.expressionAtomSnapshotMap {
-			if strings.Contains(exprAtmSnapshot, varSnapshot) {
+			if containsAny(exprAtmSnapshot, readers) {
 				workingMem.expressionAtomVariableMap[variable] = append(workingMem.expressionAtomVariableMap[variable], exprAtm)
 			}
 		}
 	}
 
+	workingMem.assignSlots()
 	workingMem.DebugContent()
+}
+
+// containsAny tells whether the snapshot contains any of the other snapshots.
+func containsAny(snapshot string, snapshots []string) bool {
+	for _, other := range snapshots {
+		if strings.Contains(snapshot, other) {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// assignSlots gives a slot in the frame to the registered nodes not having one yet.
+func (workingMem *WorkingMemory) assignSlots() {
+	for _, expr := range workingMem.expressionSnapshotMap {
+		if expr.slot == 0 {
+			workingMem.slots++
+			expr.slot = workingMem.slots
+		}
+	}
+	for _, exprAtm := range workingMem.expressionAtomSnapshotMap {
+		if exprAtm.slot == 0 {
+			workingMem.slots++
+			exprAtm.slot = workingMem.slots
+		}
+	}
+	for _, variable := range workingMem.variableSnapshotMap {
+		if variable.slot == 0 {
+			workingMem.slots++
+			variable.slot = workingMem.slots
+		}
+	}
+}
+
+// Instance creates a working memory sharing the registered nodes of this one, with an evaluation state of its own.
+func (workingMem *WorkingMemory) Instance() *WorkingMemory {
+	workingMem.shared.Store(true)
+	instance := &WorkingMemory{
+		Name:                      workingMem.Name,
+		Version:                   workingMem.Version,
+		expressionSnapshotMap:     workingMem.expressionSnapshotMap,
+		expressionAtomSnapshotMap: workingMem.expressionAtomSnapshotMap,
+		variableSnapshotMap:       workingMem.variableSnapshotMap,
+		expressionVariableMap:     workingMem.expressionVariableMap,
+		expressionAtomVariableMap: workingMem.expressionAtomVariableMap,
+		ID:                        unique.NewID(),
+		slots:                     workingMem.slots,
+		compiled:                  workingMem.compiled,
+	}
+	instance.shared.Store(true)
+	instance.frame.reset(instance.slots + 1)
+
+	return instance
+}
+
+// itemMemory creates a working memory evaluating the nodes in a frame of its own, for an item of a collection.
+// It sees the local variables and the tracer of this working memory, nil if this working memory is nil.
+func (workingMem *WorkingMemory) itemMemory() *WorkingMemory {
+	if workingMem == nil {
+
+		return nil
+	}
+
+	return &WorkingMemory{
+		Name:     workingMem.Name,
+		Version:  workingMem.Version,
+		ID:       workingMem.ID,
+		tracer:   workingMem.tracer,
+		compiled: workingMem.compiled,
+		outer:    workingMem,
+	}
+}
+
+// unshare copies the maps shared with the instances before they are changed.
+func (workingMem *WorkingMemory) unshare() {
+	if !workingMem.shared.Load() {
 
+		return
+	}
+	workingMem.expressionSnapshotMap = copyMap(workingMem.expressionSnapshotMap)
+	workingMem.expressionAtomSnapshotMap = copyMap(workingMem.expressionAtomSnapshotMap)
+	workingMem.variableSnapshotMap = copyMap(workingMem.variableSnapshotMap)
+	workingMem.shared.Store(false)
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	cp := make(map[K]V, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+
+	return cp
 }
 
 // AddExpression will add expression into its map if the expression signature is unique
@@ -281,6 +422,7 @@ func (workingMem *WorkingMemory) AddExpression(exp *Expression) *Expression {
 		return expr
 	}
 	AstLog.Tracef("%s : Added Expression Snapshot : %s", workingMem.ID, snapshot)
+	workingMem.unshare()
 	workingMem.expressionSnapshotMap[snapshot] = exp
 
 	return exp
@@ -296,6 +438,7 @@ func (workingMem *WorkingMemory) AddExpressionAtom(exp *ExpressionAtom) *Express
 		return expr
 	}
 	AstLog.Tracef("%s : Added ExpressionAtom Snapshot : %s", workingMem.ID, snapshot)
+	workingMem.unshare()
 	workingMem.expressionAtomSnapshotMap[snapshot] = exp
 
 	return exp
@@ -311,6 +454,7 @@ func (workingMem *WorkingMemory) AddVariable(vari *Variable) *Variable {
 		return v
 	}
 	AstLog.Tracef("%s : Added Variable Snapshot : %s", workingMem.ID, snapshot)
+	workingMem.unshare()
 	workingMem.variableSnapshotMap[snapshot] = vari
 
 	return vari
@@ -326,14 +470,15 @@ func (workingMem *WorkingMemory) Reset(name string) bool {
 			return workingMem.ResetVariable(vari)
 		}
 	}
+	workingMem.changedAll = true
 	for snap, expr := range workingMem.expressionSnapshotMap {
 		if strings.Contains(snap, name) || strings.Contains(expr.GrlText, name) {
-			expr.Evaluated = false
+			workingMem.state(expr.slot, expr).evaluated.Store(false)
 		}
 	}
 	for snap, expr := range workingMem.expressionAtomSnapshotMap {
 		if strings.Contains(snap, name) || strings.Contains(expr.GrlText, name) {
-			expr.Evaluated = false
+			workingMem.state(expr.slot, expr).evaluated.Store(false)
 		}
 	}
 
@@ -347,11 +492,54 @@ func (workingMem *WorkingMemory) ResetVariable(variable *Variable) bool {
 	if AstLog.Level == logger.TraceLevel {
 		AstLog.Tracef("%s : Resetting %s", workingMem.ID, variable.GetSnapshot())
 	}
+	workingMem.changedVariables = append(workingMem.changedVariables, variable)
+
+	return workingMem.resetExpressionsOf(variable)
+}
+
+// ResetFact will reset the evaluated status of the expressions reading the fact, e.g. after the fact was added, changed
+// or removed from outside of the rules. Returns true if any expression was reset, false if no expression reads the fact.
+func (workingMem *WorkingMemory) ResetFact(name string) bool {
+	variable, ok := workingMem.variableSnapshotMap[(&Variable{Name: name}).GetSnapshot()]
+	if !ok {
+
+		return false
+	}
+
+	return workingMem.ResetVariable(variable)
+}
+
+// ResetFunctionCalls will reset the evaluated status of the expressions calling a function or a method, as their
+// results may change over time, e.g. Now(). Returns true if any expression was reset, false if otherwise.
+func (workingMem *WorkingMemory) ResetFunctionCalls() bool {
+	functionCall := FUNCTIONCALL + "(n:"
+	reseted := false
+	for snap, expr := range workingMem.expressionSnapshotMap {
+		if strings.Contains(snap, functionCall) {
+			workingMem.state(expr.slot, expr).evaluated.Store(false)
+			reseted = true
+		}
+	}
+	for snap, expr := range workingMem.expressionAtomSnapshotMap {
+		if strings.Contains(snap, functionCall) {
+			workingMem.state(expr.slot, expr).evaluated.Store(false)
+			reseted = true
+		}
+	}
+	if reseted {
+		workingMem.changedAll = true
+	}
+
+	return reseted
+}
+
+// resetExpressionsOf resets the evaluated status of the expressions containing the variable, without recording the change.
+func (workingMem *WorkingMemory) resetExpressionsOf(variable *Variable) bool {
 	reseted := false
 	if arr, ok := workingMem.expressionVariableMap[variable]; ok {
 		for _, expr := range arr {
 			AstLog.Tracef("------ reset expr : %s", expr.GrlText)
-			expr.Evaluated = false
+			workingMem.state(expr.slot, expr).evaluated.Store(false)
 			reseted = true
 		}
 	} else {
@@ -360,7 +548,7 @@ func (workingMem *WorkingMemory) ResetVariable(variable *Variable) bool {
 	if arr, ok := workingMem.expressionAtomVariableMap[variable]; ok {
 		for _, expr := range arr {
 			AstLog.Tracef("------ reset expr atm : %s", expr.GrlText)
-			expr.Evaluated = false
+			workingMem.state(expr.slot, expr).evaluated.Store(false)
 			reseted = true
 		}
 	} else {
@@ -370,18 +558,190 @@ func (workingMem *WorkingMemory) ResetVariable(variable *Variable) bool {
 	return reseted
 }
 
-// ResetAll sets all expression evaluated status to false.
-// Returns true if any expression was reset, false if otherwise
+// ResetAll forgets the values of all the expressions, expression atoms and variables.
+// Returns true if any expression was reset, false if otherwise.
+// It first waits for the goroutines started by Go, as they may still be evaluating expressions.
 func (workingMem *WorkingMemory) ResetAll() bool {
-	reseted := false
-	for _, expr := range workingMem.expressionSnapshotMap {
-		expr.Evaluated = false
-		reseted = true
+	workingMem.background.Wait()
+	workingMem.changedAll = true
+	workingMem.frame.reset(workingMem.slots + 1)
+
+	return len(workingMem.expressionSnapshotMap) > 0 || len(workingMem.expressionAtomSnapshotMap) > 0
+}
+
+// IsEvaluated tells whether the value of the Expression or ExpressionAtom is kept, so it is not evaluated again
+// until the variables it reads are changed.
+func (workingMem *WorkingMemory) IsEvaluated(node Node) bool {
+	switch n := node.(type) {
+	case *Expression:
+
+		return workingMem.state(n.slot, n).evaluated.Load()
+	case *ExpressionAtom:
+
+		return workingMem.state(n.slot, n).evaluated.Load()
 	}
-	for _, expr := range workingMem.expressionAtomSnapshotMap {
-		expr.Evaluated = false
-		reseted = true
+
+	return false
+}
+
+// ValueOf returns the value the Expression, ExpressionAtom or Variable is evaluated to in this working memory,
+// the zero value if it is not evaluated. It must not be called while the node is evaluated, e.g. by an EvaluationTracer.
+func (workingMem *WorkingMemory) ValueOf(node Node) reflect.Value {
+	state, ok := workingMem.stateOf(node)
+	if !ok {
+
+		return reflect.Value{}
 	}
+	state.lock.Lock()
+	defer state.lock.Unlock()
 
-	return reseted
+	return state.value
+}
+
+// ValueNodeOf returns the value node the ExpressionAtom or Variable is evaluated to in this working memory,
+// nil if it is not evaluated. It must not be called while the node is evaluated, e.g. by an EvaluationTracer.
+func (workingMem *WorkingMemory) ValueNodeOf(node Node) model.ValueNode {
+	state, ok := workingMem.stateOf(node)
+	if !ok {
+
+		return nil
+	}
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	return state.valueNode
+}
+
+// stateOf returns the evaluation state of the Expression, ExpressionAtom or Variable.
+func (workingMem *WorkingMemory) stateOf(node Node) (*nodeState, bool) {
+	switch n := node.(type) {
+	case *Expression:
+
+		return workingMem.state(n.slot, n), true
+	case *ExpressionAtom:
+
+		return workingMem.state(n.slot, n), true
+	case *Variable:
+
+		return workingMem.state(n.slot, n), true
+	}
+
+	return nil, false
+}
+
+// state returns the evaluation state of the node in the frame. A nil working memory keeps no state,
+// the returned state is then only seen by the caller.
+func (workingMem *WorkingMemory) state(slot int, node Node) *nodeState {
+	if workingMem == nil {
+
+		return &nodeState{}
+	}
+
+	return workingMem.frame.state(slot, node)
+}
+
+// RetractRule will retract the rule entry from the execution, until ClearRetracted is called.
+func (workingMem *WorkingMemory) RetractRule(ruleName string) {
+	workingMem.frame.retract(ruleName)
+}
+
+// IsRuleRetracted checks if the rule entry is retracted from the execution.
+func (workingMem *WorkingMemory) IsRuleRetracted(ruleName string) bool {
+	if workingMem == nil {
+
+		return false
+	}
+
+	return workingMem.frame.isRetracted(ruleName)
+}
+
+// ClearRetracted will restore all the retracted rule entries.
+func (workingMem *WorkingMemory) ClearRetracted() {
+	workingMem.frame.clearRetracted()
+}
+
+// GetLocal returns the value of a local variable declared with let by the rule entry, if it is bound.
+func (workingMem *WorkingMemory) GetLocal(ruleName, name string) (model.ValueNode, bool) {
+	if workingMem.outer != nil {
+
+		return workingMem.outer.GetLocal(ruleName, name)
+	}
+	workingMem.localsLock.RLock()
+	defer workingMem.localsLock.RUnlock()
+	valueNode, ok := workingMem.locals[ruleName][name]
+
+	return valueNode, ok
+}
+
+// ClearLocals will unbind all the local variables, it is called at the beginning of every execution.
+func (workingMem *WorkingMemory) ClearLocals() {
+	workingMem.localsLock.Lock()
+	defer workingMem.localsLock.Unlock()
+	workingMem.locals = nil
+}
+
+// bindLocal sets the value of a local variable and resets the expressions using it. A local variable is only seen
+// by the rule entry declaring it, so its change is not recorded for the other rule entries.
+func (workingMem *WorkingMemory) bindLocal(variable *Variable, value reflect.Value) {
+	workingMem.localsLock.Lock()
+	if workingMem.locals == nil {
+		workingMem.locals = make(map[string]map[string]model.ValueNode)
+	}
+	if workingMem.locals[variable.LocalOf] == nil {
+		workingMem.locals[variable.LocalOf] = make(map[string]model.ValueNode)
+	}
+	workingMem.locals[variable.LocalOf][variable.Name] = model.NewGoValueNode(value, variable.Name)
+	workingMem.localsLock.Unlock()
+	workingMem.resetExpressionsOf(variable)
+}
+
+// GetExpressionsOfVariable returns all expressions that contain the specified variable in their signature.
+// Those are the expressions that get reset when the variable is reset.
+func (workingMem *WorkingMemory) GetExpressionsOfVariable(variable *Variable) []*Expression {
+
+	return workingMem.expressionVariableMap[variable]
+}
+
+// TakeChanges returns the variables that have been reset since the last call to TakeChanges,
+// and whether the expressions were reset without knowing the variable (eg. by ResetAll).
+// The recorded changes are cleared afterward.
+func (workingMem *WorkingMemory) TakeChanges() (variables []*Variable, all bool) {
+	variables = workingMem.changedVariables
+	all = workingMem.changedAll
+	workingMem.changedVariables = nil
+	workingMem.changedAll = false
+
+	return variables, all
+}
+
+// SetTracer attach an EvaluationTracer to this working memory. Set it to nil to stop tracing.
+func (workingMem *WorkingMemory) SetTracer(tracer EvaluationTracer) {
+	workingMem.tracer = tracer
+}
+
+// GetTracer returns the EvaluationTracer attached to this working memory, nil if there is none.
+func (workingMem *WorkingMemory) GetTracer() EvaluationTracer {
+	if workingMem == nil {
+
+		return nil
+	}
+
+	return workingMem.tracer
+}
+
+// runsCompiled tells whether the compiled closures of the AST are run, rather than interpreting it.
+// The traced executions are always interpreted.
+func (workingMem *WorkingMemory) runsCompiled() bool {
+
+	return workingMem != nil && workingMem.compiled && workingMem.tracer == nil
+}
+
+// Go runs fn in a new goroutine that evaluates expressions of this working memory. It is used to stop waiting for
+// an evaluation that takes too long, ResetAll then waits for fn to return before resetting the expressions.
+func (workingMem *WorkingMemory) Go(fn func()) {
+	workingMem.background.Add(1)
+	go func() {
+		defer workingMem.background.Done()
+		fn()
+	}()
 }
@@ -63,3 +63,41 @@ func TestWorkingMemory_Add(t *testing.T) {
 	assert.False(t, wm.Reset("some.variable.z"))
 	assert.True(t, wm.ResetAll())
 }
+
+func TestWorkingMemory_TakeChanges(t *testing.T) {
+	a := &Variable{GrlText: "a", Name: "a"}
+	b := &Variable{GrlText: "b", Name: "b"}
+	expr := &Expression{
+		AstID:           "abc",
+		LeftExpression:  &Expression{ExpressionAtom: &ExpressionAtom{Variable: a}},
+		RightExpression: &Expression{ExpressionAtom: &ExpressionAtom{Variable: a}},
+		Operator:        OpMul,
+	}
+	wm := NewWorkingMemory("T", "1")
+	a = wm.AddVariable(a)
+	b = wm.AddVariable(b)
+	expr = wm.AddExpression(expr)
+	wm.IndexVariables()
+
+	assert.Equal(t, []*Expression{expr}, wm.GetExpressionsOfVariable(a))
+	assert.Empty(t, wm.GetExpressionsOfVariable(b))
+
+	vars, all := wm.TakeChanges()
+	assert.Empty(t, vars)
+	assert.False(t, all)
+
+	wm.ResetVariable(a)
+	wm.Reset("b")
+	vars, all = wm.TakeChanges()
+	assert.Equal(t, []*Variable{a, b}, vars)
+	assert.False(t, all)
+
+	wm.ResetAll()
+	vars, all = wm.TakeChanges()
+	assert.Empty(t, vars)
+	assert.True(t, all)
+
+	vars, all = wm.TakeChanges()
+	assert.Empty(t, vars)
+	assert.False(t, all)
+}
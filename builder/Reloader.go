@@ -0,0 +1,401 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+// NewReloader creates new Reloader instance, rebuilding the knowledge base identified by its name and version
+// in the library from the bundle.
+func NewReloader(KnowledgeLibrary *ast.KnowledgeLibrary, name, version string, bundle pkg.ResourceBundle) *Reloader {
+
+	return &Reloader{
+		KnowledgeLibrary: KnowledgeLibrary,
+		Name:             name,
+		Version:          version,
+		Bundle:           bundle,
+	}
+}
+
+// Reloader rebuilds a knowledge base blueprint from its ResourceBundle, and swaps it into the KnowledgeLibrary
+// once it is valid. The executions of the instances created from the previous blueprint finish on it.
+// When the rebuild fails, the previous blueprint is kept. A Reloader is safe for concurrent use.
+type Reloader struct {
+	KnowledgeLibrary *ast.KnowledgeLibrary
+	Name             string
+	Version          string
+	Bundle           pkg.ResourceBundle
+	// Validator, if set, checks the rebuilt rules against the fact types, just like RuleBuilder.Validator.
+	// A rebuild having invalid rules is rejected.
+	Validator *Validator
+	// Check, if set, is called with the rebuilt blueprint before it is swapped in. A rebuild is rejected if it
+	// returns an error, e.g. when the rules fail to pass a test run.
+	Check func(knowledgeBase *ast.KnowledgeBase) error
+	// OnReload, if set, is called after every reload with the changes of the rule entries, or the error
+	// the rebuild is rejected with.
+	OnReload func(diff RuleDiff, err error)
+
+	lock sync.Mutex
+	// built is the blueprint swapped in by the last reload, and resources are the resources it is built from,
+	// in the order they are built.
+	built     *ast.KnowledgeBase
+	resources []builtResource
+}
+
+// builtResource is a resource built by a reload, identified by its path, and the rule entries built from it.
+type builtResource struct {
+	path      string
+	ruleNames []string
+}
+
+// RuleDiff tells which rule entries are changed by a reload, by name.
+type RuleDiff struct {
+	Added    []string
+	Modified []string
+	Removed  []string
+	// Resources are the changes of the resources the reload is made for, e.g. the ones received by ReloadOnChange,
+	// nil if they are not known.
+	Resources []pkg.ResourceChange
+}
+
+// IsEmpty tells whether no rule entry is changed.
+func (diff RuleDiff) IsEmpty() bool {
+
+	return len(diff.Added) == 0 && len(diff.Modified) == 0 && len(diff.Removed) == 0
+}
+
+// String will state the changed rule entries, and the changed resources if they are known.
+func (diff RuleDiff) String() string {
+	str := fmt.Sprintf("added [%s] modified [%s] removed [%s]", strings.Join(diff.Added, ", "), strings.Join(diff.Modified, ", "), strings.Join(diff.Removed, ", "))
+	if len(diff.Resources) > 0 {
+		resources := make([]string, 0, len(diff.Resources))
+		for _, change := range diff.Resources {
+			resources = append(resources, change.String())
+		}
+		str = fmt.Sprintf("%s resources [%s]", str, strings.Join(resources, ", "))
+	}
+
+	return str
+}
+
+// Reload rebuilds the knowledge base from the bundle, and swaps it into the library if its rule entries are changed.
+// The rebuild is rejected if a resource fails to load or to build, if it has no rule entry, or if the Validator
+// or the Check reject it. The knowledge base is compiled if the previous blueprint is compiled.
+func (reloader *Reloader) Reload() (RuleDiff, error) {
+
+	return reloader.reloadFor(nil)
+}
+
+// reloadFor reloads the knowledge base, only the changed resources are built if they are known.
+func (reloader *Reloader) reloadFor(changes []pkg.ResourceChange) (RuleDiff, error) {
+	reloader.lock.Lock()
+	defer reloader.lock.Unlock()
+	diff, err := reloader.reload(changes)
+	diff.Resources = changes
+	if err != nil {
+		BuilderLog.Errorf("Reloading knowledge base %s:%s failed, the previous one is kept. got %s", reloader.Name, reloader.Version, err.Error())
+	} else if !diff.IsEmpty() {
+		BuilderLog.Debugf("Reloading knowledge base %s:%s success. %s", reloader.Name, reloader.Version, diff.String())
+	}
+	if reloader.OnReload != nil {
+		reloader.OnReload(diff, err)
+	}
+
+	return diff, err
+}
+
+// Run reloads the knowledge base every interval, until the context is done. The outcome of every reload
+// is reported to OnReload.
+func (reloader *Reloader) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+
+			return ctx.Err()
+		case <-ticker.C:
+			_, _ = reloader.Reload()
+		}
+	}
+}
+
+// ReloadOnChange reloads the knowledge base every time changes of its file resources are received, e.g. from
+// WatchingFileResourceBundle.Watch, until the channel is closed or the context is done. Only the added and modified
+// files are built, along with the ones extending their rule entries, into a clone of the blueprint swapped in by
+// the previous reload, the rule entries of the modified and deleted files are removed from it. The knowledge base
+// is rebuilt from all the resources of the bundle if the blueprint is not the one of the previous reload, e.g. it is
+// the first reload. The received changes are logged and reported to OnReload in the RuleDiff along with the outcome
+// of the reload.
+func (reloader *Reloader) ReloadOnChange(ctx context.Context, changes <-chan []pkg.ResourceChange) error {
+	for {
+		select {
+		case <-ctx.Done():
+
+			return ctx.Err()
+		case changed, ok := <-changes:
+			if !ok {
+
+				return nil
+			}
+			BuilderLog.Infof("Reloading knowledge base %s:%s, resources changed : %v", reloader.Name, reloader.Version, changed)
+			_, _ = reloader.reloadFor(changed)
+		}
+	}
+}
+
+func (reloader *Reloader) reload(changes []pkg.ResourceChange) (RuleDiff, error) {
+	previous, exist := reloader.KnowledgeLibrary.LookupKnowledgeBase(reloader.Name, reloader.Version)
+
+	// the rules are built into a library of their own, so the blueprint in use is not touched.
+	scratch := ast.NewKnowledgeLibrary()
+	scratch.FunctionRegistry = reloader.KnowledgeLibrary.FunctionRegistry
+	var built []builtResource
+	var err error
+	if len(changes) > 0 && exist && previous == reloader.built {
+		built, err = reloader.rebuild(scratch, previous, changes)
+	} else {
+		built, err = reloader.build(scratch)
+	}
+	if err != nil {
+
+		return RuleDiff{}, err
+	}
+	knowledgeBase := scratch.GetKnowledgeBase(reloader.Name, reloader.Version)
+	if countRuleEntries(knowledgeBase) == 0 {
+
+		return RuleDiff{}, fmt.Errorf("knowledge base %s version %s has no rule entry", reloader.Name, reloader.Version)
+	}
+
+	diff := diffRuleEntries(previous, knowledgeBase)
+	if exist && diff.IsEmpty() {
+		// the blueprint in use has the same rule entries, it is the one the next changes are built into.
+		reloader.built, reloader.resources = previous, built
+
+		return diff, nil
+	}
+	if exist && previous.IsCompiled() {
+		knowledgeBase.Compile()
+	}
+	if reloader.Check != nil {
+		err = reloader.Check(knowledgeBase)
+		if err != nil {
+
+			return RuleDiff{}, fmt.Errorf("knowledge base %s version %s is rejected. got %w", reloader.Name, reloader.Version, err)
+		}
+	}
+	reloader.KnowledgeLibrary.SwapKnowledgeBase(knowledgeBase)
+	reloader.built, reloader.resources = knowledgeBase, built
+
+	return diff, nil
+}
+
+// build builds all the resources of the bundle into the knowledge base of the library.
+func (reloader *Reloader) build(lib *ast.KnowledgeLibrary) ([]builtResource, error) {
+	resources, err := reloader.Bundle.Load()
+	if err != nil {
+
+		return nil, err
+	}
+	ruleBuilder := NewRuleBuilder(lib)
+	ruleBuilder.Validator = reloader.Validator
+	knowledgeBase := lib.GetKnowledgeBase(reloader.Name, reloader.Version)
+	built := make([]builtResource, 0, len(resources))
+	diagnostics := make(pkg.Diagnostics, 0)
+	for _, resource := range resources {
+		ruleNames, errs := reloader.buildResource(ruleBuilder, knowledgeBase, resource)
+		diagnostics = append(diagnostics, errs...)
+		built = append(built, builtResource{path: resourcePath(resource), ruleNames: ruleNames})
+	}
+	if diagnostics.HasError() {
+
+		return nil, diagnostics
+	}
+
+	return built, nil
+}
+
+// rebuild builds the changed file resources into a clone of the blueprint, in the library. The rule entries of the
+// modified and deleted resources are removed from the clone beforehand, as well as the ones of the resources
+// extending them, which are built again as they inherit the when scope of the removed rule entries.
+// The other resources are not built again.
+func (reloader *Reloader) rebuild(lib *ast.KnowledgeLibrary, blueprint *ast.KnowledgeBase, changes []pkg.ResourceChange) ([]builtResource, error) {
+	knowledgeBase, err := blueprint.Clone(pkg.NewCloneTable())
+	if err != nil {
+
+		return nil, err
+	}
+	lib.SwapKnowledgeBase(knowledgeBase)
+
+	changed := make(map[string]pkg.ResourceChangeKind, len(changes))
+	for _, change := range changes {
+		changed[change.Path] = change.Kind
+	}
+	removed := make(map[string]bool)
+	for grown := true; grown; {
+		grown = false
+		for _, resource := range reloader.resources {
+			if _, ok := changed[resource.path]; !ok {
+				if !extendsAny(blueprint, resource.ruleNames, removed) {
+
+					continue
+				}
+				changed[resource.path] = pkg.ResourceModified
+			}
+			for _, name := range resource.ruleNames {
+				if !removed[name] {
+					removed[name] = true
+					grown = true
+				}
+			}
+		}
+	}
+	for name := range removed {
+		knowledgeBase.RemoveRuleEntry(name)
+	}
+
+	ruleBuilder := NewRuleBuilder(lib)
+	ruleBuilder.Validator = reloader.Validator
+	built := make([]builtResource, 0, len(reloader.resources)+len(changes))
+	diagnostics := make(pkg.Diagnostics, 0)
+	buildFile := func(path string) {
+		ruleNames, errs := reloader.buildResource(ruleBuilder, knowledgeBase, pkg.NewFileResource(path))
+		diagnostics = append(diagnostics, errs...)
+		built = append(built, builtResource{path: path, ruleNames: ruleNames})
+	}
+	known := make(map[string]bool, len(reloader.resources))
+	for _, resource := range reloader.resources {
+		known[resource.path] = true
+		kind, ok := changed[resource.path]
+		switch {
+		case !ok:
+			built = append(built, resource)
+		case kind != pkg.ResourceDeleted:
+			buildFile(resource.path)
+		}
+	}
+	for _, change := range changes {
+		if !known[change.Path] && change.Kind != pkg.ResourceDeleted {
+			known[change.Path] = true
+			buildFile(change.Path)
+		}
+	}
+	if diagnostics.HasError() {
+
+		return nil, diagnostics
+	}
+
+	return built, nil
+}
+
+// buildResource builds the resource into the knowledge base, and returns the names of the rule entries built from it.
+func (reloader *Reloader) buildResource(ruleBuilder *RuleBuilder, knowledgeBase *ast.KnowledgeBase, resource pkg.Resource) ([]string, pkg.Diagnostics) {
+	existing := make(map[string]bool, len(knowledgeBase.RuleEntries))
+	for name := range knowledgeBase.RuleEntries {
+		existing[name] = true
+	}
+	_, err := ruleBuilder.buildRuleFromResource(reloader.Name, reloader.Version, resource)
+	if err != nil {
+
+		return nil, pkg.DiagnosticsOf(err, resource.String())
+	}
+	ruleNames := make([]string, 0)
+	for name, entry := range knowledgeBase.RuleEntries {
+		if !existing[name] && !entry.Deleted {
+			ruleNames = append(ruleNames, name)
+		}
+	}
+	sort.Strings(ruleNames)
+
+	return ruleNames, nil
+}
+
+// resourcePath identifies a resource by its path if it is a file, by its description otherwise.
+func resourcePath(resource pkg.Resource) string {
+	if file, ok := resource.(*pkg.FileResource); ok {
+
+		return file.Path
+	}
+
+	return resource.String()
+}
+
+// extendsAny tells whether one of the rule entries of the blueprint extends one of the removed rule entries.
+func extendsAny(blueprint *ast.KnowledgeBase, ruleNames []string, removed map[string]bool) bool {
+	for _, name := range ruleNames {
+		if entry, ok := blueprint.RuleEntries[name]; ok && removed[entry.Extends] {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// countRuleEntries counts the rule entries of the knowledge base that are not deleted.
+func countRuleEntries(knowledgeBase *ast.KnowledgeBase) int {
+	count := 0
+	for _, entry := range knowledgeBase.RuleEntries {
+		if !entry.Deleted {
+			count++
+		}
+	}
+
+	return count
+}
+
+// diffRuleEntries compares the rule entries of the knowledge bases, the previous one may be nil.
+func diffRuleEntries(previous, knowledgeBase *ast.KnowledgeBase) RuleDiff {
+	snapshots := make(map[string]string)
+	if previous != nil {
+		for name, entry := range previous.RuleEntries {
+			if !entry.Deleted {
+				snapshots[name] = entry.GetSnapshot()
+			}
+		}
+	}
+
+	diff := RuleDiff{}
+	for name, entry := range knowledgeBase.RuleEntries {
+		if entry.Deleted {
+
+			continue
+		}
+		snapshot, ok := snapshots[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+		} else if snapshot != entry.GetSnapshot() {
+			diff.Modified = append(diff.Modified, name)
+		}
+		delete(snapshots, name)
+	}
+	for name := range snapshots {
+		diff.Removed = append(diff.Removed, name)
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Modified)
+	sort.Strings(diff.Removed)
+
+	return diff
+}
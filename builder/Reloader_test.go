@@ -0,0 +1,235 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryBundle is a ResourceBundle whose rules are changed by the tests.
+type memoryBundle struct {
+	lock  sync.Mutex
+	rules []string
+	err   error
+}
+
+func (bundle *memoryBundle) set(err error, rules ...string) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+	bundle.rules = rules
+	bundle.err = err
+}
+
+func (bundle *memoryBundle) Load() ([]pkg.Resource, error) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+	if bundle.err != nil {
+
+		return nil, bundle.err
+	}
+	resources := make([]pkg.Resource, 0, len(bundle.rules))
+	for _, rule := range bundle.rules {
+		resources = append(resources, pkg.NewBytesResource([]byte(rule)))
+	}
+
+	return resources, nil
+}
+
+func (bundle *memoryBundle) MustLoad() []pkg.Resource {
+	resources, err := bundle.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	return resources
+}
+
+const (
+	reloadDiscount    = `rule Discount "discount" { when Order.Total >= 100 then Order.Discount = 10; Retract("Discount"); }`
+	reloadBigDiscount = `rule Discount "discount" { when Order.Total >= 100 then Order.Discount = 20; Retract("Discount"); }`
+	reloadGift        = `rule Gift "gift" { when Order.Total >= 500 then Order.Gift = true; Retract("Gift"); }`
+	reloadShipping    = `rule Shipping "free shipping" { when Order.Total >= 50 then Order.Shipping = 0; Retract("Shipping"); }`
+)
+
+func TestReloader(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	bundle := &memoryBundle{}
+	bundle.set(nil, reloadDiscount, reloadGift)
+	reports := make([]error, 0)
+	reloader := NewReloader(lib, "Reload", "0.1.1", bundle)
+	reloader.OnReload = func(diff RuleDiff, err error) {
+		reports = append(reports, err)
+	}
+
+	diff, err := reloader.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, RuleDiff{Added: []string{"Discount", "Gift"}}, diff)
+	first, ok := lib.LookupKnowledgeBase("Reload", "0.1.1")
+	assert.True(t, ok)
+	lib.GetKnowledgeBase("Reload", "0.1.1").Compile()
+	instance, err := lib.NewKnowledgeBaseInstance("Reload", "0.1.1")
+	assert.NoError(t, err)
+
+	// an unchanged bundle does not swap the blueprint.
+	diff, err = reloader.Reload()
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Same(t, first, lib.GetKnowledgeBase("Reload", "0.1.1"))
+
+	// a changed bundle swaps in a new blueprint, the instances of the previous one are not changed.
+	bundle.set(nil, reloadBigDiscount, reloadShipping)
+	diff, err = reloader.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, RuleDiff{Added: []string{"Shipping"}, Modified: []string{"Discount"}, Removed: []string{"Gift"}}, diff)
+	assert.Equal(t, "added [Shipping] modified [Discount] removed [Gift]", diff.String())
+	second := lib.GetKnowledgeBase("Reload", "0.1.1")
+	assert.NotSame(t, first, second)
+	assert.True(t, second.IsCompiled())
+	assert.True(t, instance.ContainsRuleEntry("Gift"))
+	assert.False(t, instance.ContainsRuleEntry("Shipping"))
+	instance, err = lib.NewKnowledgeBaseInstance("Reload", "0.1.1")
+	assert.NoError(t, err)
+	assert.True(t, instance.ContainsRuleEntry("Shipping"))
+
+	// a failed rebuild keeps the previous blueprint.
+	failures := []func(){
+		func() { bundle.set(errors.New("bundle unavailable"), reloadDiscount) },
+		func() { bundle.set(nil, reloadDiscount, `rule Broken "broken" { when then }`) },
+		func() { bundle.set(nil) },
+		func() {
+			bundle.set(nil, reloadDiscount)
+			reloader.Check = func(knowledgeBase *ast.KnowledgeBase) error {
+
+				return errors.New("discount is mandatory with shipping")
+			}
+		},
+	}
+	for _, failure := range failures {
+		failure()
+		diff, err = reloader.Reload()
+		assert.Error(t, err)
+		assert.True(t, diff.IsEmpty())
+		assert.Same(t, second, lib.GetKnowledgeBase("Reload", "0.1.1"))
+	}
+	assert.ErrorContains(t, err, "knowledge base Reload version 0.1.1 is rejected")
+	assert.Len(t, reports, 7)
+	assert.NoError(t, reports[2])
+	assert.Error(t, reports[3])
+}
+
+func TestReloader_Run(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	bundle := &memoryBundle{}
+	bundle.set(nil, reloadDiscount)
+	reloader := NewReloader(lib, "Reload", "0.1.1", bundle)
+	_, err := reloader.Reload()
+	assert.NoError(t, err)
+
+	reloaded := make(chan RuleDiff, 1)
+	reloader.OnReload = func(diff RuleDiff, err error) {
+		if !diff.IsEmpty() {
+			reloaded <- diff
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- reloader.Run(ctx, time.Millisecond)
+	}()
+	bundle.set(nil, reloadDiscount, reloadGift)
+	assert.Equal(t, RuleDiff{Added: []string{"Gift"}}, <-reloaded)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.True(t, lib.GetKnowledgeBase("Reload", "0.1.1").ContainsRuleEntry("Gift"))
+}
+
+func TestReloader_ReloadOnChange(t *testing.T) {
+	dir, err := filepath.Abs(t.TempDir())
+	assert.NoError(t, err)
+	writeRuleFile := func(name, rule string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(rule), 0o644))
+
+		return path
+	}
+	discount := writeRuleFile("discount.grl", reloadDiscount)
+	gift := writeRuleFile("gift.grl", reloadGift)
+	loyalty := writeRuleFile("loyalty.grl", `rule Loyalty extends Discount "loyalty" { when Order.Gift then Order.Discount = 15; Retract("Loyalty"); }`)
+
+	lib := ast.NewKnowledgeLibrary()
+	reloader := NewReloader(lib, "Reload", "0.1.1", pkg.NewFileResourceBundle(dir, dir+"/**/*.grl"))
+	reloaded := make(chan RuleDiff)
+	reloader.OnReload = func(diff RuleDiff, err error) {
+		assert.NoError(t, err)
+		reloaded <- diff
+	}
+	changes := make(chan []pkg.ResourceChange)
+	done := make(chan error)
+	go func() {
+		done <- reloader.ReloadOnChange(context.Background(), changes)
+	}()
+
+	// the first reload builds all the resources of the bundle.
+	added := []pkg.ResourceChange{{Kind: pkg.ResourceAdded, Path: discount}}
+	changes <- added
+	assert.Equal(t, RuleDiff{Added: []string{"Discount", "Gift", "Loyalty"}, Resources: added}, <-reloaded)
+
+	// only the modified resource, and the one extending its rule, are built again: the gift file is not parsed.
+	writeRuleFile("discount.grl", `rule Discount "discount" { when Order.Total >= 200 then Order.Discount = 10; Retract("Discount"); }`)
+	writeRuleFile("gift.grl", `rule Present "present" { when Order.Total >= 500 then Order.Gift = true; Retract("Present"); }`)
+	modified := []pkg.ResourceChange{{Kind: pkg.ResourceModified, Path: discount}}
+	changes <- modified
+	assert.Equal(t, RuleDiff{Modified: []string{"Discount", "Loyalty"}, Resources: modified}, <-reloaded)
+	knowledgeBase := lib.GetKnowledgeBase("Reload", "0.1.1")
+	assert.True(t, knowledgeBase.ContainsRuleEntry("Gift"))
+	assert.False(t, knowledgeBase.ContainsRuleEntry("Present"))
+	assert.Equal(t, "(Order.Total>=200)&&(Order.Gift)", knowledgeBase.RuleEntries["Loyalty"].WhenScope.Expression.GrlText)
+
+	// the rule entries of a deleted resource are removed, the added resources are built.
+	assert.NoError(t, os.Remove(gift))
+	shipping := writeRuleFile("shipping.grl", reloadShipping)
+	changed := []pkg.ResourceChange{{Kind: pkg.ResourceDeleted, Path: gift}, {Kind: pkg.ResourceAdded, Path: shipping}}
+	changes <- changed
+	diff := <-reloaded
+	assert.Equal(t, RuleDiff{Added: []string{"Shipping"}, Removed: []string{"Gift"}, Resources: changed}, diff)
+	assert.Equal(t, fmt.Sprintf("added [Shipping] modified [] removed [Gift] resources [%s deleted, %s added]", gift, shipping), diff.String())
+
+	// a failed rebuild keeps the previous blueprint, the next changes are built into it.
+	knowledgeBase = lib.GetKnowledgeBase("Reload", "0.1.1")
+	reloader.OnReload = func(diff RuleDiff, err error) {
+		reloaded <- diff
+	}
+	writeRuleFile("loyalty.grl", `rule Loyalty extends Discount "loyalty" { when then }`)
+	changes <- []pkg.ResourceChange{{Kind: pkg.ResourceModified, Path: loyalty}}
+	assert.True(t, (<-reloaded).IsEmpty())
+	assert.Same(t, knowledgeBase, lib.GetKnowledgeBase("Reload", "0.1.1"))
+	assert.NoError(t, os.Remove(loyalty))
+	removed := []pkg.ResourceChange{{Kind: pkg.ResourceDeleted, Path: loyalty}}
+	changes <- removed
+	assert.Equal(t, RuleDiff{Removed: []string{"Loyalty"}, Resources: removed}, <-reloaded)
+	close(changes)
+	assert.NoError(t, <-done)
+}
@@ -15,12 +15,14 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
 	"github.com/hyperjumptech/grule-rule-engine/ast"
 	"github.com/hyperjumptech/grule-rule-engine/logger"
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/zap"
+	"sort"
 	"time"
 
 	"github.com/antlr4-go/antlr/v4"
@@ -83,16 +85,16 @@ func NewRuleBuilder(KnowledgeLibrary *ast.KnowledgeLibrary) *RuleBuilder {
 // RuleBuilder builds rule from GRL script into contained KnowledgeBase
 type RuleBuilder struct {
 	KnowledgeLibrary *ast.KnowledgeLibrary
+	// Validator, if set, checks the loaded rules against the fact types. Rules failing the validation
+	// are removed from the knowledge base and their problems are returned as errors.
+	Validator *Validator
 }
 
 // MustBuildRuleFromResources is similar to BuildRuleFromResources, with the difference is, it will panic if rule script contains error.
 func (builder *RuleBuilder) MustBuildRuleFromResources(name, version string, resource []pkg.Resource) {
-	for _, v := range resource {
-		err := builder.BuildRuleFromResource(name, version, v)
-		if err != nil {
+	if err := builder.BuildRuleFromResources(name, version, resource); err != nil {
 
-			panic(err)
-		}
+		panic(err)
 	}
 }
 
@@ -120,21 +122,43 @@ func (builder *RuleBuilder) MustBuildRulesFromBundle(name, version string, bundl
 	builder.MustBuildRuleFromResources(name, version, bundle.MustLoad())
 }
 
-// BuildRuleFromResources will load rules from multiple resources. It will return an error if it encounter an error on the first script it found.
+// BuildRuleFromResources will load rules from multiple resources. All the resources are loaded even if some of them
+// contain errors, the returned error is then a pkg.Diagnostics with the problems found in all the resources.
 func (builder *RuleBuilder) BuildRuleFromResources(name, version string, resource []pkg.Resource) error {
+	_, err := builder.BuildRuleFromResourcesWithDiagnostics(name, version, resource)
+
+	return err
+}
+
+// BuildRuleFromResourcesWithDiagnostics is similar to BuildRuleFromResources, but it also returns the problems
+// found in all the resources when the build succeeds, which are then warnings.
+func (builder *RuleBuilder) BuildRuleFromResourcesWithDiagnostics(name, version string, resource []pkg.Resource) (pkg.Diagnostics, error) {
+	diagnostics := make(pkg.Diagnostics, 0)
 	for _, v := range resource {
-		err := builder.BuildRuleFromResource(name, version, v)
+		warnings, err := builder.buildRuleFromResource(name, version, v)
 		if err != nil {
-
-			return err
+			diagnostics = append(diagnostics, pkg.DiagnosticsOf(err, v.String())...)
+		} else {
+			diagnostics = append(diagnostics, warnings...)
 		}
 	}
+	if diagnostics.HasError() {
+
+		return diagnostics, diagnostics
+	}
 
-	return nil
+	return diagnostics, nil
 }
 
 // BuildRuleFromResource will load rules from a single resource. It will return an error if it encounter an error on the specified resource.
 func (builder *RuleBuilder) BuildRuleFromResource(name, version string, resource pkg.Resource) error {
+	_, err := builder.buildRuleFromResource(name, version, resource)
+
+	return err
+}
+
+// buildRuleFromResource will load rules from a single resource, and return the warnings found if it succeeds.
+func (builder *RuleBuilder) buildRuleFromResource(name, version string, resource pkg.Resource) (pkg.Diagnostics, error) {
 	// save the starting time, we need to see the loading time in debug log
 	startTime := time.Now()
 
@@ -142,7 +166,7 @@ func (builder *RuleBuilder) BuildRuleFromResource(name, version string, resource
 	data, err := resource.Load()
 	if err != nil {
 
-		return err
+		return nil, err
 	}
 
 	// Immediately parse the loaded resource
@@ -150,7 +174,8 @@ func (builder *RuleBuilder) BuildRuleFromResource(name, version string, resource
 	lexer := parser.Newgrulev3Lexer(is)
 
 	errReporter := &pkg.GruleErrorReporter{
-		Errors: make([]error, 0),
+		Errors:   make([]error, 0),
+		Resource: resource.String(),
 	}
 
 	lexer.RemoveErrorListeners()
@@ -161,7 +186,7 @@ func (builder *RuleBuilder) BuildRuleFromResource(name, version string, resource
 	knowledgeBase := builder.KnowledgeLibrary.GetKnowledgeBase(name, version)
 	if knowledgeBase == nil {
 
-		return fmt.Errorf("KnowledgeBase %s:%s is not in this library", name, version)
+		return nil, fmt.Errorf("KnowledgeBase %s:%s is not in this library", name, version)
 	}
 
 	listener := antlr2.NewGruleV3ParserListener(knowledgeBase, errReporter)
@@ -177,11 +202,16 @@ func (builder *RuleBuilder) BuildRuleFromResource(name, version string, resource
 	grl := listener.Grl
 	for _, ruleEntry := range grl.RuleEntries {
 		err := knowledgeBase.AddRuleEntry(ruleEntry)
-		if err != nil && err.Error() != "rule entry TestNoDesc already exist" {
+		var existErr *ast.RuleEntryExistError
+		if err != nil && !errors.As(err, &existErr) {
 			BuilderLog.Tracef("warning while adding rule entry : %s. got %s, possibly already added by antlr listener", ruleEntry.RuleName, err.Error())
 		}
 	}
 
+	if builder.Validator != nil && !errReporter.HasError() {
+		builder.validate(knowledgeBase, grl, errReporter)
+	}
+
 	knowledgeBase.WorkingMemory.IndexVariables()
 
 	// Get the loading duration.
@@ -193,10 +223,38 @@ func (builder *RuleBuilder) BuildRuleFromResource(name, version string, resource
 			BuilderLog.Errorf("%d : %s", i, errr.Error())
 		}
 
-		return errReporter
+		return nil, errReporter
+	}
+	warnings := errReporter.Diagnostics.Warnings()
+	for _, warning := range warnings {
+		BuilderLog.Warnf("GRL warning. got %s", warning.Error())
 	}
 
 	BuilderLog.Debugf("Loading rule resource : %s success. Time taken %d ms", resource.String(), dur.Nanoseconds()/1e6)
 
-	return nil
+	return warnings, nil
+}
+
+// validate checks the rule entries loaded from a resource, removing the invalid ones from the knowledge base.
+// The rule entries having only warnings are kept.
+func (builder *RuleBuilder) validate(knowledgeBase *ast.KnowledgeBase, grl *ast.Grl, errReporter *pkg.GruleErrorReporter) {
+	names := make([]string, 0, len(grl.RuleEntries))
+	for name := range grl.RuleEntries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		diagnostics := builder.Validator.ValidateRuleEntry(grl.RuleEntries[name])
+		if len(diagnostics) == 0 {
+
+			continue
+		}
+		for _, diagnostic := range diagnostics {
+			errReporter.AddDiagnostic(diagnostic)
+		}
+		if diagnostics.HasError() {
+			knowledgeBase.RemoveRuleEntry(name)
+		}
+	}
 }
@@ -15,6 +15,8 @@
 package builder
 
 import (
+	"errors"
+
 	"github.com/hyperjumptech/grule-rule-engine/ast"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
 	"github.com/stretchr/testify/assert"
@@ -49,3 +51,71 @@ Then
 
 	assert.Equal(t, re.GetSnapshot(), reClone.GetSnapshot())
 }
+
+func TestGrlSyntaxError(t *testing.T) {
+	GRL := `rule SyntaxError "missing then" {
+	when
+		Fact.A == 1
+		Fact.B = 2;
+}`
+	lib := ast.NewKnowledgeLibrary()
+	ruleBuilder := NewRuleBuilder(lib)
+	resource := pkg.NewBytesResource([]byte(GRL))
+	err := ruleBuilder.BuildRuleFromResource("SyntaxError", "0.1.1", resource)
+	var syntaxErr *pkg.GrlSyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, resource.String(), syntaxErr.Resource)
+	assert.Equal(t, 4, syntaxErr.Line)
+	assert.Equal(t, 2, syntaxErr.Column)
+}
+
+func TestBuildRuleFromResourcesDiagnostics(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	ruleBuilder := NewRuleBuilder(lib)
+	missingThen := pkg.NewBytesResource([]byte("rule B \"b\" {\n\twhen\n\t\tFact.A == 1\n\t\tFact.B = 2;\n}\n"))
+	duplicate := pkg.NewBytesResource([]byte("rule A \"dup\" {\n\twhen\n\t\tFact.A == 1 #\n\tthen\n\t\tFact.B = 2;\n}\n"))
+	missingFile := pkg.NewFileResource("/nonexistent/rules.grl")
+	err := ruleBuilder.BuildRuleFromResources("Diagnostics", "0.1.1", []pkg.Resource{
+		pkg.NewBytesResource([]byte("rule A \"a\" {\n\twhen\n\t\tFact.A == 1\n\tthen\n\t\tFact.B = 2;\n}\n")),
+		missingThen,
+		duplicate,
+		missingFile,
+	})
+	var diagnostics pkg.Diagnostics
+	assert.True(t, errors.As(err, &diagnostics))
+	assert.Len(t, diagnostics, 4)
+
+	assert.Equal(t, pkg.Diagnostic{
+		Resource:     missingThen.String(),
+		StartLine:    4,
+		StartColumn:  2,
+		EndLine:      4,
+		EndColumn:    6,
+		Severity:     pkg.SeverityError,
+		Code:         pkg.CodeSyntaxError,
+		Message:      "missing THEN at 'Fact'",
+		SuggestedFix: "insert THEN before 'Fact'",
+		Err:          diagnostics[0].Err,
+	}, *diagnostics[0])
+	assert.Equal(t, missingThen.String()+":4:3: error[syntax]: missing THEN at 'Fact' (insert THEN before 'Fact')", diagnostics[0].Error())
+
+	assert.Equal(t, duplicate.String(), diagnostics[1].Resource)
+	assert.Equal(t, pkg.CodeLexerError, diagnostics[1].Code)
+	assert.Equal(t, 3, diagnostics[1].StartLine)
+	assert.Equal(t, 14, diagnostics[1].StartColumn)
+
+	assert.Equal(t, pkg.CodeSemanticError, diagnostics[2].Code)
+	assert.Equal(t, "rule entry A already exist", diagnostics[2].Message)
+	assert.Equal(t, 1, diagnostics[2].StartLine)
+	assert.Equal(t, 5, diagnostics[2].StartColumn)
+	var existErr *ast.RuleEntryExistError
+	assert.True(t, errors.As(diagnostics[2], &existErr))
+
+	assert.Equal(t, missingFile.String(), diagnostics[3].Resource)
+	assert.Equal(t, pkg.CodeResourceError, diagnostics[3].Code)
+	assert.Equal(t, 0, diagnostics[3].StartLine)
+
+	var syntaxErr *pkg.GrlSyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, missingThen.String(), syntaxErr.Resource)
+}
@@ -0,0 +1,154 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+// NewValidator creates a new Validator. The built-in functions are always known to the validator
+// as the DEFUNC fact, just like the engine adds them into every data context.
+func NewValidator() *Validator {
+
+	return &Validator{
+		TypeChecker: ast.NewTypeChecker(),
+	}
+}
+
+// Validator checks rule entries against the types of the facts they are going to be executed with.
+// It reports unknown facts and fields, method calls with the wrong number of arguments, comparisons
+// between incompatible types and assignments to unexported fields, so a faulty rule can be rejected
+// when it is built instead of failing while it is executed. The real numbers truncated to integers
+// are reported as warnings, which do not reject the rule.
+type Validator struct {
+	TypeChecker *ast.TypeChecker
+}
+
+// AddFact will register the type of the specified fact instance under the fact name used in the rules.
+func (v *Validator) AddFact(name string, fact interface{}) {
+	v.TypeChecker.AddFact(name, fact)
+}
+
+// AddFactType will register a Go type under the fact name used in the rules.
+func (v *Validator) AddFactType(name string, typ reflect.Type) {
+	v.TypeChecker.AddFactType(name, typ)
+}
+
+// AddJSONSchema will register a JSON fact, described by a JSON schema, under the fact name used in the rules.
+// Only the "type", "properties" and "items" keywords of the schema are used.
+func (v *Validator) AddJSONSchema(name string, schema []byte) error {
+
+	return v.TypeChecker.AddJSONSchema(name, schema)
+}
+
+// AddFunctionRegistry will make the functions of the registry, e.g. the KnowledgeLibrary's FunctionRegistry,
+// known to the validator.
+func (v *Validator) AddFunctionRegistry(registry *ast.FunctionRegistry) {
+	v.TypeChecker.AddFunctionRegistry(registry)
+}
+
+// Validate will check all the rule entries in the knowledge base, in the order of their names.
+func (v *Validator) Validate(knowledgeBase *ast.KnowledgeBase) pkg.Diagnostics {
+	names := make([]string, 0, len(knowledgeBase.RuleEntries))
+	for name := range knowledgeBase.RuleEntries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	diagnostics := make(pkg.Diagnostics, 0)
+	for _, name := range names {
+		diagnostics = append(diagnostics, v.ValidateRuleEntry(knowledgeBase.RuleEntries[name])...)
+	}
+
+	return diagnostics
+}
+
+// ValidateRuleEntry will check the when and then scope of a single rule entry.
+func (v *Validator) ValidateRuleEntry(ruleEntry *ast.RuleEntry) pkg.Diagnostics {
+	result := v.TypeChecker.CheckRuleEntry(ruleEntry)
+	diagnostics := make(pkg.Diagnostics, 0, len(result.Errors))
+	for _, typeErr := range result.Errors {
+		diagnostics = append(diagnostics, locate(&pkg.Diagnostic{
+			Severity: pkg.SeverityError,
+			Code:     pkg.CodeSemanticError,
+			Message:  fmt.Sprintf("rule %s : %s in \"%s\"", typeErr.RuleName, typeErr.Message, typeErr.GrlText),
+			Err: &ValidationError{
+				RuleName: typeErr.RuleName,
+				GrlText:  typeErr.GrlText,
+				Message:  typeErr.Message,
+				Err:      typeErr,
+			},
+		}, typeErr.Position, ruleEntry))
+	}
+	for _, conversion := range result.Conversions {
+		if conversion.From.IsReal() && conversion.To.IsNumber() && !conversion.To.IsReal() {
+			message := fmt.Sprintf("%s is truncated to %s", conversion.From, conversion.To)
+			diagnostics = append(diagnostics, locate(&pkg.Diagnostic{
+				Severity: pkg.SeverityWarning,
+				Code:     pkg.CodeSemanticError,
+				Message:  fmt.Sprintf("rule %s : %s in \"%s\"", conversion.RuleName, message, conversion.GrlText),
+				Err: &ValidationError{
+					RuleName: conversion.RuleName,
+					GrlText:  conversion.GrlText,
+					Message:  message,
+				},
+			}, conversion.Position, ruleEntry))
+		}
+	}
+
+	return diagnostics
+}
+
+// locate locates the diagnostic at the position of the problem in the rule entry. As the nodes are shared
+// between the rule entries, the problem may be positioned in another rule entry, or not be positioned at all,
+// the diagnostic is then located at the name of the rule entry.
+func locate(diagnostic *pkg.Diagnostic, position ast.Position, ruleEntry *ast.RuleEntry) *pkg.Diagnostic {
+	if !ruleEntry.Position.Contains(position) {
+		position = ruleEntry.NamePosition
+	}
+	diagnostic.StartLine = position.StartLine
+	diagnostic.StartColumn = position.StartColumn
+	diagnostic.EndLine = position.EndLine
+	diagnostic.EndColumn = position.EndColumn
+
+	return diagnostic
+}
+
+// ValidationError is a problem the Validator found in a rule entry.
+type ValidationError struct {
+	RuleName string
+	// GrlText is the part of the rule having the problem.
+	GrlText string
+	Message string
+	// Err is the *ast.TypeError describing the problem, nil for the warnings.
+	Err error
+}
+
+// Error returns the rule name and the description of the problem.
+func (e *ValidationError) Error() string {
+
+	return fmt.Sprintf("rule %s : %s", e.RuleName, e.Message)
+}
+
+// Unwrap returns the *ast.TypeError describing the problem.
+func (e *ValidationError) Unwrap() error {
+
+	return e.Err
+}
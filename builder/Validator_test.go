@@ -0,0 +1,358 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package builder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type ValidatedOrder struct {
+	Amount   float64
+	Quantity int
+	Customer string
+	Created  time.Time
+	Items    []*ValidatedItem
+	Labels   map[string]string
+	approved bool
+}
+
+type ValidatedItem struct {
+	Price float64
+}
+
+func (o *ValidatedOrder) Discount(percent float64) float64 {
+
+	return o.Amount * percent / 100
+}
+
+func (o *ValidatedOrder) Tag(labels ...string) {}
+
+func (o *ValidatedOrder) Split() (float64, float64) {
+
+	return o.Amount / 2, o.Amount / 2
+}
+
+const customerSchema = `{
+	"type": "object",
+	"properties": {
+		"Name": {"type": "string"},
+		"Age": {"type": "integer"},
+		"Tags": {"type": "array", "items": {"type": "string"}},
+		"Extra": {"type": "object"}
+	}
+}`
+
+func newTestValidator(t *testing.T) *Validator {
+	validator := NewValidator()
+	validator.AddFact("Order", &ValidatedOrder{})
+	err := validator.AddJSONSchema("Customer", []byte(customerSchema))
+	assert.NoError(t, err)
+
+	return validator
+}
+
+func validateGRL(t *testing.T, validator *Validator, grl string) pkg.Diagnostics {
+	lib := ast.NewKnowledgeLibrary()
+	err := NewRuleBuilder(lib).BuildRuleFromResource("Validator", "0.0.1", pkg.NewBytesResource([]byte(grl)))
+	assert.NoError(t, err)
+
+	return validator.Validate(lib.GetKnowledgeBase("Validator", "0.0.1"))
+}
+
+func TestValidator_ValidRules(t *testing.T) {
+	grl := `
+rule Valid "all valid" {
+	when
+		Order.Amount > 100 && Order.Quantity >= 2 && Order.Customer.HasPrefix("VIP") &&
+		Order.Items[0].Price < Order.Discount(10.0) && Order.Labels["kind"] == "retail" &&
+		Order.Created < Now() && Order.Customer.In("a", "b", "c") &&
+		Customer.Name == "John" && Customer.Age > 17 && Customer.Tags[0].ToLower() == "eu" &&
+		Customer.Extra.Anything != nil && Order.Items.Len() > 0
+	then
+		Order.Amount = Order.Amount - Order.Discount(10.0);
+		Order.Amount += 1;
+		Order.Quantity = Order.Quantity * 2;
+		Order.Customer = "VIP " + Order.Customer;
+		Order.Tag();
+		Order.Tag("a", "b");
+		Customer.Name = "Jane";
+		Retract("Valid");
+}`
+	diagnostics := validateGRL(t, newTestValidator(t), grl)
+	assert.Empty(t, diagnostics)
+}
+
+func TestValidator_InvalidRules(t *testing.T) {
+	testData := []struct {
+		grl     string
+		message string
+		// located is the part of the GRL the diagnostic is located at.
+		located string
+	}{
+		{`Order.Amont > 100`, "ValidatedOrder has no field named Amont", `Order.Amont`},
+		{`Order.Amount.Value > 100`, "float64 is not an object", `Order.Amount.Value`},
+		{`Unknown.Value > 100`, "unknown fact Unknown", `Unknown`},
+		{`Customer.Nam == "John"`, "has no property named Nam", `Customer.Nam`},
+		{`Order.Discount() > 10`, "function Discount requires 1 argument(s), got 0", `Discount()`},
+		{`Order.Customer.HasPrefix("a", "b")`, "function HasPrefix requires 1 argument(s), got 2", `HasPrefix("a", "b")`},
+		{`Order.Customer.Reverse() == "a"`, "function Reverse is not supported for string", `Reverse()`},
+		{`Order.Discount(10) > 1`, `argument 1 of function Discount is of type int64, not float64 in "Discount(10)"`, `Discount(10)`},
+		{`Order.Split() > 1`, "returns multiple values", `Split()`},
+		{`Order.Customer > 3`, "can not compare type string with int64", `Order.Customer > 3`},
+		{`Order.Amount == "100"`, "can not compare type float64 with string", `Order.Amount == "100"`},
+		{`Order.Created > 10`, "can not compare type time.Time with int64", `Order.Created > 10`},
+		{`Customer.Age > "17"`, "can not compare type float64 with string", `Customer.Age > "17"`},
+		{`Order.Amount && true`, "can not use type float64 in a logical operation", `Order.Amount && true`},
+		{`Order.Customer[0] == "a"`, "string is not an array nor map", `[0]`},
+		{`Order.Amount`, "when scope is of type float64, not a boolean", `Order.Amount`},
+		{`NoSuchFunction()`, "has no function named NoSuchFunction", `NoSuchFunction()`},
+		{`Order.Quantity % 1.5 == 1`, "in an integer operation", `Order.Quantity % 1.5`},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when ` + td.grl + ` then Retract("Invalid"); }`
+		diagnostics := validateGRL(t, newTestValidator(t), grl)
+		if assert.Len(t, diagnostics, 1, td.grl) {
+			assert.Contains(t, diagnostics[0].Message, td.message, td.grl)
+			assert.Equal(t, pkg.CodeSemanticError, diagnostics[0].Code)
+			var validationErr *ValidationError
+			assert.True(t, errors.As(diagnostics[0], &validationErr))
+			assert.Equal(t, "Invalid", validationErr.RuleName)
+			assert.Equal(t, 1, diagnostics[0].StartLine, td.grl)
+			assert.Equal(t, 1, diagnostics[0].EndLine, td.grl)
+			assert.Equal(t, td.located, grl[diagnostics[0].StartColumn:diagnostics[0].EndColumn], td.grl)
+		}
+	}
+}
+
+func TestValidator_InvalidAssignments(t *testing.T) {
+	testData := []struct {
+		grl     string
+		message string
+	}{
+		{`Order.approved = true;`, "can not assign to unexported field approved"},
+		{`Order.Quantity = "two";`, "can not assign type string to int"},
+		{`Order.Customer = 10;`, "can not assign type int64 to string"},
+		{`Order.Customer -= "a";`, "can not use type string and string in an arithmetic operation"},
+		{`Customer.Age = false;`, "can not assign type bool to float64"},
+		{`Order.Discount(1, 2);`, "function Discount requires 1 argument(s), got 2"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when true then ` + td.grl + ` }`
+		diagnostics := validateGRL(t, newTestValidator(t), grl)
+		if assert.Len(t, diagnostics, 1, td.grl) {
+			assert.Contains(t, diagnostics[0].Message, td.message, td.grl)
+		}
+	}
+}
+
+func TestValidator_RuleBuilder(t *testing.T) {
+	grl := `
+rule Valid "valid" {
+	when
+		Order.Amount > 100
+	then
+		Order.Amount = 100;
+}
+
+rule Invalid "invalid" {
+	when
+		Order.Amont > 100
+	then
+		Order.approved = true;
+}`
+	lib := ast.NewKnowledgeLibrary()
+	ruleBuilder := NewRuleBuilder(lib)
+	ruleBuilder.Validator = newTestValidator(t)
+	err := ruleBuilder.BuildRuleFromResources("Validator", "0.0.1", []pkg.Resource{pkg.NewBytesResource([]byte(grl))})
+	assert.Error(t, err)
+
+	var diagnostics pkg.Diagnostics
+	if assert.True(t, errors.As(err, &diagnostics)) {
+		assert.Len(t, diagnostics, 2)
+		for _, diagnostic := range diagnostics {
+			var validationErr *ValidationError
+			assert.True(t, errors.As(diagnostic, &validationErr))
+			assert.Equal(t, "Invalid", validationErr.RuleName)
+		}
+		// the diagnostics are located at the part of the rule having the problem.
+		assert.Equal(t, []int{11, 2, 11, 13}, []int{diagnostics[0].StartLine, diagnostics[0].StartColumn, diagnostics[0].EndLine, diagnostics[0].EndColumn})
+		assert.Equal(t, []int{13, 2, 13, 23}, []int{diagnostics[1].StartLine, diagnostics[1].StartColumn, diagnostics[1].EndLine, diagnostics[1].EndColumn})
+	}
+
+	kb := lib.GetKnowledgeBase("Validator", "0.0.1")
+	assert.True(t, kb.ContainsRuleEntry("Valid"))
+	assert.False(t, kb.ContainsRuleEntry("Invalid"))
+}
+
+func TestValidator_SharedNodes(t *testing.T) {
+	grl := `
+rule First "first" {
+	when
+		Order.Amont > 100
+	then
+		Retract("First");
+}
+
+rule Second "second" {
+	when
+		Order.Amont > 100
+	then
+		Retract("Second");
+}`
+	diagnostics := validateGRL(t, newTestValidator(t), grl)
+	if assert.Len(t, diagnostics, 2) {
+		assert.Contains(t, diagnostics[0].Message, "rule First")
+		assert.Equal(t, []int{4, 2, 4, 13}, []int{diagnostics[0].StartLine, diagnostics[0].StartColumn, diagnostics[0].EndLine, diagnostics[0].EndColumn})
+		// the when scope of Second is the node built for First, the diagnostic is located at the name of the rule.
+		assert.Contains(t, diagnostics[1].Message, "rule Second")
+		assert.Equal(t, []int{9, 5, 9, 11}, []int{diagnostics[1].StartLine, diagnostics[1].StartColumn, diagnostics[1].EndLine, diagnostics[1].EndColumn})
+	}
+}
+
+func TestValidator_Warnings(t *testing.T) {
+	grl := `
+rule Truncated "truncated" {
+	when
+		Order.Amount > 100
+	then
+		Order.Quantity = Order.Amount / 10;
+		Retract("Truncated");
+}`
+	lib := ast.NewKnowledgeLibrary()
+	ruleBuilder := NewRuleBuilder(lib)
+	ruleBuilder.Validator = newTestValidator(t)
+	diagnostics, err := ruleBuilder.BuildRuleFromResourcesWithDiagnostics("Validator", "0.0.1", []pkg.Resource{pkg.NewBytesResource([]byte(grl))})
+	assert.NoError(t, err)
+
+	// the warnings are returned by a successful build, the rule is kept.
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, pkg.SeverityWarning, diagnostics[0].Severity)
+		assert.Equal(t, `rule Truncated : float64 is truncated to int in "Order.Quantity=Order.Amount/10"`, diagnostics[0].Message)
+		var validationErr *ValidationError
+		assert.True(t, errors.As(diagnostics[0], &validationErr))
+		assert.Equal(t, "Truncated", validationErr.RuleName)
+		assert.Equal(t, []int{6, 2, 6, 36}, []int{diagnostics[0].StartLine, diagnostics[0].StartColumn, diagnostics[0].EndLine, diagnostics[0].EndColumn})
+		assert.Equal(t, `Byte array resources 126 bytes:6:3: warning[semantic]: rule Truncated : float64 is truncated to int in "Order.Quantity=Order.Amount/10"`, diagnostics[0].Error())
+	}
+	assert.Equal(t, diagnostics, diagnostics.Warnings())
+	assert.True(t, lib.GetKnowledgeBase("Validator", "0.0.1").ContainsRuleEntry("Truncated"))
+}
+
+func TestValidator_AddJSONSchema(t *testing.T) {
+	err := NewValidator().AddJSONSchema("Broken", []byte(`{"type": `))
+	assert.Error(t, err)
+}
+
+func TestValidator_FunctionRegistry(t *testing.T) {
+	registry := ast.NewFunctionRegistry()
+	registry.MustRegister("Geo.Distance", func(a, b float64) float64 { return b - a })
+	registry.MustRegister("Upper", func(text string) string { return text })
+	validator := newTestValidator(t)
+	validator.AddFunctionRegistry(registry)
+
+	grl := `rule Valid "valid" { when Geo.Distance(Order.Quantity, 2.5) > 1 && Upper(Order.Customer) == "A" then Retract("Valid"); }`
+	assert.Empty(t, validateGRL(t, validator, grl))
+
+	grl = `rule Invalid "invalid" { when Upper(Order.Amount) == "A" then Retract("Invalid"); }`
+	diagnostics := validateGRL(t, validator, grl)
+	if assert.Len(t, diagnostics, 1) {
+		assert.Contains(t, diagnostics[0].Message, "argument 1 of function Upper is of type float64, not string")
+	}
+}
+
+func TestValidator_CollectionExpression(t *testing.T) {
+	grl := `rule Valid "valid" { when exists(i in Order.Items : i.Price > 100) && sum(i in Order.Items : i.Price) > 1.5 && count(l in Order.Labels where l == "eu") > 0 then Retract("Valid"); }`
+	assert.Empty(t, validateGRL(t, newTestValidator(t), grl))
+
+	testData := []struct {
+		grl     string
+		message string
+	}{
+		{`exists(i in Order.Items : i.Cost > 1)`, "ValidatedItem has no field named Cost"},
+		{`count(i in Order.Items where i.Price) > 1`, "filter is of type float64, not a boolean"},
+		{`forall(i in Order.Items : i.Price)`, "predicate is of type float64, not a boolean"},
+		{`sum(l in Order.Labels) > 1`, "can not sum type string"},
+		{`max(i in Order.Items : i.Price) == "a"`, "can not compare type float64 with string"},
+		{`exists(c in Order.Customer : c == "a")`, "string is not an array nor map"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when ` + td.grl + ` then Retract("Invalid"); }`
+		diagnostics := validateGRL(t, newTestValidator(t), grl)
+		if assert.Len(t, diagnostics, 1, td.grl) {
+			assert.Contains(t, diagnostics[0].Message, td.message, td.grl)
+		}
+	}
+}
+
+func TestValidator_LocalVariable(t *testing.T) {
+	grl := `rule Valid "valid" { when let total = Order.Amount * 2; total > 100 then let label = Order.Customer + "!"; Order.Customer = label; Order.Amount = total; Retract("Valid"); }`
+	assert.Empty(t, validateGRL(t, newTestValidator(t), grl))
+
+	testData := []struct {
+		grl     string
+		message string
+	}{
+		{`when let total = Order.Amount; total == "a" then Retract("Invalid");`, "can not compare type float64 with string"},
+		{`when true then let name = Order.Customer; Order.Quantity = name;`, "can not assign type string to int"},
+		{`when let total = Order.Cost; total > 1 then Retract("Invalid");`, "ValidatedOrder has no field named Cost"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { ` + td.grl + ` }`
+		diagnostics := validateGRL(t, newTestValidator(t), grl)
+		if assert.Len(t, diagnostics, 1, td.grl) {
+			assert.Contains(t, diagnostics[0].Message, td.message, td.grl)
+		}
+	}
+}
+
+func TestValidator_ThenStatement(t *testing.T) {
+	grl := `rule Valid "valid" { when true then if Order.Amount > 1 { Order.Quantity = 1; } else { Order.Quantity = 2; } for i in Order.Items { i.Price = i.Price * 2; } Retract("Valid"); }`
+	assert.Empty(t, validateGRL(t, newTestValidator(t), grl))
+
+	testData := []struct {
+		grl     string
+		message string
+	}{
+		{`if Order.Customer { Retract("Invalid"); }`, "condition is of type string, not a boolean"},
+		{`if true {} else if Order.Amount { Retract("Invalid"); }`, "condition is of type float64, not a boolean"},
+		{`for i in Order.Items { i.Cost = 1; }`, "ValidatedItem has no field named Cost"},
+		{`for i in Order.Items { Order.Customer = i.Price; }`, "can not assign type float64 to string"},
+		{`for c in Order.Customer { Retract("Invalid"); }`, "string is not an array nor map"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when true then ` + td.grl + ` }`
+		diagnostics := validateGRL(t, newTestValidator(t), grl)
+		if assert.Len(t, diagnostics, 1, td.grl) {
+			assert.Contains(t, diagnostics[0].Message, td.message, td.grl)
+		}
+	}
+}
+
+func TestValidator_RuleEnabled(t *testing.T) {
+	grl := `rule Valid "valid" date-effective "2025-01-01" enabled Order.Amount > 1 { when true then Retract("Valid"); }`
+	assert.Empty(t, validateGRL(t, newTestValidator(t), grl))
+
+	grl = `rule Invalid "invalid" enabled Order.Customer { when true then Retract("Invalid"); }`
+	diagnostics := validateGRL(t, newTestValidator(t), grl)
+	if assert.Len(t, diagnostics, 1) {
+		assert.Contains(t, diagnostics[0].Message, "enabled is of type string, not a boolean")
+	}
+}
@@ -0,0 +1,133 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type AgendaFact struct {
+	Trail   string
+	Started bool
+	Priced  bool
+	Taxed   bool
+	Printed bool
+}
+
+const agendaGroupRules = `
+rule Start "main group gives the focus to the other groups" salience 10 {
+	when
+		!Fact.Started
+	then
+		Fact.Started = true;
+		Fact.Trail = Fact.Trail + "S";
+		SetFocus("report");
+		SetFocus("pricing");
+}
+
+rule Price "pricing group" agenda-group "pricing" {
+	when
+		!Fact.Priced
+	then
+		Fact.Priced = true;
+		Fact.Trail = Fact.Trail + "P";
+}
+
+rule Tax "pricing group, after price" agenda-group "pricing" {
+	when
+		Fact.Priced && !Fact.Taxed
+	then
+		Fact.Taxed = true;
+		Fact.Trail = Fact.Trail + "T";
+}
+
+rule Print "report group" salience 5 agenda-group "report" {
+	when
+		!Fact.Printed
+	then
+		Fact.Printed = true;
+		Fact.Trail = Fact.Trail + "R";
+}
+
+rule Audit "never focused" agenda-group "audit" {
+	when
+		true
+	then
+		Fact.Trail = Fact.Trail + "A";
+		Complete();
+}
+`
+
+func executeAgendaGroupRules(t *testing.T, grl string) *AgendaFact {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("Agenda", "0.1.1", pkg.NewBytesResource([]byte(grl)))
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Agenda", "0.1.1")
+	assert.NoError(t, err)
+
+	fact := &AgendaFact{}
+	dctx := ast.NewDataContext()
+	err = dctx.Add("Fact", fact)
+	assert.NoError(t, err)
+
+	err = NewGruleEngine().Execute(dctx, kb)
+	assert.NoError(t, err)
+
+	return fact
+}
+
+func TestAgendaGroup_Focus(t *testing.T) {
+	fact := executeAgendaGroupRules(t, agendaGroupRules)
+	assert.Equal(t, "SPTR", fact.Trail)
+}
+
+func TestAgendaGroup_PopFocus(t *testing.T) {
+	grl := agendaGroupRules + `
+rule Skip "leaves the pricing group early" salience 100 agenda-group "pricing" {
+	when
+		!Fact.Priced
+	then
+		Fact.Priced = true;
+		Fact.Taxed = true;
+		PopFocus();
+}
+`
+	fact := executeAgendaGroupRules(t, grl)
+	assert.Equal(t, "SR", fact.Trail)
+}
+
+func TestAgendaGroup_FetchMatchingRulesIgnoresFocus(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("Agenda", "0.1.1", pkg.NewBytesResource([]byte(agendaGroupRules)))
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Agenda", "0.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "pricing", kb.RuleEntries["Price"].AgendaGroup)
+	assert.Equal(t, ast.DefaultAgendaGroup, kb.RuleEntries["Start"].AgendaGroup)
+
+	dctx := ast.NewDataContext()
+	err = dctx.Add("Fact", &AgendaFact{})
+	assert.NoError(t, err)
+	matching, err := NewGruleEngine().FetchMatchingRules(dctx, kb)
+	assert.NoError(t, err)
+	assert.Len(t, matching, 4)
+}
@@ -0,0 +1,125 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type CompiledFact struct {
+	Count    int
+	Total    float64
+	Name     string
+	Done     bool
+	Children []*CompiledFact
+}
+
+func (f *CompiledFact) Half() float64 {
+
+	return f.Total / 2
+}
+
+const compiledRules = `
+rule Count "count up to ten" salience 10 {
+	when
+		Fact.Count < 10 && !Fact.Done
+	then
+		Fact.Count = Fact.Count + 1;
+		Fact.Total += Fact.Count * 1.5;
+}
+
+rule Name "name the fact" {
+	when
+		Fact.Count >= 10 && Fact.Half() > 20 && Fact.Name == "" && Json.Kind == "fact"
+	then
+		Fact.Name = "fact-" + Fact.Count;
+		Fact.Children[0].Total = Fact.Total / Fact.Count;
+}
+
+rule Done "done" {
+	when
+		Fact.Name != "" && !(Fact.Count != 10) && Fact.Children[0].Total > 0
+	then
+		Fact.Done = true;
+		Retract("Done");
+}
+`
+
+func executeCompiledRules(t *testing.T, compile bool) *CompiledFact {
+	kb := buildLoopKnowledgeBase(t, compiledRules)
+	if compile {
+		kb.Compile()
+		assert.True(t, kb.IsCompiled())
+	}
+	fact := &CompiledFact{Children: []*CompiledFact{{}}}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("Fact", fact))
+	assert.NoError(t, dctx.AddJSON("Json", []byte(`{"Kind": "fact"}`)))
+	assert.NoError(t, NewGruleEngine().Execute(dctx, kb))
+
+	return fact
+}
+
+func TestKnowledgeBase_Compile(t *testing.T) {
+	interpreted := executeCompiledRules(t, false)
+	compiled := executeCompiledRules(t, true)
+	assert.Equal(t, interpreted, compiled)
+	assert.Equal(t, 10, compiled.Count)
+	assert.Equal(t, 82.5, compiled.Total)
+	assert.Equal(t, "fact-10", compiled.Name)
+	assert.Equal(t, 8.25, compiled.Children[0].Total)
+	assert.True(t, compiled.Done)
+}
+
+func TestKnowledgeBase_CompileClone(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, compiledRules)
+	assert.False(t, kb.IsCompiled())
+	kb.Compile()
+	clone, err := kb.Clone(pkg.NewCloneTable())
+	assert.NoError(t, err)
+	assert.True(t, clone.IsCompiled())
+}
+
+func TestKnowledgeBase_CompileMissingFact(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, `rule Missing "missing" { when Other.Count == 1 then Retract("Missing"); }`)
+	kb.Compile()
+	dctx := ast.NewDataContext()
+	err := (&GruleEngine{MaxCycle: 10, ReturnErrOnFailedRuleEvaluation: true}).Execute(dctx, kb)
+	var missingErr *ast.MissingFactError
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, "Other", missingErr.FactName)
+}
+
+func TestKnowledgeBase_CompileTrace(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, traceRules)
+	kb.Compile()
+	loan := &LoanFact{Income: 5000, Amount: 1000}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("Loan", loan))
+
+	// tracing always uses the interpreter, so the evaluated values are still recorded.
+	trace, err := NewGruleEngine().ExecuteWithTrace(context.Background(), dctx, kb)
+	assert.NoError(t, err)
+	assert.True(t, loan.Approved)
+	if assert.NotEmpty(t, trace.Cycles) && assert.NotNil(t, trace.Cycles[0].Chosen) {
+		assert.NotEmpty(t, trace.Cycles[0].Chosen.Values)
+	}
+}
@@ -0,0 +1,221 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+)
+
+// Activation is a rule entry whose when scope is satisfied and waiting in the agenda to be executed.
+type Activation struct {
+	// RuleEntry is the satisfied rule entry.
+	RuleEntry *ast.RuleEntry
+	// Sequence tells the order of which this activation entered the agenda. The first activation get 1.
+	Sequence uint64
+	// Cycle is the cycle number in which the rule entry became satisfied.
+	Cycle uint64
+}
+
+// ConflictResolver is implemented by those who want to decide which activation should be executed
+// when there are more than one rule entries satisfied in a cycle.
+// The activations given to the resolver are always ordered by their rule name.
+type ConflictResolver interface {
+	// Resolve will select one activation from the activation list to be executed by the engine.
+	Resolve(activations []*Activation) *Activation
+}
+
+// SalienceConflictResolver selects the activation with the highest salience.
+// If more than one activations have the same salience, the one with the lowest rule name alphabetically wins.
+// This is the default resolver used by the engine.
+type SalienceConflictResolver struct {
+}
+
+// Resolve will select the activation with the highest salience.
+func (r *SalienceConflictResolver) Resolve(activations []*Activation) *Activation {
+
+	return pickActivation(activations, func(a, b *Activation) bool {
+
+		return compareSalienceThenName(a, b)
+	})
+}
+
+// RecencyConflictResolver selects the activation that got satisfied the most recent cycle.
+// If more than one activations satisfied on the same cycle, the salience and then the rule name is used.
+type RecencyConflictResolver struct {
+}
+
+// Resolve will select the most recently satisfied activation.
+func (r *RecencyConflictResolver) Resolve(activations []*Activation) *Activation {
+
+	return pickActivation(activations, func(a, b *Activation) bool {
+		if a.Cycle != b.Cycle {
+
+			return a.Cycle > b.Cycle
+		}
+
+		return compareSalienceThenName(a, b)
+	})
+}
+
+// SpecificityConflictResolver selects the activation which rule entry have the most conditions in its when scope.
+// If more than one activations have the same number of conditions, the salience and then the rule name is used.
+type SpecificityConflictResolver struct {
+}
+
+// Resolve will select the activation with the most specific when scope.
+func (r *SpecificityConflictResolver) Resolve(activations []*Activation) *Activation {
+
+	return pickActivation(activations, func(a, b *Activation) bool {
+		aCount := CountConditions(a.RuleEntry)
+		bCount := CountConditions(b.RuleEntry)
+		if aCount != bCount {
+
+			return aCount > bCount
+		}
+
+		return compareSalienceThenName(a, b)
+	})
+}
+
+// LIFOConflictResolver treats the agenda as a stack, the last activation that entered the agenda is executed first.
+type LIFOConflictResolver struct {
+}
+
+// Resolve will select the last activation entered the agenda.
+func (r *LIFOConflictResolver) Resolve(activations []*Activation) *Activation {
+
+	return pickActivation(activations, func(a, b *Activation) bool {
+
+		return a.Sequence > b.Sequence
+	})
+}
+
+// FIFOConflictResolver treats the agenda as a queue, the first activation that entered the agenda is executed first.
+type FIFOConflictResolver struct {
+}
+
+// Resolve will select the first activation entered the agenda.
+func (r *FIFOConflictResolver) Resolve(activations []*Activation) *Activation {
+
+	return pickActivation(activations, func(a, b *Activation) bool {
+
+		return a.Sequence < b.Sequence
+	})
+}
+
+// NewRandomConflictResolver creates a RandomConflictResolver using the specified seed.
+// Two resolvers created with the same seed will make the same choices given the same activations.
+func NewRandomConflictResolver(seed int64) *RandomConflictResolver {
+
+	return &RandomConflictResolver{
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// RandomConflictResolver selects an activation randomly.
+type RandomConflictResolver struct {
+	lock   sync.Mutex
+	random *rand.Rand
+}
+
+// Resolve will select a random activation.
+func (r *RandomConflictResolver) Resolve(activations []*Activation) *Activation {
+	if len(activations) == 0 {
+
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return activations[r.random.Intn(len(activations))]
+}
+
+// CountConditions count the number of conditions in a rule entry when scope.
+// Conditions are the operands joined by the logical operator && and ||.
+func CountConditions(entry *ast.RuleEntry) int {
+	if entry == nil || entry.WhenScope == nil {
+
+		return 0
+	}
+
+	return countExpressionConditions(entry.WhenScope.Expression)
+}
+
+func countExpressionConditions(expr *ast.Expression) int {
+	if expr == nil {
+
+		return 0
+	}
+	if expr.LeftExpression != nil && expr.RightExpression != nil && (expr.Operator == ast.OpAnd || expr.Operator == ast.OpOr) {
+
+		return countExpressionConditions(expr.LeftExpression) + countExpressionConditions(expr.RightExpression)
+	}
+	if expr.SingleExpression != nil {
+
+		return countExpressionConditions(expr.SingleExpression)
+	}
+
+	return 1
+}
+
+// compareSalienceThenName returns true if activation a should be picked over b based on salience and then rule name.
+func compareSalienceThenName(a, b *Activation) bool {
+	if a.RuleEntry.Salience != b.RuleEntry.Salience {
+
+		return a.RuleEntry.Salience > b.RuleEntry.Salience
+	}
+
+	return strings.Compare(a.RuleEntry.RuleName, b.RuleEntry.RuleName) < 0
+}
+
+// pickActivation returns the activation that wins over all the other activations according to the better function.
+func pickActivation(activations []*Activation, better func(a, b *Activation) bool) *Activation {
+	if len(activations) == 0 {
+
+		return nil
+	}
+	winner := activations[0]
+	for _, act := range activations[1:] {
+		if better(act, winner) {
+			winner = act
+		}
+	}
+
+	return winner
+}
+
+// sortedRuleEntries returns all rule entries in the knowledge base ordered by their rule name.
+func sortedRuleEntries(knowledge *ast.KnowledgeBase) []*ast.RuleEntry {
+	entries := make([]*ast.RuleEntry, 0, len(knowledge.RuleEntries))
+	for _, entry := range knowledge.RuleEntries {
+		entries = append(entries, entry)
+	}
+	sortRuleEntriesByName(entries)
+
+	return entries
+}
+
+// sortRuleEntriesByName sorts the rule entries by their rule name.
+func sortRuleEntriesByName(entries []*ast.RuleEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+
+		return strings.Compare(entries[i].RuleName, entries[j].RuleName) < 0
+	})
+}
@@ -0,0 +1,107 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type ConflictFact struct {
+	Trail string
+	Step  int
+}
+
+const conflictRules = `
+rule Bravo "second in name order" {
+	when
+		Fact.Step == 0
+	then
+		Fact.Trail = Fact.Trail + "B";
+		Fact.Step = 1;
+}
+
+rule Alpha "first in name order" {
+	when
+		Fact.Step == 0
+	then
+		Fact.Trail = Fact.Trail + "A";
+		Fact.Step = 1;
+}
+
+rule Charlie "most specific" {
+	when
+		Fact.Step == 0 && Fact.Trail == "" && Fact.Step < 10
+	then
+		Fact.Trail = Fact.Trail + "C";
+		Fact.Step = 1;
+}
+`
+
+func executeConflictRules(t *testing.T, resolver ConflictResolver) string {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("Conflict", "0.1.1", pkg.NewBytesResource([]byte(conflictRules)))
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Conflict", "0.1.1")
+	assert.NoError(t, err)
+
+	fact := &ConflictFact{}
+	dctx := ast.NewDataContext()
+	err = dctx.Add("Fact", fact)
+	assert.NoError(t, err)
+
+	eng := NewGruleEngine()
+	eng.ConflictResolver = resolver
+	err = eng.Execute(dctx, kb)
+	assert.NoError(t, err)
+
+	return fact.Trail
+}
+
+func TestConflictResolver_DefaultIsDeterministic(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, "A", executeConflictRules(t, nil))
+	}
+}
+
+func TestConflictResolver_Specificity(t *testing.T) {
+	assert.Equal(t, "C", executeConflictRules(t, &SpecificityConflictResolver{}))
+}
+
+func TestConflictResolver_RandomIsReproducible(t *testing.T) {
+	first := executeConflictRules(t, NewRandomConflictResolver(42))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, executeConflictRules(t, NewRandomConflictResolver(42)))
+	}
+}
+
+func TestConflictResolver_Resolve(t *testing.T) {
+	alpha := &Activation{RuleEntry: &ast.RuleEntry{RuleName: "Alpha", Salience: 1}, Sequence: 2, Cycle: 1}
+	bravo := &Activation{RuleEntry: &ast.RuleEntry{RuleName: "Bravo", Salience: 5}, Sequence: 1, Cycle: 1}
+	charlie := &Activation{RuleEntry: &ast.RuleEntry{RuleName: "Charlie", Salience: 5}, Sequence: 3, Cycle: 2}
+	activations := []*Activation{alpha, bravo, charlie}
+
+	assert.Equal(t, bravo, (&SalienceConflictResolver{}).Resolve(activations))
+	assert.Equal(t, charlie, (&RecencyConflictResolver{}).Resolve(activations))
+	assert.Equal(t, charlie, (&LIFOConflictResolver{}).Resolve(activations))
+	assert.Equal(t, bravo, (&FIFOConflictResolver{}).Resolve(activations))
+	assert.Nil(t, (&SalienceConflictResolver{}).Resolve(nil))
+	assert.Nil(t, NewRandomConflictResolver(1).Resolve(nil))
+}
@@ -0,0 +1,91 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+)
+
+// RuleExecutionError is returned by the GruleEngine when the when scope of a rule entry fails to evaluate,
+// or when its then scope fails to execute.
+type RuleExecutionError struct {
+	// RuleName is the name of the failing rule entry.
+	RuleName string
+	// GrlText is the GRL text of the failing when or then scope.
+	GrlText string
+	// InWhenScope is true if the error happened while evaluating the when scope, false if while executing the then scope.
+	InWhenScope bool
+	// Err is the cause of the failure.
+	Err error
+}
+
+// Error returns the description of the failure.
+func (e *RuleExecutionError) Error() string {
+	if e.InWhenScope {
+
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("error while executing rule %s. got %v", e.RuleName, e.Err)
+}
+
+// Unwrap returns the cause of the failure.
+func (e *RuleExecutionError) Unwrap() error {
+
+	return e.Err
+}
+
+// whenScopeError will wrap the error of evaluating the when scope of the rule entry into a RuleExecutionError.
+func whenScopeError(ruleEntry *ast.RuleEntry, err error) *RuleExecutionError {
+	grlText := ""
+	if ruleEntry.WhenScope != nil {
+		grlText = ruleEntry.WhenScope.GrlText
+	}
+
+	return &RuleExecutionError{
+		RuleName:    ruleEntry.RuleName,
+		GrlText:     grlText,
+		InWhenScope: true,
+		Err:         err,
+	}
+}
+
+// thenScopeError will wrap the error of executing the then scope of the rule entry into a RuleExecutionError.
+func thenScopeError(ruleEntry *ast.RuleEntry, err error) *RuleExecutionError {
+	grlText := ""
+	if ruleEntry.ThenScope != nil {
+		grlText = ruleEntry.ThenScope.GrlText
+	}
+
+	return &RuleExecutionError{
+		RuleName: ruleEntry.RuleName,
+		GrlText:  grlText,
+		Err:      err,
+	}
+}
+
+// MaxCycleError is returned by the GruleEngine when it keeps selecting rule entries to execute for more than MaxCycle cycles.
+type MaxCycleError struct {
+	// MaxCycle is the GruleEngine.MaxCycle that was reached.
+	MaxCycle uint64
+}
+
+// Error returns the description of the reached limit.
+func (e *MaxCycleError) Error() string {
+
+	return fmt.Sprintf("the GruleEngine successfully selected rule candidate for execution after %d cycles, this could possibly caused by rule entry(s) that keep added into execution pool but when executed it does not change any data in context. Please evaluate your rule entries \"When\" and \"Then\" scope. You can adjust the maximum cycle using GruleEngine.MaxCycle variable", e.MaxCycle)
+}
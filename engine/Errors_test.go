@@ -0,0 +1,93 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type ErrorFact struct {
+	Count int
+	Text  string
+}
+
+const errorRules = `
+rule MissingFact "refers to a fact not in the data context" {
+	when
+		Fact.Count == 1 && Other.Count == 1
+	then
+		Fact.Count = 2;
+}
+
+rule TypeMismatch "subtracts a string" {
+	when
+		Fact.Count == 2
+	then
+		Fact.Count = Fact.Count - Fact.Text;
+}
+
+rule Endless "never stops" {
+	when
+		Fact.Count == 3
+	then
+		Fact.Text = "endless";
+}
+`
+
+func executeErrorRules(t *testing.T, eng *GruleEngine, fact *ErrorFact) error {
+	kb := buildLoopKnowledgeBase(t, errorRules)
+	dctx := ast.NewDataContext()
+	err := dctx.Add("Fact", fact)
+	assert.NoError(t, err)
+
+	return eng.Execute(dctx, kb)
+}
+
+func TestRuleExecutionError(t *testing.T) {
+	// failed when scope evaluations are ignored by default.
+	err := executeErrorRules(t, &GruleEngine{MaxCycle: 10}, &ErrorFact{Count: 1})
+	assert.NoError(t, err)
+
+	err = executeErrorRules(t, &GruleEngine{MaxCycle: 10, ReturnErrOnFailedRuleEvaluation: true}, &ErrorFact{Count: 1})
+	var ruleErr *RuleExecutionError
+	assert.True(t, errors.As(err, &ruleErr))
+	assert.Equal(t, "MissingFact", ruleErr.RuleName)
+	assert.True(t, ruleErr.InWhenScope)
+	assert.Contains(t, ruleErr.GrlText, "Other.Count==1")
+	var missingErr *ast.MissingFactError
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, "Other", missingErr.FactName)
+
+	err = executeErrorRules(t, &GruleEngine{MaxCycle: 10}, &ErrorFact{Count: 2, Text: "text"})
+	assert.True(t, errors.As(err, &ruleErr))
+	assert.Equal(t, "TypeMismatch", ruleErr.RuleName)
+	assert.False(t, ruleErr.InWhenScope)
+	assert.Contains(t, ruleErr.GrlText, "Fact.Count-Fact.Text")
+	var typeErr *pkg.TypeMismatchError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "subtraction", typeErr.Operation)
+}
+
+func TestMaxCycleError(t *testing.T) {
+	err := executeErrorRules(t, &GruleEngine{MaxCycle: 10}, &ErrorFact{Count: 3})
+	var cycleErr *MaxCycleError
+	assert.True(t, errors.As(err, &cycleErr))
+	assert.Equal(t, uint64(10), cycleErr.MaxCycle)
+}
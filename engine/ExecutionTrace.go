@@ -0,0 +1,217 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"reflect"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+)
+
+// ExecutionTrace records what happened in every cycle of an execution, explaining why each rule entry was executed.
+// It is returned by GruleEngine.ExecuteWithTrace
+type ExecutionTrace struct {
+	KnowledgeBaseName    string
+	KnowledgeBaseVersion string
+	Cycles               []*CycleTrace
+}
+
+// CycleTrace records a single cycle of an execution.
+type CycleTrace struct {
+	// Cycle is the cycle number, the first cycle is 1.
+	Cycle uint64
+	// Evaluations are the rule entries which when scope got evaluated in this cycle.
+	Evaluations []*RuleEvaluationTrace
+	// Candidates are the names of the satisfied rule entries in this cycle, ordered by name.
+	Candidates []string
+	// Chosen is the latest evaluation of the rule entry selected for execution, nil if no rule entry was executed.
+	// The evaluation may have taken place in an earlier cycle if nothing has changed the rule entry's when scope since.
+	Chosen *RuleEvaluationTrace
+	// Assignments are the assignments made while executing the chosen rule entry's then scope.
+	Assignments []*AssignmentTrace
+}
+
+// RuleEvaluationTrace records the evaluation of a rule entry's when scope.
+type RuleEvaluationTrace struct {
+	RuleName string
+	// Cycle is the cycle number in which the evaluation took place.
+	Cycle     uint64
+	Satisfied bool
+	Error     error
+	// Values are the values of the Expression and ExpressionAtom in the when scope, in the order they finished evaluating.
+	// Operands that are skipped by the && and || short circuit are not evaluated and so they are not in the list.
+	Values []*ValueTrace
+}
+
+// ValueTrace records the value of an Expression or ExpressionAtom.
+type ValueTrace struct {
+	// AstID is the ID of the AST node that produced the value.
+	AstID string
+	// GrlText is the GRL text of the AST node.
+	GrlText string
+	// Value is the evaluated value, nil if the evaluation failed.
+	Value interface{}
+	Error error
+}
+
+// AssignmentTrace records an assignment made in a then scope.
+type AssignmentTrace struct {
+	// GrlText is the GRL text of the assignment.
+	GrlText string
+	// Variable is the GRL text of the assigned variable.
+	Variable string
+	// Before is the value of the variable prior to the assignment, nil if it did not have any.
+	Before interface{}
+	After  interface{}
+}
+
+// executionTracer builds an ExecutionTrace. It is attached to the working memory as an ast.EvaluationTracer during execution.
+// All methods are safe to call on a nil tracer, so the engine does not need to check whether tracing is enabled.
+type executionTracer struct {
+	trace       *ExecutionTrace
+	cycle       *CycleTrace
+	evaluation  *RuleEvaluationTrace
+	executing   bool
+	evaluations map[*ast.RuleEntry]*RuleEvaluationTrace
+}
+
+func newExecutionTracer(knowledge *ast.KnowledgeBase) *executionTracer {
+
+	return &executionTracer{
+		trace: &ExecutionTrace{
+			KnowledgeBaseName:    knowledge.Name,
+			KnowledgeBaseVersion: knowledge.Version,
+			Cycles:               make([]*CycleTrace, 0),
+		},
+		evaluations: make(map[*ast.RuleEntry]*RuleEvaluationTrace),
+	}
+}
+
+func (t *executionTracer) beginCycle(cycle uint64) {
+	if t == nil {
+
+		return
+	}
+	t.cycle = &CycleTrace{
+		Cycle:       cycle,
+		Evaluations: make([]*RuleEvaluationTrace, 0),
+		Candidates:  make([]string, 0),
+		Assignments: make([]*AssignmentTrace, 0),
+	}
+	t.trace.Cycles = append(t.trace.Cycles, t.cycle)
+}
+
+func (t *executionTracer) beginEvaluation(ruleEntry *ast.RuleEntry) {
+	if t == nil {
+
+		return
+	}
+	t.evaluation = &RuleEvaluationTrace{
+		RuleName: ruleEntry.RuleName,
+		Cycle:    t.cycle.Cycle,
+		Values:   make([]*ValueTrace, 0),
+	}
+	t.cycle.Evaluations = append(t.cycle.Evaluations, t.evaluation)
+	t.evaluations[ruleEntry] = t.evaluation
+}
+
+func (t *executionTracer) endEvaluation(satisfied bool, err error) {
+	if t == nil {
+
+		return
+	}
+	t.evaluation.Satisfied = satisfied
+	t.evaluation.Error = err
+	t.evaluation = nil
+}
+
+func (t *executionTracer) candidates(runnable []*Activation) {
+	if t == nil {
+
+		return
+	}
+	for _, activation := range runnable {
+		t.cycle.Candidates = append(t.cycle.Candidates, activation.RuleEntry.RuleName)
+	}
+}
+
+func (t *executionTracer) beginExecution(ruleEntry *ast.RuleEntry) {
+	if t == nil {
+
+		return
+	}
+	t.cycle.Chosen = t.evaluations[ruleEntry]
+	t.executing = true
+}
+
+func (t *executionTracer) endExecution() {
+	if t == nil {
+
+		return
+	}
+	t.executing = false
+}
+
+// TraceExpression records the expression value if a when scope is being evaluated.
+func (t *executionTracer) TraceExpression(expr *ast.Expression, value reflect.Value, err error) {
+	if t.evaluation != nil {
+		t.evaluation.Values = append(t.evaluation.Values, newValueTrace(expr.AstID, expr.GrlText, value, err))
+	}
+}
+
+// TraceExpressionAtom records the expression atom value if a when scope is being evaluated.
+func (t *executionTracer) TraceExpressionAtom(atom *ast.ExpressionAtom, value reflect.Value, err error) {
+	if t.evaluation != nil {
+		t.evaluation.Values = append(t.evaluation.Values, newValueTrace(atom.AstID, atom.GrlText, value, err))
+	}
+}
+
+// TraceAssignment records the assignment if a then scope is being executed.
+func (t *executionTracer) TraceAssignment(assign *ast.Assignment, before, after reflect.Value) {
+	if !t.executing {
+
+		return
+	}
+	variable := ""
+	if assign.Variable != nil {
+		variable = assign.Variable.GrlText
+	}
+	t.cycle.Assignments = append(t.cycle.Assignments, &AssignmentTrace{
+		GrlText:  assign.GrlText,
+		Variable: variable,
+		Before:   traceValue(before),
+		After:    traceValue(after),
+	})
+}
+
+func newValueTrace(astID, grlText string, value reflect.Value, err error) *ValueTrace {
+
+	return &ValueTrace{
+		AstID:   astID,
+		GrlText: grlText,
+		Value:   traceValue(value),
+		Error:   err,
+	}
+}
+
+// traceValue returns the value held by the reflect.Value, or nil if there is none.
+func traceValue(value reflect.Value) interface{} {
+	if !value.IsValid() || !value.CanInterface() {
+
+		return nil
+	}
+
+	return value.Interface()
+}
@@ -0,0 +1,93 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type LoanFact struct {
+	Income   int
+	Amount   int
+	Approved bool
+}
+
+const traceRules = `
+rule Approve "approve when income is enough" salience 10 {
+	when
+		Loan.Income > Loan.Amount * 2 && !Loan.Approved
+	then
+		Loan.Approved = true;
+}
+
+rule Reject "reject when income is too low" {
+	when
+		Loan.Income < Loan.Amount
+	then
+		Loan.Approved = false;
+}
+`
+
+func TestGruleEngine_ExecuteWithTrace(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("Trace", "0.1.1", pkg.NewBytesResource([]byte(traceRules)))
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Trace", "0.1.1")
+	assert.NoError(t, err)
+
+	loan := &LoanFact{Income: 5000, Amount: 1000}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("Loan", loan))
+
+	trace, err := NewGruleEngine().ExecuteWithTrace(context.Background(), dctx, kb)
+	assert.NoError(t, err)
+	assert.True(t, loan.Approved)
+	assert.Equal(t, "Trace", trace.KnowledgeBaseName)
+	assert.Len(t, trace.Cycles, 2)
+
+	first := trace.Cycles[0]
+	assert.Equal(t, uint64(1), first.Cycle)
+	assert.Equal(t, []string{"Approve"}, first.Candidates)
+	assert.Len(t, first.Evaluations, 2)
+	assert.NotNil(t, first.Chosen)
+	assert.Equal(t, "Approve", first.Chosen.RuleName)
+	assert.True(t, first.Chosen.Satisfied)
+
+	values := make(map[string]interface{})
+	for _, value := range first.Chosen.Values {
+		values[value.GrlText] = value.Value
+	}
+	assert.Equal(t, 5000, values["Loan.Income"])
+	assert.Equal(t, int64(2000), values["Loan.Amount*2"])
+	assert.Equal(t, true, values["Loan.Income>Loan.Amount*2"])
+	assert.Equal(t, true, values["Loan.Income>Loan.Amount*2&&!Loan.Approved"])
+
+	assert.Len(t, first.Assignments, 1)
+	assert.Equal(t, "Loan.Approved", first.Assignments[0].Variable)
+	assert.Equal(t, false, first.Assignments[0].Before)
+	assert.Equal(t, true, first.Assignments[0].After)
+
+	last := trace.Cycles[1]
+	assert.Empty(t, last.Candidates)
+	assert.Nil(t, last.Chosen)
+	assert.Empty(t, last.Assignments)
+}
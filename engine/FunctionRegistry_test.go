@@ -0,0 +1,164 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantKey struct{}
+
+type ShipmentFact struct {
+	FromX, FromY float64
+	ToX, ToY     float64
+	Distance     float64
+	Hash         string
+	Tenant       string
+	Total        int64
+}
+
+type GeoFunctions struct{}
+
+func (g *GeoFunctions) Distance(x1, y1, x2, y2 float64) float64 {
+
+	return math.Hypot(x2-x1, y2-y1)
+}
+
+const registryRules = `
+rule Measure "measure the shipment" salience 10 {
+	when
+		Shipment.Distance == 0
+	then
+		Shipment.Distance = Geo.Distance(Shipment.FromX, Shipment.FromY, Shipment.ToX, Shipment.ToY);
+		Shipment.Hash = HashSha256("shipment");
+		Shipment.Tenant = Tenant();
+		Shipment.Total = Sum(1, 2, 3, 4);
+		Retract("Measure");
+}
+`
+
+func hashOf(text string) string {
+	sum := sha256.Sum256([]byte(text))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func newRegistryLibrary(t *testing.T, grl string) (*ast.KnowledgeLibrary, error) {
+	lib := ast.NewKnowledgeLibrary()
+	assert.NoError(t, lib.FunctionRegistry.RegisterNamespace("Geo", &GeoFunctions{}))
+	lib.FunctionRegistry.MustRegister("HashSha256", hashOf)
+	lib.FunctionRegistry.MustRegister("Sum", func(values ...int64) int64 {
+		var sum int64
+		for _, value := range values {
+			sum += value
+		}
+
+		return sum
+	})
+	err := builder.NewRuleBuilder(lib).BuildRuleFromResource("Registry", "0.1.1", pkg.NewBytesResource([]byte(grl)))
+
+	return lib, err
+}
+
+func TestFunctionRegistry_Execute(t *testing.T) {
+	lib, err := newRegistryLibrary(t, registryRules)
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Registry", "0.1.1")
+	assert.NoError(t, err)
+
+	eng := NewGruleEngine()
+	eng.FunctionRegistry = ast.NewFunctionRegistry()
+	eng.FunctionRegistry.MustRegister("Tenant", func(ctx context.Context) (string, error) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		if !ok {
+
+			return "", errors.New("no tenant")
+		}
+
+		return tenant, nil
+	})
+
+	shipment := &ShipmentFact{ToX: 3, ToY: 4}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("Shipment", shipment))
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	assert.NoError(t, eng.ExecuteWithContext(ctx, dctx, kb))
+	assert.Equal(t, 5.0, shipment.Distance)
+	assert.Equal(t, hashOf("shipment"), shipment.Hash)
+	assert.Equal(t, "acme", shipment.Tenant)
+	assert.Equal(t, int64(10), shipment.Total)
+
+	// the error returned by a function fails the rule.
+	kb, err = lib.NewKnowledgeBaseInstance("Registry", "0.1.1")
+	assert.NoError(t, err)
+	dctx = ast.NewDataContext()
+	assert.NoError(t, dctx.Add("Shipment", &ShipmentFact{ToX: 3, ToY: 4}))
+	err = eng.Execute(dctx, kb)
+	assert.ErrorContains(t, err, "function Tenant returned an error. got no tenant")
+}
+
+func TestFunctionRegistry_FactHidesNamespace(t *testing.T) {
+	grl := `rule Measure "measure" { when Shipment.Distance == 0 then Shipment.Distance = Geo.Distance(1.0, 1.0, 1.0, 1.0) + 1; Retract("Measure"); }`
+	lib, err := newRegistryLibrary(t, grl)
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Registry", "0.1.1")
+	assert.NoError(t, err)
+
+	shipment := &ShipmentFact{}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("Shipment", shipment))
+	assert.NoError(t, dctx.Add("Geo", &ShiftedGeo{}))
+	assert.NoError(t, NewGruleEngine().Execute(dctx, kb))
+	assert.Equal(t, 101.0, shipment.Distance)
+}
+
+type ShiftedGeo struct{}
+
+func (g *ShiftedGeo) Distance(x1, y1, x2, y2 float64) float64 {
+
+	return 100
+}
+
+func TestFunctionRegistry_Arity(t *testing.T) {
+	testData := []struct {
+		call    string
+		message string
+	}{
+		{`Geo.Distance(1.0, 2.0)`, "function Geo.Distance requires 4 argument(s), got 2"},
+		{`HashSha256()`, "function HashSha256 requires 1 argument(s), got 0"},
+	}
+	for _, td := range testData {
+		grl := `rule Arity "arity" { when Shipment.Hash == "" then Shipment.Hash = ` + td.call + `; }`
+		_, err := newRegistryLibrary(t, grl)
+		var reporter *pkg.GruleErrorReporter
+		if assert.True(t, errors.As(err, &reporter), td.call) && assert.Len(t, reporter.Diagnostics, 1) {
+			assert.Contains(t, reporter.Diagnostics[0].Message, td.message)
+			assert.Equal(t, 1, reporter.Diagnostics[0].StartLine)
+		}
+	}
+
+	_, err := newRegistryLibrary(t, `rule Arity "arity" { when true then Shipment.Total = Sum(); }`)
+	assert.NoError(t, err)
+}
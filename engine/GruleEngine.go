@@ -16,11 +16,15 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/zap"
 	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperjumptech/grule-rule-engine/ast"
@@ -88,6 +92,35 @@ type GruleEngine struct {
 	MaxCycle                        uint64
 	ReturnErrOnFailedRuleEvaluation bool
 	Listeners                       []GruleEngineListener
+	// ConflictResolver decides which rule entry to execute when more than one rule entries are satisfied in a cycle.
+	// If nil, the SalienceConflictResolver is used.
+	ConflictResolver ConflictResolver
+	// DisableIncrementalMatching makes the engine evaluate the when scope of every rule entry in every cycle.
+	// By default, after the first cycle the engine only evaluates rule entries whose when scope depends on
+	// variables changed by the previous cycle, thus listeners only get notified for those rule entries.
+	DisableIncrementalMatching bool
+	// Parallelism is the number of goroutines used to evaluate the when scopes of rule entries in a cycle.
+	// A value of 0 or 1 evaluates them one by one. The evaluation results are applied in the same order
+	// as the sequential evaluation, so the chosen rule entry, the listener notifications and the returned
+	// error do not depend on this setting. Functions called from when scopes must be safe for concurrent use.
+	// The when scopes are always evaluated one by one by ExecuteWithTrace.
+	Parallelism int
+	// MaxRuleEvaluationTime is the maximum time the evaluation of a when scope may take, 0 means no limit.
+	// The engine stops waiting for an evaluation that takes longer, but the function being called keeps running
+	// in the background, the next execution on the same knowledge base waits for it to finish.
+	// It is not applied by ExecuteWithTrace.
+	MaxRuleEvaluationTime time.Duration
+	// MaxFunctionCalls is the maximum number of function and method calls made by the rules in an execution, 0 means no limit.
+	MaxFunctionCalls uint64
+	// MaxAssignments is the maximum number of assignments made by the rules in an execution, 0 means no limit.
+	MaxAssignments uint64
+	// FunctionRegistry holds functions callable by the rules of every knowledge base executed by this engine.
+	// They are looked up before the functions registered into the knowledge base's library.
+	FunctionRegistry *ast.FunctionRegistry
+	// Clock gives the time compared to the date-effective and date-expires of the rule entries, it is read once
+	// at the beginning of an execution, and the time returned by the Now and NowIn functions. If nil, the
+	// ast.SystemClock is used.
+	Clock ast.Clock
 }
 
 // Execute function is the same as ExecuteWithContext(context.Background())
@@ -123,39 +156,130 @@ func (g *GruleEngine) notifyBeginCycle(ctx context.Context, cycle uint64) {
 	}
 }
 
+// getConflictResolver returns the configured conflict resolver, or the default one if not configured.
+func (g *GruleEngine) getConflictResolver() ConflictResolver {
+	if g.ConflictResolver == nil {
+
+		return &SalienceConflictResolver{}
+	}
+
+	return g.ConflictResolver
+}
+
+// getClock returns the configured clock, or the system clock if not configured.
+func (g *GruleEngine) getClock() ast.Clock {
+	if g.Clock == nil {
+
+		return ast.SystemClock
+	}
+
+	return g.Clock
+}
+
 // ExecuteWithContext function will execute a knowledge evaluation and action against data context.
 // The engine will evaluate context cancelation status in each cycle.
 // The engine also do conflict resolution of which rule to execute.
 func (g *GruleEngine) ExecuteWithContext(ctx context.Context, dataCtx ast.IDataContext, knowledge *ast.KnowledgeBase) error {
+
+	return g.execute(ctx, dataCtx, knowledge, nil)
+}
+
+// ExecuteWithTrace function works the same as ExecuteWithContext, and also returns an ExecutionTrace
+// that records in every cycle the candidate rule entries, the chosen rule entry, the values of the expressions
+// in the evaluated when scopes and the assignments made by the executed then scope.
+// The trace is returned even if the execution returns an error, it then records the cycles up to the error.
+func (g *GruleEngine) ExecuteWithTrace(ctx context.Context, dataCtx ast.IDataContext, knowledge *ast.KnowledgeBase) (*ExecutionTrace, error) {
+	if knowledge == nil || dataCtx == nil {
+
+		return nil, fmt.Errorf("nil KnowledgeBase or DataContext is not allowed")
+	}
+	tracer := newExecutionTracer(knowledge)
+	knowledge.WorkingMemory.SetTracer(tracer)
+	defer knowledge.WorkingMemory.SetTracer(nil)
+	err := g.execute(ctx, dataCtx, knowledge, tracer)
+
+	return tracer.trace, err
+}
+
+// execute runs the execution cycles, the tracer records them if it is not nil.
+func (g *GruleEngine) execute(ctx context.Context, dataCtx ast.IDataContext, knowledge *ast.KnowledgeBase, tracer *executionTracer) error {
 	if knowledge == nil || dataCtx == nil {
 
 		return fmt.Errorf("nil KnowledgeBase or DataContext is not allowed")
 	}
 
-	log.Debugf("Starting rule execution using knowledge '%s' version %s. Contains %d rule entries", knowledge.Name, knowledge.Version, len(knowledge.RuleEntries))
+	g.prepare(dataCtx, knowledge)
 
-	// Prepare the timer, we need to measure the processing time in debug mode.
-	startTime := time.Now()
+	return g.run(ctx, dataCtx, knowledge, tracer)
+}
 
+// prepare makes the knowledge base start from scratch with the data context.
+func (g *GruleEngine) prepare(dataCtx ast.IDataContext, knowledge *ast.KnowledgeBase) {
 	// Prepare the build-in function and add to datacontext.
 	defunc := &ast.BuiltInFunctions{
 		Knowledge:     knowledge,
 		WorkingMemory: knowledge.WorkingMemory,
 		DataContext:   dataCtx,
+		Clock:         g.getClock(),
 	}
 	dataCtx.Add("DEFUNC", defunc)
 
 	// Working memory need to be resetted. all Expression will be set as not evaluated.
 	log.Debugf("Resetting Working memory")
 	knowledge.WorkingMemory.ResetAll()
+	knowledge.WorkingMemory.ClearLocals()
 	knowledge.Reset()
 
 	// Initialize all AST with datacontext and working memory
 	log.Debugf("Initializing Context")
 	knowledge.InitializeContext(dataCtx)
+}
+
+// run runs the execution cycles until no more rule entry can be executed, keeping the state of the working memory
+// and of the rule entries. The tracer records the cycles if it is not nil.
+func (g *GruleEngine) run(ctx context.Context, dataCtx ast.IDataContext, knowledge *ast.KnowledgeBase, tracer *executionTracer) error {
+	log.Debugf("Starting rule execution using knowledge '%s' version %s. Contains %d rule entries", knowledge.Name, knowledge.Version, len(knowledge.RuleEntries))
+
+	// Prepare the timer, we need to measure the processing time in debug mode.
+	startTime := time.Now()
+
+	// Count the function calls and assignments made during this execution. The limiter is carried by the context,
+	// so an evaluation still running in the background once this execution is done keeps counting with it.
+	ctx = ast.WithExecutionLimiter(ctx, g.newResourceLimiter())
+
+	// Make the registered functions callable. The registries are carried by the context, which is given to
+	// the functions asking for it.
+	ctx = ast.WithFunctionRegistries(ctx, g.FunctionRegistry, knowledge.FunctionRegistry)
 
 	var cycle uint64
 
+	// The rule entries are always visited in the same order, so the conflict resolution is reproducible.
+	ruleEntries := sortedRuleEntries(knowledge)
+	resolver := g.getConflictResolver()
+
+	// The agenda keeps the activations of satisfied rule entries between cycles.
+	agenda := make(map[*ast.RuleEntry]*Activation)
+	var activationSequence uint64
+
+	// Rule entries that are indexed by their when scope expression, to find which rule entries are affected by a variable change.
+	rulesOfExpression := indexRuleEntriesByWhenExpression(ruleEntries)
+	// Rule entries that must be evaluated on the next cycle regardless of variable changes.
+	pending := make(map[*ast.RuleEntry]bool)
+
+	// The last executed rule entry, its changes are the ones taken at the beginning of the next cycle.
+	var lastRunner *ast.RuleEntry
+	// No-loop rule entries that must not be re-activated until another rule entry changes their facts.
+	noLoopSuppressed := make(map[*ast.RuleEntry]bool)
+	// Lock-on-active rule entries that already executed, they never get activated again in this execution.
+	locked := make(map[*ast.RuleEntry]bool)
+	// Rule entries outside of their date-effective and date-expires are not candidates in this execution.
+	now := g.getClock().Now()
+	// evaluable tells whether the when scope of a rule entry must be evaluated.
+	evaluable := func(ruleEntry *ast.RuleEntry) bool {
+
+		return !knowledge.IsRuleRetracted(ruleEntry.RuleName) && !ruleEntry.Deleted && !locked[ruleEntry] && ruleEntry.IsActiveAt(now)
+	}
+
 	/*
 		Un-limited loop as long as there are rule to execute.
 		We need to add safety mechanism to detect unlimited loop as there are possibility executed rule are not changing
@@ -169,34 +293,130 @@ func (g *GruleEngine) ExecuteWithContext(ctx context.Context, dataCtx ast.IDataC
 		}
 
 		g.notifyBeginCycle(ctx, cycle+1)
+		tracer.beginCycle(cycle + 1)
+
+		// Select the rule entries to evaluate in this cycle.
+		// The when scope of a rule entry not affected by the changes still holds the same result as the previous cycle.
+		changedVariables, changedAll := knowledge.WorkingMemory.TakeChanges()
+		affected := make(map[*ast.RuleEntry]bool)
+		for _, variable := range changedVariables {
+			for _, expr := range knowledge.WorkingMemory.GetExpressionsOfVariable(variable) {
+				for _, ruleEntry := range rulesOfExpression[expr] {
+					affected[ruleEntry] = true
+				}
+			}
+		}
 
-		// Select all rule entry that can be executed.
-		log.Tracef("Select all rule entry that can be executed.")
-		runnable := make([]*ast.RuleEntry, 0)
-		for _, ruleEntry := range knowledge.RuleEntries {
+		// A no-loop rule entry may be activated again once another rule entry changed its facts.
+		for ruleEntry := range noLoopSuppressed {
+			if ruleEntry != lastRunner && (changedAll || affected[ruleEntry]) {
+				delete(noLoopSuppressed, ruleEntry)
+			}
+		}
+
+		toEvaluate := ruleEntries
+		if !g.DisableIncrementalMatching && !changedAll && cycle > 0 {
+			for ruleEntry := range affected {
+				pending[ruleEntry] = true
+			}
+			toEvaluate = make([]*ast.RuleEntry, 0, len(pending))
+			for ruleEntry := range pending {
+				toEvaluate = append(toEvaluate, ruleEntry)
+			}
+			sortRuleEntriesByName(toEvaluate)
+		}
+		pending = make(map[*ast.RuleEntry]bool)
+
+		// Evaluate the selected rule entries and update the agenda.
+		log.Tracef("Evaluate %d rule entries.", len(toEvaluate))
+		var evaluations []ruleEvaluation
+		if g.Parallelism > 1 && tracer == nil {
+			evaluations = g.evaluateConcurrently(ctx, toEvaluate, evaluable, dataCtx, knowledge.WorkingMemory)
+		}
+		for i, ruleEntry := range toEvaluate {
 			if ctx.Err() != nil {
 				log.Error("Context canceled")
 
 				return ctx.Err()
 			}
-			if !ruleEntry.Retracted && !ruleEntry.Deleted {
+			if evaluable(ruleEntry) {
 				// test if this rule entry v can execute.
-				can, err := ruleEntry.Evaluate(ctx, dataCtx, knowledge.WorkingMemory)
+				var can bool
+				var err error
+				if evaluations != nil {
+					can, err = evaluations[i].can, evaluations[i].err
+				} else if tracer != nil {
+					tracer.beginEvaluation(ruleEntry)
+					can, err = ruleEntry.Evaluate(ctx, dataCtx, knowledge.WorkingMemory)
+					tracer.endEvaluation(can, err)
+					err = resourceLimitErrorOf(err, ruleEntry)
+				} else {
+					can, err = g.evaluateRuleEntry(ctx, ruleEntry, dataCtx, knowledge.WorkingMemory)
+				}
 				if err != nil {
 					log.Errorf("Failed testing condition for rule : %s. Got error %v", ruleEntry.RuleName, err)
-					if g.ReturnErrOnFailedRuleEvaluation {
+					var limitErr *ResourceLimitError
+					if errors.As(err, &limitErr) {
 
 						return err
 					}
+					if g.ReturnErrOnFailedRuleEvaluation {
+
+						return whenScopeError(ruleEntry, err)
+					}
+					// failed evaluation is not cached, so it must be evaluated again
+					pending[ruleEntry] = true
+				}
+				// if can, add into the agenda
+				if can && noLoopSuppressed[ruleEntry] {
+					log.Tracef("Rule %s is satisfied but not activated by its own changes, it is no-loop", ruleEntry.RuleName)
+					can = false
 				}
-				// if can, add into runnable array
 				if can {
-					runnable = append(runnable, ruleEntry)
+					if _, ok := agenda[ruleEntry]; !ok {
+						activationSequence++
+						agenda[ruleEntry] = &Activation{
+							RuleEntry: ruleEntry,
+							Sequence:  activationSequence,
+							Cycle:     cycle + 1,
+						}
+					}
+				} else {
+					delete(agenda, ruleEntry)
 				}
 				// notify all listeners that a rule's when scope is been evaluated.
 				g.notifyEvaluateRuleEntry(ctx, cycle+1, ruleEntry, can)
+			} else {
+				delete(agenda, ruleEntry)
+			}
+		}
+
+		// Select all rule entry that can be executed.
+		runnable := make([]*Activation, 0, len(agenda))
+		for ruleEntry, activation := range agenda {
+			if knowledge.IsRuleRetracted(ruleEntry.RuleName) || ruleEntry.Deleted {
+				delete(agenda, ruleEntry)
+
+				continue
+			}
+			runnable = append(runnable, activation)
+		}
+		sort.Slice(runnable, func(i, j int) bool {
+
+			return strings.Compare(runnable[i].RuleEntry.RuleName, runnable[j].RuleEntry.RuleName) < 0
+		})
+
+		// Only the rule entries of the focused agenda group are candidates.
+		// If none of them can be executed, the agenda group loses the focus to the previously focused one.
+		for {
+			focused := activationsOfAgendaGroup(runnable, knowledge.GetFocus())
+			if len(focused) > 0 || !knowledge.PopFocus() {
+				runnable = focused
+
+				break
 			}
 		}
+		tracer.candidates(runnable)
 
 		// disabled to test the rete's variable change detection.
 		// knowledge.RuleContextReset()
@@ -212,29 +432,43 @@ func (g *GruleEngine) ExecuteWithContext(ctx context.Context, dataCtx ast.IDataC
 			if cycle > g.MaxCycle {
 				log.Error("Max cycle reached")
 
-				return fmt.Errorf("the GruleEngine successfully selected rule candidate for execution after %d cycles, this could possibly caused by rule entry(s) that keep added into execution pool but when executed it does not change any data in context. Please evaluate your rule entries \"When\" and \"Then\" scope. You can adjust the maximum cycle using GruleEngine.MaxCycle variable", g.MaxCycle)
+				return &MaxCycleError{MaxCycle: g.MaxCycle}
 			}
 
-			runner := runnable[0]
-
-			// scan all runnables and pick the highest salience
-			if len(runnable) > 1 {
-				for idx, pr := range runnable {
-					if idx > 0 && runner.Salience < pr.Salience {
-						runner = pr
-					}
-				}
+			// let the conflict resolver pick which rule entry to execute
+			activation := resolver.Resolve(runnable)
+			if activation == nil {
+				activation = runnable[0]
 			}
+			runner := activation.RuleEntry
+			// once executed, the rule entry must be satisfied again to get back into the agenda
+			delete(agenda, runner)
+			pending[runner] = true
+			lastRunner = runner
+			if runner.NoLoop {
+				noLoopSuppressed[runner] = true
+			}
+			if runner.LockOnActive {
+				locked[runner] = true
+			}
+
 			// set the current rule entry to run. This is for trace ability purpose
 			dataCtx.SetRuleEntry(runner)
 			// notify listeners that we are about to execute a rule entry then scope
 			g.notifyExecuteRuleEntry(ctx, cycle, runner)
 			// execute the top most prioritized rule
+			tracer.beginExecution(runner)
 			err := runner.Execute(ctx, dataCtx, knowledge.WorkingMemory)
+			tracer.endExecution()
 			if err != nil {
 				log.Errorf("Failed execution rule : %s. Got error %v", runner.RuleName, err)
+				var limitErr *ResourceLimitError
+				if errors.As(err, &limitErr) {
 
-				return fmt.Errorf("error while executing rule %s. got %w", runner.RuleName, err)
+					return resourceLimitErrorOf(err, runner)
+				}
+
+				return thenScopeError(runner, err)
 			}
 
 			if dataCtx.IsComplete() {
@@ -252,8 +486,46 @@ func (g *GruleEngine) ExecuteWithContext(ctx context.Context, dataCtx ast.IDataC
 	return nil
 }
 
+// ruleEvaluation is the result of evaluating the when scope of a rule entry.
+type ruleEvaluation struct {
+	can bool
+	err error
+}
+
+// evaluateConcurrently will evaluate the when scopes of the evaluable rule entries using up to g.Parallelism goroutines.
+// The results are returned at the same index as their rule entries. Once the context is canceled, the remaining
+// rule entries are left unevaluated.
+func (g *GruleEngine) evaluateConcurrently(ctx context.Context, ruleEntries []*ast.RuleEntry, evaluable func(*ast.RuleEntry) bool, dataCtx ast.IDataContext, memory *ast.WorkingMemory) []ruleEvaluation {
+	evaluations := make([]ruleEvaluation, len(ruleEntries))
+	workers := g.Parallelism
+	if workers > len(ruleEntries) {
+		workers = len(ruleEntries)
+	}
+	next := int64(-1)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(ruleEntries) || ctx.Err() != nil {
+
+					return
+				}
+				if evaluable(ruleEntries[i]) {
+					evaluations[i].can, evaluations[i].err = g.evaluateRuleEntry(ctx, ruleEntries[i], dataCtx, memory)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return evaluations
+}
+
 // FetchMatchingRules function is responsible to fetch all the rules that matches to a fact against all rule entries
-// Returns []*ast.RuleEntry order by salience
+// Returns []*ast.RuleEntry order by salience, rule entries with the same salience are ordered by their name
 func (g *GruleEngine) FetchMatchingRules(dataCtx ast.IDataContext, knowledge *ast.KnowledgeBase) ([]*ast.RuleEntry, error) {
 	if knowledge == nil || dataCtx == nil {
 
@@ -266,29 +538,40 @@ func (g *GruleEngine) FetchMatchingRules(dataCtx ast.IDataContext, knowledge *as
 		Knowledge:     knowledge,
 		WorkingMemory: knowledge.WorkingMemory,
 		DataContext:   dataCtx,
+		Clock:         g.getClock(),
 	}
 	dataCtx.Add("DEFUNC", defunc)
 
 	// Working memory need to be resetted. all Expression will be set as not evaluated.
 	log.Debugf("Resetting Working memory")
 	knowledge.WorkingMemory.ResetAll()
+	knowledge.WorkingMemory.ClearLocals()
 	// Initialize all AST with datacontext and working memory
 	log.Debugf("Initializing Context")
 	knowledge.InitializeContext(dataCtx)
+	ctx := ast.WithExecutionLimiter(context.Background(), g.newResourceLimiter())
+	ctx = ast.WithFunctionRegistries(ctx, g.FunctionRegistry, knowledge.FunctionRegistry)
 
 	//Loop through all the rule entries available in the knowledge base and add to the response list if it is able to evaluate
 	// Select all rule entry that can be executed.
 	log.Tracef("Select all rule entry that can be executed.")
 	runnable := make([]*ast.RuleEntry, 0)
-	for _, entries := range knowledge.RuleEntries {
-		if !entries.Deleted {
+	now := g.getClock().Now()
+	for _, entries := range sortedRuleEntries(knowledge) {
+		if !entries.Deleted && entries.IsActiveAt(now) {
 			// test if this rule entry v can execute.
-			can, err := entries.Evaluate(context.Background(), dataCtx, knowledge.WorkingMemory)
+			can, err := g.evaluateRuleEntry(ctx, entries, dataCtx, knowledge.WorkingMemory)
 			if err != nil {
 				log.Errorf("Failed testing condition for rule : %s. Got error %v", entries.RuleName, err)
-				if g.ReturnErrOnFailedRuleEvaluation {
+				var limitErr *ResourceLimitError
+				if errors.As(err, &limitErr) {
+
 					return nil, err
 				}
+				if g.ReturnErrOnFailedRuleEvaluation {
+
+					return nil, whenScopeError(entries, err)
+				}
 			}
 			// if can, add into runnable array
 			if can {
@@ -306,3 +589,43 @@ func (g *GruleEngine) FetchMatchingRules(dataCtx ast.IDataContext, knowledge *as
 
 	return runnable, nil
 }
+
+// indexRuleEntriesByWhenExpression maps the when scope expression of each rule entries to the rule entries.
+// Different rule entries may share the same expression as the working memory deduplicates identical expressions.
+func indexRuleEntriesByWhenExpression(ruleEntries []*ast.RuleEntry) map[*ast.Expression][]*ast.RuleEntry {
+	index := make(map[*ast.Expression][]*ast.RuleEntry)
+	for _, ruleEntry := range ruleEntries {
+		if ruleEntry.WhenScope != nil && ruleEntry.WhenScope.Expression != nil {
+			index[ruleEntry.WhenScope.Expression] = append(index[ruleEntry.WhenScope.Expression], ruleEntry)
+		}
+		// the when scope reads the facts through its local variables, a change to their expressions affects it too.
+		if ruleEntry.WhenScope != nil {
+			for _, let := range ruleEntry.WhenScope.Lets {
+				index[let.Expression] = append(index[let.Expression], ruleEntry)
+			}
+		}
+		// so does a change to the enabled expression, it is evaluated before the when scope.
+		if ruleEntry.Enabled != nil {
+			index[ruleEntry.Enabled] = append(index[ruleEntry.Enabled], ruleEntry)
+		}
+	}
+
+	return index
+}
+
+// activationsOfAgendaGroup returns the activations whose rule entry belongs to the agenda group.
+// Rule entries without agenda group belong to the ast.DefaultAgendaGroup.
+func activationsOfAgendaGroup(activations []*Activation, agendaGroup string) []*Activation {
+	ret := make([]*Activation, 0, len(activations))
+	for _, activation := range activations {
+		ruleAgendaGroup := activation.RuleEntry.AgendaGroup
+		if len(ruleAgendaGroup) == 0 {
+			ruleAgendaGroup = ast.DefaultAgendaGroup
+		}
+		if ruleAgendaGroup == agendaGroup {
+			ret = append(ret, activation)
+		}
+	}
+
+	return ret
+}
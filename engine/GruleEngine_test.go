@@ -685,3 +685,56 @@ func TestGruleEngine_Follows_logical_operator_precedence(t *testing.T) {
 	assert.Equal(t, fact.Result, true)
 	assert.Equal(t, fact.NetAmount, float32(143.32))
 }
+
+type evaluationCounter struct {
+	evaluations int
+	executions  []string
+}
+
+func (c *evaluationCounter) EvaluateRuleEntry(ctx context.Context, cycle uint64, entry *ast.RuleEntry, candidate bool) {
+	c.evaluations++
+}
+
+func (c *evaluationCounter) ExecuteRuleEntry(ctx context.Context, cycle uint64, entry *ast.RuleEntry) {
+	c.executions = append(c.executions, entry.RuleName)
+}
+
+func (c *evaluationCounter) BeginCycle(ctx context.Context, cycle uint64) {
+}
+
+func executeCarRules(t *testing.T, disableIncremental bool) (*TestCar, *DistanceRecorder, *evaluationCounter) {
+	tc := &TestCar{
+		SpeedUp:        true,
+		Speed:          0,
+		MaxSpeed:       100,
+		SpeedIncrement: 2,
+	}
+	dr := &DistanceRecorder{}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("TestCar", tc))
+	assert.NoError(t, dctx.Add("DistanceRecord", dr))
+
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	assert.NoError(t, rb.BuildRuleFromResource("Test", "0.1.1", pkg.NewBytesResource([]byte(rules))))
+	kb, err := lib.NewKnowledgeBaseInstance("Test", "0.1.1")
+	assert.NoError(t, err)
+
+	counter := &evaluationCounter{}
+	engine := NewGruleEngine()
+	engine.DisableIncrementalMatching = disableIncremental
+	engine.Listeners = []GruleEngineListener{counter}
+	assert.NoError(t, engine.Execute(dctx, kb))
+
+	return tc, dr, counter
+}
+
+func TestGruleEngine_IncrementalMatching(t *testing.T) {
+	fullCar, fullRecord, fullCounter := executeCarRules(t, true)
+	incCar, incRecord, incCounter := executeCarRules(t, false)
+
+	assert.Equal(t, fullCar, incCar)
+	assert.Equal(t, fullRecord.TotalDistance, incRecord.TotalDistance)
+	assert.Equal(t, fullCounter.executions, incCounter.executions)
+	assert.Less(t, incCounter.evaluations, fullCounter.evaluations)
+}
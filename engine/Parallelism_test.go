@@ -0,0 +1,159 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+type ParallelFact struct {
+	Amount  int
+	Visited []string
+}
+
+func (f *ParallelFact) Visit(name string) {
+	f.Visited = append(f.Visited, name)
+}
+
+func (f *ParallelFact) Fail(name string) bool {
+	panic(fmt.Sprintf("%s failed", name))
+}
+
+// parallelRules will create rules sharing the same expressions, each of them is executed once.
+func parallelRules(count int) string {
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		sb.WriteString(fmt.Sprintf(`
+rule Rule%02d "step %d" salience %d {
+	when
+		Fact.Amount >= %d && Fact.Amount < 1000 && Fact.Amount == %d
+	then
+		Fact.Visit("Rule%02d");
+		Fact.Amount = Fact.Amount + 1;
+}
+`, i, i, i%3, i/2, i, i))
+	}
+
+	return sb.String()
+}
+
+type recordingListener struct {
+	events []string
+}
+
+func (l *recordingListener) EvaluateRuleEntry(ctx context.Context, cycle uint64, entry *ast.RuleEntry, candidate bool) {
+	l.events = append(l.events, fmt.Sprintf("%d eval %s %v", cycle, entry.RuleName, candidate))
+}
+
+func (l *recordingListener) ExecuteRuleEntry(ctx context.Context, cycle uint64, entry *ast.RuleEntry) {
+	l.events = append(l.events, fmt.Sprintf("%d exec %s", cycle, entry.RuleName))
+}
+
+func (l *recordingListener) BeginCycle(ctx context.Context, cycle uint64) {
+	l.events = append(l.events, fmt.Sprintf("%d begin", cycle))
+}
+
+func executeParallelRules(t *testing.T, grl string, parallelism int, disableIncremental bool) (*ParallelFact, []string) {
+	kb := buildLoopKnowledgeBase(t, grl)
+	fact := &ParallelFact{}
+	dctx := ast.NewDataContext()
+	err := dctx.Add("Fact", fact)
+	assert.NoError(t, err)
+	listener := &recordingListener{}
+	eng := &GruleEngine{
+		MaxCycle:                   100,
+		Parallelism:                parallelism,
+		DisableIncrementalMatching: disableIncremental,
+		Listeners:                  []GruleEngineListener{listener},
+	}
+	err = eng.Execute(dctx, kb)
+	assert.NoError(t, err)
+
+	return fact, listener.events
+}
+
+func TestParallelism(t *testing.T) {
+	grl := parallelRules(30)
+	for _, disableIncremental := range []bool{false, true} {
+		fact, events := executeParallelRules(t, grl, 0, disableIncremental)
+		assert.Equal(t, 30, fact.Amount)
+		assert.Len(t, fact.Visited, 30)
+		for _, parallelism := range []int{2, 8, 64} {
+			for i := 0; i < 10; i++ {
+				parallelFact, parallelEvents := executeParallelRules(t, grl, parallelism, disableIncremental)
+				assert.Equal(t, fact, parallelFact)
+				assert.Equal(t, events, parallelEvents)
+			}
+		}
+	}
+}
+
+const failingRules = `
+rule A "fails" {
+	when
+		Fact.Amount > 0 || Fact.Fail("A")
+	then
+		Retract("A");
+}
+
+rule B "fails" {
+	when
+		Fact.Amount > 0 || Fact.Fail("B")
+	then
+		Retract("B");
+}
+
+rule C "fails" {
+	when
+		Fact.Amount > 0 || Fact.Fail("C")
+	then
+		Retract("C");
+}
+`
+
+func TestParallelismError(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, failingRules)
+	for i := 0; i < 20; i++ {
+		dctx := ast.NewDataContext()
+		err := dctx.Add("Fact", &ParallelFact{})
+		assert.NoError(t, err)
+		eng := &GruleEngine{
+			MaxCycle:                        100,
+			Parallelism:                     3,
+			ReturnErrOnFailedRuleEvaluation: true,
+		}
+		err = eng.Execute(dctx, kb)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "A failed")
+	}
+}
+
+func TestParallelismContextCancel(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, parallelRules(10))
+	dctx := ast.NewDataContext()
+	err := dctx.Add("Fact", &ParallelFact{})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	eng := &GruleEngine{MaxCycle: 100, Parallelism: 4}
+	err = eng.ExecuteWithContext(ctx, dctx, kb)
+	assert.Equal(t, context.Canceled, err)
+}
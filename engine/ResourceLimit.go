@@ -0,0 +1,148 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+)
+
+// Names of the GruleEngine resource limits, as reported by ResourceLimitError.
+const (
+	LimitRuleEvaluationTime = "MaxRuleEvaluationTime"
+	LimitFunctionCalls      = "MaxFunctionCalls"
+	LimitAssignments        = "MaxAssignments"
+)
+
+// ResourceLimitError is returned by the GruleEngine when a rule entry hits one of the engine's resource limits.
+// It is returned whether GruleEngine.ReturnErrOnFailedRuleEvaluation is set or not.
+type ResourceLimitError struct {
+	// RuleName is the name of the rule entry that hit the limit.
+	RuleName string
+	// Limit is the name of the limit that was hit, one of LimitRuleEvaluationTime, LimitFunctionCalls or LimitAssignments.
+	Limit string
+	// Threshold is the value of the limit, a time.Duration or an uint64 count.
+	Threshold interface{}
+}
+
+// Error returns the description of the limit hit.
+func (e *ResourceLimitError) Error() string {
+
+	return fmt.Sprintf("rule %s exceeded the %s limit of %v", e.RuleName, e.Limit, e.Threshold)
+}
+
+// resourceLimitErrorOf will return the ResourceLimitError found in err with its RuleName set to the rule entry,
+// or err itself if there is none.
+func resourceLimitErrorOf(err error, ruleEntry *ast.RuleEntry) error {
+	var limitErr *ResourceLimitError
+	if errors.As(err, &limitErr) {
+
+		return &ResourceLimitError{
+			RuleName:  ruleEntry.RuleName,
+			Limit:     limitErr.Limit,
+			Threshold: limitErr.Threshold,
+		}
+	}
+
+	return err
+}
+
+// resourceLimiter is the ast.ExecutionLimiter counting the function calls and assignments of an execution.
+type resourceLimiter struct {
+	maxFunctionCalls uint64
+	maxAssignments   uint64
+	functionCalls    uint64
+	assignments      uint64
+}
+
+// CountFunctionCall will return a ResourceLimitError once the maximum number of function calls is exceeded.
+func (l *resourceLimiter) CountFunctionCall(functionName string) error {
+	if l.maxFunctionCalls > 0 && atomic.AddUint64(&l.functionCalls, 1) > l.maxFunctionCalls {
+
+		return &ResourceLimitError{
+			Limit:     LimitFunctionCalls,
+			Threshold: l.maxFunctionCalls,
+		}
+	}
+
+	return nil
+}
+
+// CountAssignment will return a ResourceLimitError once the maximum number of assignments is exceeded.
+func (l *resourceLimiter) CountAssignment() error {
+	if l.maxAssignments > 0 && atomic.AddUint64(&l.assignments, 1) > l.maxAssignments {
+
+		return &ResourceLimitError{
+			Limit:     LimitAssignments,
+			Threshold: l.maxAssignments,
+		}
+	}
+
+	return nil
+}
+
+// newResourceLimiter will create the ast.ExecutionLimiter of an execution, nil if the engine has no count limit.
+func (g *GruleEngine) newResourceLimiter() ast.ExecutionLimiter {
+	if g.MaxFunctionCalls == 0 && g.MaxAssignments == 0 {
+
+		return nil
+	}
+
+	return &resourceLimiter{
+		maxFunctionCalls: g.MaxFunctionCalls,
+		maxAssignments:   g.MaxAssignments,
+	}
+}
+
+// evaluateRuleEntry will evaluate the when scope of the rule entry. If MaxRuleEvaluationTime is set, the evaluation
+// runs in its own goroutine and a ResourceLimitError is returned once the time is elapsed, without waiting for it.
+// The context of a timed-out evaluation is canceled, so the registered functions asking for it can stop early.
+func (g *GruleEngine) evaluateRuleEntry(ctx context.Context, ruleEntry *ast.RuleEntry, dataCtx ast.IDataContext, memory *ast.WorkingMemory) (bool, error) {
+	if g.MaxRuleEvaluationTime <= 0 {
+		can, err := ruleEntry.Evaluate(ctx, dataCtx, memory)
+
+		return can, resourceLimitErrorOf(err, ruleEntry)
+	}
+	evaluationCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan ruleEvaluation, 1)
+	memory.Go(func() {
+		can, err := ruleEntry.Evaluate(evaluationCtx, dataCtx, memory)
+		done <- ruleEvaluation{can: can, err: err}
+	})
+	timer := time.NewTimer(g.MaxRuleEvaluationTime)
+	defer timer.Stop()
+	select {
+	case evaluation := <-done:
+
+		return evaluation.can, resourceLimitErrorOf(evaluation.err, ruleEntry)
+	case <-timer.C:
+		log.Errorf("Evaluation of rule %s takes longer than %v", ruleEntry.RuleName, g.MaxRuleEvaluationTime)
+
+		return false, &ResourceLimitError{
+			RuleName:  ruleEntry.RuleName,
+			Limit:     LimitRuleEvaluationTime,
+			Threshold: g.MaxRuleEvaluationTime,
+		}
+	case <-ctx.Done():
+
+		return false, fmt.Errorf("context error on evaluating rule %s. got %w", ruleEntry.RuleName, ctx.Err())
+	}
+}
@@ -0,0 +1,165 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+type LimitFact struct {
+	Count int
+	Ticks int
+	Delay time.Duration
+}
+
+func (f *LimitFact) Slow() bool {
+	time.Sleep(f.Delay)
+
+	return true
+}
+
+func (f *LimitFact) Tick(count int) {
+	f.Ticks++
+}
+
+const limitRules = `
+rule Count "counts up to 10" {
+	when
+		Fact.Count < 10
+	then
+		Fact.Count = Fact.Count + 1;
+		Fact.Tick(Fact.Count);
+}
+
+rule Slow "takes its time" {
+	when
+		Fact.Count == 10 && Fact.Slow()
+	then
+		Fact.Count = 11;
+}
+`
+
+func executeLimitRules(t *testing.T, kb *ast.KnowledgeBase, eng *GruleEngine, fact *LimitFact) error {
+	dctx := ast.NewDataContext()
+	err := dctx.Add("Fact", fact)
+	assert.NoError(t, err)
+
+	return eng.Execute(dctx, kb)
+}
+
+func TestResourceLimit_FunctionCalls(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, limitRules)
+
+	err := executeLimitRules(t, kb, &GruleEngine{MaxCycle: 100, MaxFunctionCalls: 11}, &LimitFact{})
+	assert.NoError(t, err)
+
+	err = executeLimitRules(t, kb, &GruleEngine{MaxCycle: 100, MaxFunctionCalls: 5}, &LimitFact{})
+	var limitErr *ResourceLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, "Count", limitErr.RuleName)
+	assert.Equal(t, LimitFunctionCalls, limitErr.Limit)
+	assert.Equal(t, uint64(5), limitErr.Threshold)
+
+	// the when scope of Slow calls the function, the limit error is returned even if failed evaluations are ignored.
+	err = executeLimitRules(t, kb, &GruleEngine{MaxCycle: 100, MaxFunctionCalls: 1}, &LimitFact{Count: 9})
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, "Slow", limitErr.RuleName)
+}
+
+func TestResourceLimit_Assignments(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, limitRules)
+	fact := &LimitFact{}
+	err := executeLimitRules(t, kb, &GruleEngine{MaxCycle: 100, MaxAssignments: 3}, fact)
+	var limitErr *ResourceLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, "Count", limitErr.RuleName)
+	assert.Equal(t, LimitAssignments, limitErr.Limit)
+	assert.Equal(t, "rule Count exceeded the MaxAssignments limit of 3", err.Error())
+	assert.Equal(t, 3, fact.Count)
+}
+
+func TestResourceLimit_RuleEvaluationTime(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, limitRules)
+	eng := &GruleEngine{MaxCycle: 100, MaxRuleEvaluationTime: 50 * time.Millisecond}
+
+	fact := &LimitFact{Delay: time.Millisecond}
+	err := executeLimitRules(t, kb, eng, fact)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, fact.Count)
+
+	start := time.Now()
+	fact = &LimitFact{Delay: 500 * time.Millisecond}
+	err = executeLimitRules(t, kb, eng, fact)
+	assert.Less(t, int64(time.Since(start)), int64(400*time.Millisecond))
+	var limitErr *ResourceLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, "Slow", limitErr.RuleName)
+	assert.Equal(t, LimitRuleEvaluationTime, limitErr.Limit)
+	assert.Equal(t, 50*time.Millisecond, limitErr.Threshold)
+	assert.Equal(t, 10, fact.Count)
+
+	// the next execution waits for the abandoned evaluation before resetting the working memory.
+	fact = &LimitFact{Delay: time.Millisecond}
+	err = executeLimitRules(t, kb, &GruleEngine{MaxCycle: 100, Parallelism: 4, MaxRuleEvaluationTime: time.Second}, fact)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, fact.Count)
+}
+
+const pausingRule = `
+rule Pausing "calls a slow registered function" {
+	when
+		Fact.Count == 0 && Pause() && Canceled()
+	then
+		Fact.Count = 1;
+}
+`
+
+func TestResourceLimit_RuleEvaluationTimeInBackground(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, pausingRule)
+	registry := ast.NewFunctionRegistry()
+	registry.MustRegister("Pause", func() bool {
+		time.Sleep(100 * time.Millisecond)
+
+		return true
+	})
+	canceled := make(chan bool, 2)
+	registry.MustRegister("Canceled", func(ctx context.Context) bool {
+		canceled <- ctx.Err() != nil
+
+		return true
+	})
+
+	// the timed-out evaluation keeps running once the execution is done, with the context of its execution canceled.
+	eng := &GruleEngine{MaxCycle: 10, MaxFunctionCalls: 10, MaxRuleEvaluationTime: 20 * time.Millisecond, FunctionRegistry: registry}
+	err := executeLimitRules(t, kb, eng, &LimitFact{})
+	var limitErr *ResourceLimitError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitRuleEvaluationTime, limitErr.Limit)
+
+	// the next execution does not share its context, its limiter nor its function registries with it.
+	eng = &GruleEngine{MaxCycle: 10, MaxFunctionCalls: 10, MaxRuleEvaluationTime: time.Second, FunctionRegistry: registry}
+	fact := &LimitFact{}
+	err = executeLimitRules(t, kb, eng, fact)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fact.Count)
+	assert.True(t, <-canceled)
+	assert.False(t, <-canceled)
+}
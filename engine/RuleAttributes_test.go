@@ -0,0 +1,149 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type LoopFact struct {
+	Count int
+	Poked bool
+}
+
+const noLoopRules = `
+rule Increment "increments its own condition" no-loop {
+	when
+		Fact.Count < 5
+	then
+		Fact.Count = Fact.Count + 1;
+}
+
+rule Poke "changes the facts of Increment once" {
+	when
+		Fact.Count == 1 && !Fact.Poked
+	then
+		Fact.Poked = true;
+		Fact.Count = 2;
+}
+`
+
+const lockOnActiveRules = `
+rule Increment "increments its own condition" lock-on-active {
+	when
+		Fact.Count < 5
+	then
+		Fact.Count = Fact.Count + 1;
+}
+
+rule Poke "changes the facts of Increment once" {
+	when
+		Fact.Count == 1 && !Fact.Poked
+	then
+		Fact.Poked = true;
+		Fact.Count = 2;
+}
+`
+
+func buildLoopKnowledgeBase(t *testing.T, grl string) *ast.KnowledgeBase {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("Loop", "0.1.1", pkg.NewBytesResource([]byte(grl)))
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Loop", "0.1.1")
+	assert.NoError(t, err)
+
+	return kb
+}
+
+func executeLoopRules(t *testing.T, eng *GruleEngine, kb *ast.KnowledgeBase) *LoopFact {
+	fact := &LoopFact{}
+	dctx := ast.NewDataContext()
+	err := dctx.Add("Fact", fact)
+	assert.NoError(t, err)
+	err = eng.Execute(dctx, kb)
+	assert.NoError(t, err)
+
+	return fact
+}
+
+func TestNoLoop(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, noLoopRules)
+	assert.True(t, kb.RuleEntries["Increment"].NoLoop)
+	assert.False(t, kb.RuleEntries["Poke"].NoLoop)
+
+	// Increment fires, Poke changes the count which re-activates Increment once more.
+	fact := executeLoopRules(t, NewGruleEngine(), kb)
+	assert.Equal(t, 3, fact.Count)
+	assert.True(t, fact.Poked)
+
+	eng := NewGruleEngine()
+	eng.DisableIncrementalMatching = true
+	fact = executeLoopRules(t, eng, kb)
+	assert.Equal(t, 3, fact.Count)
+
+	// without no-loop the rule fires until its condition is no longer satisfied.
+	kb.RuleEntries["Increment"].NoLoop = false
+	fact = executeLoopRules(t, NewGruleEngine(), kb)
+	assert.Equal(t, 5, fact.Count)
+}
+
+func TestLockOnActive(t *testing.T) {
+	kb := buildLoopKnowledgeBase(t, lockOnActiveRules)
+	assert.True(t, kb.RuleEntries["Increment"].LockOnActive)
+
+	// Increment fires only once even though Poke changes its facts.
+	fact := executeLoopRules(t, NewGruleEngine(), kb)
+	assert.Equal(t, 2, fact.Count)
+	assert.True(t, fact.Poked)
+
+	// the lock is released when the knowledge base is executed again.
+	fact = executeLoopRules(t, NewGruleEngine(), kb)
+	assert.Equal(t, 2, fact.Count)
+}
+
+func TestRuleAttributesSerialization(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("Loop", "0.1.1", pkg.NewBytesResource([]byte(noLoopRules+`
+rule Locked "locked rule" salience 3 agenda-group "locking" lock-on-active no-loop {
+	when
+		false
+	then
+		Fact.Count = 0;
+}
+`)))
+	assert.NoError(t, err)
+
+	buff := &bytes.Buffer{}
+	err = lib.StoreKnowledgeBaseToWriter(buff, "Loop", "0.1.1")
+	assert.NoError(t, err)
+
+	loadedLib := ast.NewKnowledgeLibrary()
+	kb, err := loadedLib.LoadKnowledgeBaseFromReader(buff, true)
+	assert.NoError(t, err)
+	assert.True(t, kb.RuleEntries["Increment"].NoLoop)
+	assert.False(t, kb.RuleEntries["Increment"].LockOnActive)
+	assert.True(t, kb.RuleEntries["Locked"].NoLoop)
+	assert.True(t, kb.RuleEntries["Locked"].LockOnActive)
+	assert.Equal(t, "locking", kb.RuleEntries["Locked"].AgendaGroup)
+	assert.Equal(t, 3, kb.RuleEntries["Locked"].Salience)
+}
@@ -0,0 +1,132 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+)
+
+// NewSession creates a Session executing the knowledge base with this engine, without any fact.
+// The knowledge base must not be executed by anything else while the session is in use,
+// a KnowledgeLibrary gives a knowledge base of its own with NewKnowledgeBaseInstance.
+func (g *GruleEngine) NewSession(knowledge *ast.KnowledgeBase) (*Session, error) {
+	if knowledge == nil {
+
+		return nil, fmt.Errorf("nil KnowledgeBase is not allowed")
+	}
+	dataCtx, ok := ast.NewDataContext().(*ast.DataContext)
+	if !ok {
+
+		return nil, fmt.Errorf("unexpected data context type")
+	}
+	g.prepare(dataCtx, knowledge)
+
+	return &Session{
+		engine:    g,
+		knowledge: knowledge,
+		dataCtx:   dataCtx,
+	}, nil
+}
+
+// Session is a long-lived execution of a knowledge base, whose facts are inserted, updated and retracted over time.
+// Unlike GruleEngine.Execute, FireAllRules does not start from scratch: the working memory keeps the values of the
+// expressions not reading the touched facts, and the rule entries retracted by the rules stay retracted.
+// The expressions calling functions are evaluated again by every FireAllRules, as their results may change over time.
+// A Session is safe for concurrent use.
+type Session struct {
+	engine    *GruleEngine
+	knowledge *ast.KnowledgeBase
+	dataCtx   *ast.DataContext
+	lock      sync.Mutex
+}
+
+// Insert will add the fact under the name, replacing the fact already inserted with the same name.
+func (s *Session) Insert(name string, fact interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if name == "DEFUNC" {
+
+		return fmt.Errorf("fact name %s is reserved", name)
+	}
+	err := s.dataCtx.Add(name, fact)
+	if err != nil {
+
+		return err
+	}
+	s.knowledge.WorkingMemory.ResetFact(name)
+
+	return nil
+}
+
+// InsertJSON will add the JSON fact under the name, replacing the fact already inserted with the same name.
+func (s *Session) InsertJSON(name string, JSON []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if name == "DEFUNC" {
+
+		return fmt.Errorf("fact name %s is reserved", name)
+	}
+	err := s.dataCtx.AddJSON(name, JSON)
+	if err != nil {
+
+		return err
+	}
+	s.knowledge.WorkingMemory.ResetFact(name)
+
+	return nil
+}
+
+// Update tells the session the inserted fact has been changed outside of the rules, so the expressions reading it
+// are evaluated again by the next FireAllRules.
+func (s *Session) Update(name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if name == "DEFUNC" || s.dataCtx.Get(name) == nil {
+
+		return &ast.MissingFactError{FactName: name}
+	}
+	s.knowledge.WorkingMemory.ResetFact(name)
+
+	return nil
+}
+
+// Retract will remove the inserted fact. The rule entries reading it are not satisfied until it is inserted again.
+func (s *Session) Retract(name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if name == "DEFUNC" || s.dataCtx.Get(name) == nil {
+
+		return &ast.MissingFactError{FactName: name}
+	}
+	s.dataCtx.Remove(name)
+	s.knowledge.WorkingMemory.ResetFact(name)
+
+	return nil
+}
+
+// FireAllRules will execute the rule entries, in cycles, until none of them can be executed,
+// just like GruleEngine.ExecuteWithContext does.
+func (s *Session) FireAllRules(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.dataCtx.Resume()
+	s.knowledge.WorkingMemory.ResetFunctionCalls()
+
+	return s.engine.run(ctx, s.dataCtx, s.knowledge, nil)
+}
@@ -0,0 +1,291 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type CollectionItem struct {
+	Name     string
+	Price    float64
+	Quantity int
+	Taxed    bool
+	Tags     []string
+}
+
+type CollectionOrder struct {
+	Items  []*CollectionItem
+	Stocks map[string]int
+
+	Expensive   bool
+	AllInStock  bool
+	TaxedCount  int64
+	Total       float64
+	Quantity    int64
+	Cheapest    float64
+	Priciest    string
+	Tagged      bool
+	EmptyCount  int64
+	StockCount  int64
+	Description string
+}
+
+func newCollectionOrder() *CollectionOrder {
+
+	return &CollectionOrder{
+		Items: []*CollectionItem{
+			{Name: "book", Price: 20, Quantity: 3, Taxed: true},
+			{Name: "lamp", Price: 150, Quantity: 1, Taxed: true, Tags: []string{"fragile"}},
+			{Name: "bread", Price: 5, Quantity: 2},
+		},
+		Stocks: map[string]int{"book": 10, "lamp": 1, "bread": 5},
+	}
+}
+
+func TestCollectionExpression_Aggregates(t *testing.T) {
+	grl := `
+rule Aggregate "aggregate the order items" {
+	when
+		exists(i in Order.Items : i.Price > 100) && count(i in Order.Items where i.Taxed) == 2
+	then
+		Order.Expensive = true;
+		Order.AllInStock = forall(i in Order.Items : Order.Stocks[i.Name] >= i.Quantity);
+		Order.TaxedCount = count(i in Order.Items : i.Taxed);
+		Order.Total = sum(i in Order.Items : i.Price * i.Quantity);
+		Order.Quantity = SUM(i in Order.Items : i.Quantity);
+		Order.Cheapest = min(i in Order.Items : i.Price);
+		Order.Priciest = max(i in Order.Items where i.Taxed : i.Name);
+		Order.Tagged = any(i in Order.Items : exists(t in i.Tags : t == "fragile"));
+		Order.EmptyCount = count(i in Order.Items where i.Price > 1000);
+		Order.StockCount = sum(s in Order.Stocks);
+		Retract("Aggregate");
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		order := newCollectionOrder()
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Order", order))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+
+		assert.True(t, order.Expensive, variant.Name)
+		assert.True(t, order.AllInStock, variant.Name)
+		assert.Equal(t, int64(2), order.TaxedCount, variant.Name)
+		assert.Equal(t, 220.0, order.Total, variant.Name)
+		assert.Equal(t, int64(6), order.Quantity, variant.Name)
+		assert.Equal(t, 5.0, order.Cheapest, variant.Name)
+		// max compares the strings, the filter keeps the taxed items only.
+		assert.Equal(t, "lamp", order.Priciest, variant.Name)
+		assert.True(t, order.Tagged, variant.Name)
+		// a filter keeping no item counts 0.
+		assert.Equal(t, int64(0), order.EmptyCount, variant.Name)
+		// the items of a map are its values.
+		assert.Equal(t, int64(16), order.StockCount, variant.Name)
+
+		// an item out of stock fails the forall, a missing tag the nested exists.
+		order = newCollectionOrder()
+		order.Stocks["lamp"] = 0
+		order.Items[1].Tags = nil
+		dataCtx = ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Order", order))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+		assert.True(t, order.Expensive, variant.Name)
+		assert.False(t, order.AllInStock, variant.Name)
+		assert.False(t, order.Tagged, variant.Name)
+
+		// no item above 100, the rule does not match.
+		order = newCollectionOrder()
+		order.Items[1].Price = 80
+		dataCtx = ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Order", order))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+		assert.False(t, order.Expensive, variant.Name)
+	}
+}
+
+func TestCollectionExpression_EmptyCollection(t *testing.T) {
+	grl := `
+rule Empty "aggregate an empty collection" {
+	when
+		Order.Description == "" && !exists(i in Order.Items : i.Taxed)
+	then
+		Order.AllInStock = forall(i in Order.Items : Order.Stocks[i.Name] >= i.Quantity);
+		Order.TaxedCount = count(i in Order.Items);
+		Order.Total = sum(i in Order.Items : i.Price);
+		Order.StockCount = sum(s in Order.Stocks);
+		Order.Description = "empty";
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		for _, order := range []*CollectionOrder{{}, {Items: []*CollectionItem{}, Stocks: map[string]int{}}} {
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Order", order))
+			assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+			assert.Equal(t, "empty", order.Description, variant.Name)
+			// forall holds for no item, count and sum are 0.
+			assert.True(t, order.AllInStock, variant.Name)
+			assert.Equal(t, int64(0), order.TaxedCount, variant.Name)
+			assert.Equal(t, 0.0, order.Total, variant.Name)
+			assert.Equal(t, int64(0), order.StockCount, variant.Name)
+		}
+	}
+}
+
+func TestCollectionExpression_ItemChanged(t *testing.T) {
+	grl := `
+rule Price "the free items are priced" salience 10 {
+	when
+		Order.Items[2].Price == 0
+	then
+		Order.Items[2].Price = 5;
+}
+
+rule Priced "all the items are priced" {
+	when
+		forall(i in Order.Items : i.Price > 0) && Order.Description == ""
+	then
+		Order.Description = "priced";
+}`
+	// the collection expressions are evaluated again once a field of an item is changed.
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		for _, disableIncremental := range []bool{false, true} {
+			order := newCollectionOrder()
+			order.Items[2].Price = 0
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Order", order))
+			eng := engine.NewGruleEngine()
+			eng.DisableIncrementalMatching = disableIncremental
+			assert.NoError(t, eng.Execute(dataCtx, variant.New()))
+			assert.Equal(t, 5.0, order.Items[2].Price, variant.Name)
+			assert.Equal(t, "priced", order.Description, "%s incremental matching disabled : %v", variant.Name, disableIncremental)
+		}
+	}
+}
+
+func TestCollectionExpression_JSON(t *testing.T) {
+	grl := `
+rule Aggregate "aggregate the order items" {
+	when
+		Result.Description == "" && forall(i in Order.Items : i.Price > 0)
+	then
+		Result.Description = "total " + sum(i in Order.Items : i.Price) + " for " + count(i in Order.Items) + " items";
+		Retract("Aggregate");
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		result := &CollectionOrder{}
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.AddJSON("Order", []byte(`{"Items": [{"Price": 1.5}, {"Price": 2}]}`)))
+		assert.NoError(t, dataCtx.Add("Result", result))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+		assert.Equal(t, "total 3.500000 for 2 items", result.Description, variant.Name)
+	}
+}
+
+func TestCollectionExpression_Errors(t *testing.T) {
+	testData := []struct {
+		when    string
+		message string
+	}{
+		{`every(i in Order.Items : i.Taxed)`, "unknown collection operator every"},
+		{`exists(i of Order.Items : i.Taxed)`, "expecting in after exists(i, got of"},
+		{`count(i in Order.Items having i.Taxed) > 1`, "expecting where in count, got having"},
+		{`forall(i in Order.Items)`, "forall requires a predicate after ':'"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when ` + td.when + ` then Retract("Invalid"); }`
+		err := builder.NewRuleBuilder(ast.NewKnowledgeLibrary()).BuildRuleFromResource("Collection", "0.1.1", pkg.NewBytesResource([]byte(grl)))
+		assert.ErrorContains(t, err, td.message, td.when)
+	}
+
+	// min and max have no value when the filter keeps no item.
+	grl := `rule Invalid "invalid" { when min(i in Order.Items where i.Price > 1000 : i.Price) > 1 then Retract("Invalid"); }`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Order", newCollectionOrder()))
+		eng := engine.NewGruleEngine()
+		eng.ReturnErrOnFailedRuleEvaluation = true
+		assert.ErrorContains(t, eng.Execute(dataCtx, variant.New()), "the collection has no item", variant.Name)
+	}
+}
+
+type QuantifierItem struct {
+	X int
+}
+
+type QuantifierFact struct {
+	Groups []int
+	Items  []*QuantifierItem
+	A      bool
+	B      bool
+}
+
+func (f *QuantifierFact) Sleep() bool {
+	time.Sleep(20 * time.Millisecond)
+
+	return true
+}
+
+func (f *QuantifierFact) SleepLonger() bool {
+	time.Sleep(50 * time.Millisecond)
+
+	return true
+}
+
+// The exists of rule A is shared with the one nested in the forall of rule B, both rules are evaluated at once.
+const nestedQuantifierRules = `
+rule A "a" {
+	when
+		F.Sleep() && exists(j in F.Items : j.X > 1) && !F.A
+	then
+		F.A = true;
+}
+
+rule B "b" {
+	when
+		forall(i in F.Groups : F.SleepLonger() && exists(j in F.Items : j.X > 1)) && !F.B
+	then
+		F.B = true;
+}
+`
+
+func TestCollectionExpression_NestedInParallel(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	err := builder.NewRuleBuilder(lib).BuildRuleFromResource("Quantifier", "0.1.1", pkg.NewBytesResource([]byte(nestedQuantifierRules)))
+	assert.NoError(t, err)
+	kb, err := lib.NewKnowledgeBaseInstance("Quantifier", "0.1.1")
+	assert.NoError(t, err)
+
+	fact := &QuantifierFact{Groups: []int{1, 2}, Items: []*QuantifierItem{{X: 1}, {X: 2}}}
+	dctx := ast.NewDataContext()
+	assert.NoError(t, dctx.Add("F", fact))
+	eng := engine.NewGruleEngine()
+	eng.Parallelism = 4
+	done := make(chan error, 1)
+	go func() {
+		done <- eng.Execute(dctx, kb)
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the evaluation of the nested collection expressions is deadlocked")
+	}
+	assert.True(t, fact.A)
+	assert.True(t, fact.B)
+}
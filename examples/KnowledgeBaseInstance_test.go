@@ -0,0 +1,175 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+type InstanceAccount struct {
+	Balance float64
+	Level   string
+	Bonus   float64
+}
+
+const instanceRules = `
+rule Gold "the rich accounts are gold" salience 10 {
+	when
+		Account.Balance >= 1000 && Account.Level == ""
+	then
+		Account.Level = "gold";
+}
+
+rule Silver "the other accounts are silver" {
+	when
+		Account.Balance < 1000 && Account.Level == ""
+	then
+		Account.Level = "silver";
+}
+
+rule Bonus "the gold accounts get a bonus once" {
+	when
+		Account.Level == "gold"
+	then
+		Account.Bonus = Account.Balance * 0.01;
+		Retract("Bonus");
+}`
+
+func executeInstance(t *testing.T, kb *ast.KnowledgeBase, account *InstanceAccount) {
+	dataCtx := ast.NewDataContext()
+	assert.NoError(t, dataCtx.Add("Account", account))
+	assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, kb))
+}
+
+func TestKnowledgeBaseInstance_SharedAST(t *testing.T) {
+	for _, variant := range buildKnowledgeBaseVariants(t, instanceRules) {
+		gold := variant.New()
+		silver := variant.New()
+
+		// the instances share the scopes of the rule entries, but not their metadata nor their evaluation state.
+		assert.Same(t, gold.RuleEntries["Gold"].WhenScope, silver.RuleEntries["Gold"].WhenScope, variant.Name)
+		assert.NotSame(t, gold.RuleEntries["Gold"], silver.RuleEntries["Gold"], variant.Name)
+		goldAccount := &InstanceAccount{Balance: 5000}
+		executeInstance(t, gold, goldAccount)
+		assert.Equal(t, "gold", goldAccount.Level, variant.Name)
+		assert.Equal(t, float64(50), goldAccount.Bonus, variant.Name)
+		assert.True(t, gold.IsRuleRetracted("Bonus"), variant.Name)
+		assert.False(t, silver.IsRuleRetracted("Bonus"), variant.Name)
+		// the compiled instances keep no evaluated node.
+		when := silver.RuleEntries["Gold"].WhenScope.Expression
+		assert.Equal(t, !gold.IsCompiled(), gold.WorkingMemory.IsEvaluated(when), variant.Name)
+		assert.False(t, silver.WorkingMemory.IsEvaluated(when), variant.Name)
+
+		silverAccount := &InstanceAccount{Balance: 500}
+		executeInstance(t, silver, silverAccount)
+		assert.Equal(t, "silver", silverAccount.Level, variant.Name)
+		assert.Equal(t, float64(0), silverAccount.Bonus, variant.Name)
+
+		// executing an instance again starts from scratch, the retracted rules are back.
+		goldAccount = &InstanceAccount{Balance: 2000}
+		executeInstance(t, gold, goldAccount)
+		assert.Equal(t, float64(20), goldAccount.Bonus, variant.Name)
+	}
+}
+
+func TestKnowledgeBaseInstance_BlueprintChanged(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	ruleBuilder := builder.NewRuleBuilder(lib)
+	assert.NoError(t, ruleBuilder.BuildRuleFromResource("Instance", "0.1.1", pkg.NewBytesResource([]byte(instanceRules))))
+	kb, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+
+	// an instance is not changed by the rules added to its blueprint afterward.
+	platinum := `
+rule Platinum "the richest accounts are platinum" salience 20 {
+	when
+		Account.Balance >= 10000 && Account.Level == ""
+	then
+		Account.Level = "platinum";
+}`
+	assert.NoError(t, ruleBuilder.BuildRuleFromResource("Instance", "0.1.1", pkg.NewBytesResource([]byte(platinum))))
+	assert.False(t, kb.ContainsRuleEntry("Platinum"))
+	account := &InstanceAccount{Balance: 20000}
+	executeInstance(t, kb, account)
+	assert.Equal(t, "gold", account.Level)
+
+	rebuilt, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+	account = &InstanceAccount{Balance: 20000}
+	executeInstance(t, rebuilt, account)
+	assert.Equal(t, "platinum", account.Level)
+}
+
+func TestKnowledgeBaseInstance_Compile(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	assert.NoError(t, builder.NewRuleBuilder(lib).BuildRuleFromResource("Instance", "0.1.1", pkg.NewBytesResource([]byte(instanceRules))))
+	compiled, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+	interpreted, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+
+	// compiling an instance leaves its blueprint and the other instances of it interpreted.
+	compiled.Compile()
+	assert.True(t, compiled.IsCompiled())
+	assert.False(t, interpreted.IsCompiled())
+	assert.False(t, lib.GetKnowledgeBase("Instance", "0.1.1").IsCompiled())
+	when := compiled.RuleEntries["Gold"].WhenScope.Expression
+	account := &InstanceAccount{Balance: 5000}
+	executeInstance(t, compiled, account)
+	assert.Equal(t, float64(50), account.Bonus)
+	assert.False(t, compiled.WorkingMemory.IsEvaluated(when))
+	account = &InstanceAccount{Balance: 5000}
+	executeInstance(t, interpreted, account)
+	assert.Equal(t, float64(50), account.Bonus)
+	assert.True(t, interpreted.WorkingMemory.IsEvaluated(when))
+
+	// the instances created from a compiled blueprint are compiled, the ones created before are not.
+	lib.GetKnowledgeBase("Instance", "0.1.1").Compile()
+	assert.False(t, interpreted.IsCompiled())
+	instance, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+	assert.True(t, instance.IsCompiled())
+	executeInstance(t, instance, &InstanceAccount{Balance: 5000})
+	assert.False(t, instance.WorkingMemory.IsEvaluated(when))
+}
+
+func TestKnowledgeBaseInstance_Concurrent(t *testing.T) {
+	for _, variant := range buildKnowledgeBaseVariants(t, instanceRules) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(balance float64) {
+				defer wg.Done()
+				account := &InstanceAccount{Balance: balance}
+				executeInstance(t, variant.New(), account)
+				// 1000 is the boundary between silver and gold.
+				if balance >= 1000 {
+					assert.Equal(t, "gold", account.Level, variant.Name)
+					assert.Equal(t, balance*0.01, account.Bonus, variant.Name)
+				} else {
+					assert.Equal(t, "silver", account.Level, variant.Name)
+					assert.Equal(t, float64(0), account.Bonus, variant.Name)
+				}
+			}(float64(i * 40))
+		}
+		wg.Wait()
+	}
+}
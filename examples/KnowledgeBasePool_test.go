@@ -0,0 +1,238 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"bytes"
+	"context"
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type PooledOrder struct {
+	Total    float64
+	Discount float64
+	Gift     bool
+}
+
+const poolRules = `
+rule Discount "discount the big orders once" {
+	when
+		Order.Total >= 100
+	then
+		Order.Discount = Order.Total * 0.1;
+		Retract("Discount");
+}`
+
+func newPool(t *testing.T, options ast.KnowledgeBasePoolOptions) (*ast.KnowledgeLibrary, *ast.KnowledgeBasePool) {
+	lib := ast.NewKnowledgeLibrary()
+	assert.NoError(t, builder.NewRuleBuilder(lib).BuildRuleFromResource("Pool", "0.1.1", pkg.NewBytesResource([]byte(poolRules))))
+	pool, err := lib.NewKnowledgeBasePool("Pool", "0.1.1", options)
+	assert.NoError(t, err)
+
+	return lib, pool
+}
+
+func executePooled(t *testing.T, pool *ast.KnowledgeBasePool, order *PooledOrder) *ast.KnowledgeBase {
+	kb, err := pool.Get(context.Background())
+	assert.NoError(t, err)
+	dataCtx := ast.NewDataContext()
+	assert.NoError(t, dataCtx.Add("Order", order))
+	assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, kb))
+
+	return kb
+}
+
+func TestKnowledgeBasePool_GrowAndShrink(t *testing.T) {
+	_, pool := newPool(t, ast.KnowledgeBasePoolOptions{MinIdle: 2, MaxIdle: 3, MaxSize: 4})
+	assert.Equal(t, ast.KnowledgeBasePoolStats{Idle: 2}, pool.Stats())
+
+	// the pool grows up to MaxSize, then Get waits.
+	taken := make([]*ast.KnowledgeBase, 0)
+	for i := 0; i < 4; i++ {
+		order := &PooledOrder{Total: 200}
+		taken = append(taken, executePooled(t, pool, order))
+		assert.Equal(t, float64(20), order.Discount)
+	}
+	assert.Equal(t, ast.KnowledgeBasePoolStats{InUse: 4}, pool.Stats())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := pool.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// a waiting Get takes the instance given back.
+	got := make(chan *ast.KnowledgeBase)
+	go func() {
+		kb, err := pool.Get(context.Background())
+		assert.NoError(t, err)
+		got <- kb
+	}()
+	assert.NoError(t, pool.Put(taken[0]))
+	assert.Same(t, taken[0], <-got)
+
+	// the instances given back are reset, and the pool shrinks down to MaxIdle.
+	assert.True(t, taken[1].IsRuleRetracted("Discount"))
+	for _, kb := range taken {
+		assert.NoError(t, pool.Put(kb))
+	}
+	assert.Equal(t, ast.KnowledgeBasePoolStats{Idle: 3}, pool.Stats())
+	assert.False(t, taken[1].IsRuleRetracted("Discount"))
+	assert.Nil(t, taken[1].DataContext)
+
+	// an instance is given back once.
+	assert.ErrorContains(t, pool.Put(taken[0]), "is not taken from this pool")
+	order := &PooledOrder{Total: 300}
+	executePooled(t, pool, order)
+	assert.Equal(t, float64(30), order.Discount)
+}
+
+func TestKnowledgeBasePool_BlueprintChanged(t *testing.T) {
+	lib, pool := newPool(t, ast.KnowledgeBasePoolOptions{MinIdle: 2, MaxIdle: 3})
+	kb := executePooled(t, pool, &PooledOrder{Total: 300})
+
+	// the pool is invalidated when the blueprint is rebuilt, the instances taken before are dropped once given back.
+	gift := `
+rule Gift "a gift for the big orders" {
+	when
+		Order.Total >= 100 && !Order.Gift
+	then
+		Order.Gift = true;
+}`
+	assert.NoError(t, builder.NewRuleBuilder(lib).BuildRuleFromResource("Pool", "0.1.1", pkg.NewBytesResource([]byte(gift))))
+	order := &PooledOrder{Total: 300}
+	rebuilt := executePooled(t, pool, order)
+	assert.True(t, order.Gift)
+	assert.NotSame(t, kb, rebuilt)
+	assert.NoError(t, pool.Put(kb))
+	assert.NoError(t, pool.Put(rebuilt))
+	assert.Eventually(t, func() bool {
+
+		return pool.Stats().Idle == 2
+	}, time.Second, time.Millisecond)
+	for i := 0; i < 2; i++ {
+		kb, err := pool.Get(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, kb.ContainsRuleEntry("Gift"))
+	}
+
+	// and when the blueprint is replaced.
+	stored := ast.NewKnowledgeLibrary()
+	assert.NoError(t, builder.NewRuleBuilder(stored).BuildRuleFromResource("Pool", "0.1.1", pkg.NewBytesResource([]byte(poolRules))))
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, stored.StoreKnowledgeBaseToWriter(buffer, "Pool", "0.1.1"))
+	_, err := lib.LoadKnowledgeBaseFromReader(buffer, true)
+	assert.NoError(t, err)
+	kb, err = pool.Get(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, kb.ContainsRuleEntry("Gift"))
+}
+
+func TestKnowledgeBasePool_Concurrent(t *testing.T) {
+	_, pool := newPool(t, ast.KnowledgeBasePoolOptions{MinIdle: 1, MaxIdle: 2, MaxSize: 3})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(total float64) {
+			defer wg.Done()
+			order := &PooledOrder{Total: total}
+			kb := executePooled(t, pool, order)
+			assert.Equal(t, total*0.1, order.Discount)
+			assert.NoError(t, pool.Put(kb))
+		}(float64(100 + i))
+	}
+	wg.Wait()
+	stats := pool.Stats()
+	assert.Equal(t, 0, stats.InUse)
+	assert.True(t, stats.Idle >= 1 && stats.Idle <= 2, stats.Idle)
+}
+
+func TestKnowledgeBasePool_PutWaitingBackground(t *testing.T) {
+	_, pool := newPool(t, ast.KnowledgeBasePoolOptions{MaxIdle: 2, MaxSize: 2})
+	slow := executePooled(t, pool, &PooledOrder{Total: 200})
+
+	// the instance given back waits for its background evaluation, the pool keeps serving meanwhile.
+	finish := make(chan struct{})
+	slow.WorkingMemory.Go(func() {
+		<-finish
+	})
+	done := make(chan error)
+	go func() {
+		done <- pool.Put(slow)
+	}()
+	order := &PooledOrder{Total: 200}
+	assert.NoError(t, pool.Put(executePooled(t, pool, order)))
+	assert.Equal(t, float64(20), order.Discount)
+	assert.Equal(t, ast.KnowledgeBasePoolStats{Idle: 1, InUse: 1}, pool.Stats())
+	close(finish)
+	assert.NoError(t, <-done)
+	assert.Equal(t, ast.KnowledgeBasePoolStats{Idle: 2}, pool.Stats())
+}
+
+func TestKnowledgeBasePool_PutTwice(t *testing.T) {
+	_, pool := newPool(t, ast.KnowledgeBasePoolOptions{MaxIdle: 2})
+	slow := executePooled(t, pool, &PooledOrder{Total: 200})
+	finish := make(chan struct{})
+	slow.WorkingMemory.Go(func() {
+		<-finish
+	})
+
+	// the instance is given back twice at once, the Put not giving it back fails without waiting for the reset.
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- pool.Put(slow)
+		}()
+	}
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "is not taken from this pool")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the instance given back twice is reset by both Put")
+	}
+	close(finish)
+	assert.NoError(t, <-done)
+	assert.Equal(t, ast.KnowledgeBasePoolStats{Idle: 1}, pool.Stats())
+}
+
+func TestKnowledgeBasePool_Errors(t *testing.T) {
+	lib, pool := newPool(t, ast.KnowledgeBasePoolOptions{})
+
+	testData := []struct {
+		options ast.KnowledgeBasePoolOptions
+		message string
+	}{
+		{ast.KnowledgeBasePoolOptions{MinIdle: -1}, "must not be negative"},
+		{ast.KnowledgeBasePoolOptions{MinIdle: 2, MaxIdle: 1}, "MaxIdle 1 is less than MinIdle 2"},
+		{ast.KnowledgeBasePoolOptions{MaxIdle: 2, MaxSize: 1}, "MaxSize 1 is less than MaxIdle 2"},
+	}
+	for _, td := range testData {
+		_, err := lib.NewKnowledgeBasePool("Pool", "0.1.1", td.options)
+		assert.ErrorContains(t, err, td.message)
+	}
+	_, err := lib.NewKnowledgeBasePool("Missing", "0.1.1", ast.KnowledgeBasePoolOptions{MinIdle: 1})
+	assert.ErrorContains(t, err, "knowledge base Missing version 0.1.1 not exist")
+
+	// an instance not taken from the pool can not be given to it.
+	kb, err := lib.NewKnowledgeBaseInstance("Pool", "0.1.1")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, pool.Put(kb), "is not taken from this pool")
+}
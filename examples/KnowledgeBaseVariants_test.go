@@ -0,0 +1,66 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"bytes"
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// knowledgeBaseVariant is a way of getting the instances of a knowledge base, the rules must behave the same in all of them.
+type knowledgeBaseVariant struct {
+	Name string
+	// New creates an instance of the knowledge base, ready to be executed.
+	New func() *ast.KnowledgeBase
+}
+
+// buildKnowledgeBaseVariants builds each GRL as a resource of its own, then returns the variants of its knowledge base: the instances created
+// from the blueprint, the compiled ones, and the ones created from the blueprint loaded back from its binary catalog.
+func buildKnowledgeBaseVariants(t *testing.T, grls ...string) []knowledgeBaseVariant {
+	lib := ast.NewKnowledgeLibrary()
+	ruleBuilder := builder.NewRuleBuilder(lib)
+	for _, grl := range grls {
+		assert.NoError(t, ruleBuilder.BuildRuleFromResource("Variants", "0.0.1", pkg.NewBytesResource([]byte(grl))))
+	}
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, lib.StoreKnowledgeBaseToWriter(buffer, "Variants", "0.0.1"))
+	loaded := ast.NewKnowledgeLibrary()
+	_, err := loaded.LoadKnowledgeBaseFromReader(buffer, true)
+	assert.NoError(t, err)
+
+	newInstance := func(lib *ast.KnowledgeLibrary, compiled bool) func() *ast.KnowledgeBase {
+
+		return func() *ast.KnowledgeBase {
+			kb, err := lib.NewKnowledgeBaseInstance("Variants", "0.0.1")
+			assert.NoError(t, err)
+			if compiled {
+				kb.Compile()
+			}
+
+			return kb
+		}
+	}
+
+	return []knowledgeBaseVariant{
+		{Name: "instance", New: newInstance(lib, false)},
+		{Name: "compiled", New: newInstance(lib, true)},
+		{Name: "catalog", New: newInstance(loaded, false)},
+	}
+}
@@ -0,0 +1,129 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type LocalOrder struct {
+	Total    float64
+	Discount float64
+	Net      float64
+	Rounded  float64
+	Doubled  float64
+	Priced   bool
+	A, B     int64
+	ResultA  int64
+	ResultB  int64
+}
+
+func TestLocalVariable_WhenAndThen(t *testing.T) {
+	grl := `
+rule Bump "bump the total" salience 10 {
+	when
+		Order.Total == 100
+	then
+		Order.Total = 200;
+}
+
+rule Price "price the order" {
+	when
+		let total = Order.Total * 2;
+		total > 300 && !Order.Priced
+	then
+		let discount = Order.Total * 0.1;
+		let net = Order.Total - discount;
+		Order.Doubled = total;
+		Order.Discount = discount;
+		Order.Net = net;
+		net += 0.5;
+		Order.Rounded = net;
+		Order.Priced = true;
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		kb := variant.New()
+		// the executions share the instance, the locals of the previous one must be gone.
+		for i := 0; i < 2; i++ {
+			order := &LocalOrder{Total: 100}
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Order", order))
+			assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, kb))
+
+			// the local of the when scope is evaluated again once Bump changed the total.
+			assert.Equal(t, 200.0, order.Total, variant.Name)
+			assert.Equal(t, 400.0, order.Doubled, variant.Name)
+			assert.Equal(t, 20.0, order.Discount, variant.Name)
+			assert.Equal(t, 180.0, order.Net, variant.Name)
+			// a compound assignment changes the local, not the fact it was read from.
+			assert.Equal(t, 180.5, order.Rounded, variant.Name)
+			assert.True(t, order.Priced, variant.Name)
+		}
+
+		// locals are kept in the working memory, they are not facts.
+		_, isLocal := kb.WorkingMemory.GetLocal("Price", "discount")
+		assert.True(t, isLocal, variant.Name)
+		_, isLocal = kb.WorkingMemory.GetLocal("Bump", "discount")
+		assert.False(t, isLocal, variant.Name)
+	}
+}
+
+func TestLocalVariable_SameNameInTwoRules(t *testing.T) {
+	grl := `
+rule ResultA "local of rule a" {
+	when
+		Order.ResultA == 0
+	then
+		let x = Order.A;
+		Order.ResultA = x;
+}
+
+rule ResultB "local of rule b" {
+	when
+		let x = Order.B;
+		Order.ResultB != x
+	then
+		Order.ResultB = x;
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		order := &LocalOrder{A: 1, B: 2}
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Order", order))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+		// each rule sees its own x.
+		assert.Equal(t, int64(1), order.ResultA, variant.Name)
+		assert.Equal(t, int64(2), order.ResultB, variant.Name)
+	}
+}
+
+func TestLocalVariable_Errors(t *testing.T) {
+	testData := []struct {
+		grl     string
+		message string
+	}{
+		{`rule Invalid "invalid" { when true then let x = 1; let x = 2; Retract("Invalid"); }`, "local variable x is already declared in rule Invalid"},
+		{`rule Invalid "invalid" { when let x = 1; x > 0 then let x = 2; Retract("Invalid"); }`, "local variable x is already declared in rule Invalid"},
+		{`rule Invalid "invalid" { when true then var x = 1; Retract("Invalid"); }`, "expecting let to declare local variable x, got var"},
+	}
+	for _, td := range testData {
+		err := builder.NewRuleBuilder(ast.NewKnowledgeLibrary()).BuildRuleFromResource("Local", "0.1.1", pkg.NewBytesResource([]byte(td.grl)))
+		assert.ErrorContains(t, err, td.message, td.grl)
+	}
+}
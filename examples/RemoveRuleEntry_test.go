@@ -20,6 +20,7 @@ import (
 	"github.com/hyperjumptech/grule-rule-engine/engine"
 	"github.com/hyperjumptech/grule-rule-engine/pkg"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 )
 
@@ -85,3 +86,43 @@ func TestRemoveRuleEntry(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Its Black!!!", color.Message)
 }
+
+func TestRemoveRuleEntry_WhileCreatingInstances(t *testing.T) {
+	lib := ast.NewKnowledgeLibrary()
+	assert.NoError(t, builder.NewRuleBuilder(lib).BuildRuleFromResource("Instance", "0.1.1", pkg.NewBytesResource([]byte(instanceRules))))
+	before, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+
+	// the rule entries are removed from the blueprint while instances are created from it.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	for _, name := range []string{"Gold", "Silver", "Bonus", "Missing"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			lib.RemoveRuleEntry(name, "Instance", "0.1.1")
+		}(name)
+	}
+	wg.Wait()
+
+	kb, err := lib.NewKnowledgeBaseInstance("Instance", "0.1.1")
+	assert.NoError(t, err)
+	assert.False(t, kb.ContainsRuleEntry("Gold"))
+	account := &InstanceAccount{Balance: 5000}
+	executeInstance(t, kb, account)
+	assert.Equal(t, "", account.Level)
+
+	// the instances created before keep their rule entries.
+	account = &InstanceAccount{Balance: 5000}
+	executeInstance(t, before, account)
+	assert.Equal(t, "gold", account.Level)
+}
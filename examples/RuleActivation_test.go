@@ -0,0 +1,192 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type ActivationFlags struct {
+	PromoOn bool
+	Beta    bool
+}
+
+type ActivationOrder struct {
+	Discount int64
+	Beta     bool
+}
+
+func fixedClock(now time.Time) ast.Clock {
+
+	return ast.ClockFunc(func() time.Time {
+
+		return now
+	})
+}
+
+func TestRuleActivation_DateRange(t *testing.T) {
+	grl := `
+rule Promo "quarter promotion" date-effective "2025-01-01" date-expires "2025-03-31" enabled Flags.PromoOn {
+	when
+		Order.Discount == 0
+	then
+		Order.Discount = 10;
+}
+
+rule Launch "turns the beta on" {
+	when
+		!Flags.Beta
+	then
+		Flags.Beta = true;
+}
+
+rule Beta "beta feature" salience 10 ENABLED Flags.Beta && !Order.Beta {
+	when
+		true
+	then
+		Order.Beta = true;
+}`
+	testData := []struct {
+		name     string
+		now      time.Time
+		promoOn  bool
+		discount int64
+	}{
+		{"just before it is effective", time.Date(2024, time.December, 31, 23, 59, 59, 0, time.Local), true, 0},
+		{"the instant it is effective", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local), true, 10},
+		{"a date only expiry includes the whole day", time.Date(2025, time.March, 31, 23, 59, 59, 0, time.Local), true, 10},
+		{"in range but not enabled", time.Date(2025, time.February, 1, 12, 0, 0, 0, time.Local), false, 0},
+		{"the instant it expires", time.Date(2025, time.April, 1, 0, 0, 0, 0, time.Local), true, 0},
+	}
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		kb := variant.New()
+		promo := kb.RuleEntries["Promo"]
+		assert.True(t, promo.DateEffective.Equal(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)), variant.Name)
+		assert.True(t, promo.DateExpires.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.Local)), variant.Name)
+		assert.Equal(t, "Flags.PromoOn", promo.Enabled.GrlText, variant.Name)
+		assert.True(t, kb.RuleEntries["Launch"].DateEffective.IsZero(), variant.Name)
+		assert.Nil(t, kb.RuleEntries["Launch"].Enabled, variant.Name)
+
+		for _, td := range testData {
+			order := &ActivationOrder{}
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Flags", &ActivationFlags{PromoOn: td.promoOn}))
+			assert.NoError(t, dataCtx.Add("Order", order))
+			eng := engine.NewGruleEngine()
+			eng.Clock = fixedClock(td.now)
+			assert.NoError(t, eng.Execute(dataCtx, variant.New()))
+			assert.Equal(t, td.discount, order.Discount, "%s : %s", variant.Name, td.name)
+			// the Beta rule is enabled once the Launch rule changed the flag.
+			assert.True(t, order.Beta, "%s : %s", variant.Name, td.name)
+
+			// the rules out of their range are not fetched.
+			dataCtx = ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Flags", &ActivationFlags{PromoOn: td.promoOn}))
+			assert.NoError(t, dataCtx.Add("Order", &ActivationOrder{}))
+			entries, err := eng.FetchMatchingRules(dataCtx, variant.New())
+			assert.NoError(t, err)
+			if td.discount > 0 {
+				assert.Equal(t, []string{"Launch", "Promo"}, ruleNames(entries), "%s : %s", variant.Name, td.name)
+			} else {
+				assert.Equal(t, []string{"Launch"}, ruleNames(entries), "%s : %s", variant.Name, td.name)
+			}
+		}
+
+		// the system clock is used by default, the promotion is over.
+		order := &ActivationOrder{}
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Flags", &ActivationFlags{PromoOn: true}))
+		assert.NoError(t, dataCtx.Add("Order", order))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+		assert.Equal(t, int64(0), order.Discount, variant.Name)
+	}
+}
+
+func TestRuleActivation_EnabledByName(t *testing.T) {
+	// enabled and extends are keywords, a single name after enabled is its expression, not the parent of the rule.
+	grl := `
+rule Parent "parent" {
+	when
+		true
+	then
+		Retract("Parent");
+}
+
+rule Child "child" enabled On {
+	when
+		true
+	then
+		Order.Discount = 10;
+		Retract("Child");
+}
+
+rule GrandChild extends Child enabled On {
+	when
+		true
+	then
+		Order.Beta = true;
+		Retract("GrandChild");
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		kb := variant.New()
+		assert.Equal(t, "", kb.RuleEntries["Child"].Extends, variant.Name)
+		assert.Equal(t, "On", kb.RuleEntries["Child"].Enabled.GrlText, variant.Name)
+		assert.Equal(t, "Child", kb.RuleEntries["GrandChild"].Extends, variant.Name)
+		assert.Equal(t, "On", kb.RuleEntries["GrandChild"].Enabled.GrlText, variant.Name)
+
+		for _, on := range []bool{true, false} {
+			order := &ActivationOrder{}
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("On", on))
+			assert.NoError(t, dataCtx.Add("Order", order))
+			assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+			assert.Equal(t, on, order.Discount == 10, "%s enabled : %v", variant.Name, on)
+			assert.Equal(t, on, order.Beta, "%s enabled : %v", variant.Name, on)
+		}
+	}
+}
+
+func TestRuleActivation_Errors(t *testing.T) {
+	testData := []struct {
+		attributes string
+		message    string
+	}{
+		{`date-effective "first of may"`, `invalid date-effective "first of may"`},
+		{`date-expires "2025-13-01"`, `invalid date-expires "2025-13-01"`},
+		{`date-effective "2025-03-01" date-expires "2025-01-01T00:00:00Z"`, "date-expires 2025-01-01T00:00:00Z is not after date-effective"},
+		{`enable Flags.PromoOn`, "mismatched input 'enable'"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" ` + td.attributes + ` { when true then Retract("Invalid"); }`
+		err := builder.NewRuleBuilder(ast.NewKnowledgeLibrary()).BuildRuleFromResource("Activation", "0.1.1", pkg.NewBytesResource([]byte(grl)))
+		assert.ErrorContains(t, err, td.message, td.attributes)
+	}
+
+	// an enabled expression failing to evaluate is reported with its rule.
+	grl := `rule Invalid "invalid" enabled Flags.PromoOn + 1 { when true then Retract("Invalid"); }`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Flags", &ActivationFlags{}))
+		eng := engine.NewGruleEngine()
+		eng.ReturnErrOnFailedRuleEvaluation = true
+		assert.ErrorContains(t, eng.Execute(dataCtx, variant.New()), "enabled expression of rule 'Invalid'", variant.Name)
+	}
+}
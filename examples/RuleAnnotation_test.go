@@ -0,0 +1,112 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type AnnotatedCustomer struct {
+	Verified bool
+	Region   string
+	Checked  int64
+}
+
+func ruleNames(entries []*ast.RuleEntry) []string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.RuleName
+	}
+
+	return names
+}
+
+func TestRuleAnnotation_Query(t *testing.T) {
+	grl := `
+@owner("risk-team") @since("2024-05-01") @tags("kyc", "eu")
+rule KYC "verify the customer" salience 10 {
+	when
+		!Customer.Verified
+	then
+		Customer.Verified = true;
+		Customer.Checked += 1;
+}
+
+@owner("sales-team") @tags('eu') @tags("marketing") @draft
+rule Region "check the region" {
+	when
+		Customer.Region == "EU"
+	then
+		Customer.Checked += 1;
+		Retract("Region");
+}
+
+rule Plain "no annotation" {
+	when
+		Customer.Checked == 2
+	then
+		Customer.Checked += 1;
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		kb := variant.New()
+		assert.Equal(t, map[string][]string{
+			"owner": {"risk-team"},
+			"since": {"2024-05-01"},
+			"tags":  {"kyc", "eu"},
+		}, kb.RuleEntries["KYC"].Annotations, variant.Name)
+		// a repeated annotation gathers its values, one without value has none.
+		assert.Equal(t, map[string][]string{
+			"owner": {"sales-team"},
+			"tags":  {"eu", "marketing"},
+			"draft": {},
+		}, kb.RuleEntries["Region"].Annotations, variant.Name)
+		assert.Nil(t, kb.RuleEntries["Plain"].Annotations, variant.Name)
+
+		owner, ok := kb.RuleEntries["KYC"].GetAnnotation("owner")
+		assert.True(t, ok, variant.Name)
+		assert.Equal(t, []string{"risk-team"}, owner, variant.Name)
+		_, ok = kb.RuleEntries["Plain"].GetAnnotation("owner")
+		assert.False(t, ok, variant.Name)
+
+		// the rules having any of the values are returned, sorted by name.
+		assert.Equal(t, []string{"KYC", "Region"}, ruleNames(kb.GetRuleEntriesByAnnotation("tags")), variant.Name)
+		assert.Equal(t, []string{"KYC", "Region"}, ruleNames(kb.GetRuleEntriesByAnnotation("tags", "eu")), variant.Name)
+		assert.Equal(t, []string{"KYC", "Region"}, ruleNames(kb.GetRuleEntriesByAnnotation("tags", "kyc", "marketing")), variant.Name)
+		assert.Equal(t, []string{"KYC"}, ruleNames(kb.GetRuleEntriesByAnnotation("owner", "risk-team")), variant.Name)
+		assert.Equal(t, []string{"Region"}, ruleNames(kb.GetRuleEntriesByAnnotation("draft")), variant.Name)
+		assert.Empty(t, kb.GetRuleEntriesByAnnotation("owner", "legal-team"), variant.Name)
+		assert.Empty(t, kb.GetRuleEntriesByAnnotation("reviewed"), variant.Name)
+
+		// the annotations do not change how the rules are executed.
+		customer := &AnnotatedCustomer{Region: "EU"}
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Customer", customer))
+		assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, kb))
+		assert.True(t, customer.Verified, variant.Name)
+		assert.Equal(t, int64(3), customer.Checked, variant.Name)
+
+		// the instances do not share the annotations.
+		kb.RuleEntries["KYC"].Annotations["tags"][0] = "aml"
+		kb = variant.New()
+		assert.Equal(t, []string{"kyc", "eu"}, kb.RuleEntries["KYC"].Annotations["tags"], variant.Name)
+
+		// a removed rule is not returned anymore.
+		kb.RemoveRuleEntry("Region")
+		assert.Equal(t, []string{"KYC"}, ruleNames(kb.GetRuleEntriesByAnnotation("tags")), variant.Name)
+	}
+}
@@ -0,0 +1,126 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ExtendedCustomer struct {
+	Active   bool
+	Blocked  bool
+	Region   string
+	Spent    float64
+	Checked  bool
+	Discount int64
+	Gold     bool
+	Priority bool
+}
+
+func TestRuleExtends_Chain(t *testing.T) {
+	parent := `
+rule EUCustomer "an active EU customer" salience 10 {
+	when
+		let spent = Customer.Spent;
+		Customer.Active && !Customer.Blocked && Customer.Region == "EU" && spent >= 0
+	then
+		Customer.Checked = true;
+		Retract("EUCustomer");
+}`
+	// Gold extends Discount, declared after it in the same resource.
+	children := `
+rule Gold extends Discount "a gold customer" {
+	when
+		Customer.Spent > 5000
+	then
+		Customer.Gold = true;
+		Retract("Gold");
+}
+
+rule Discount extends EUCustomer "a discount for big spenders" {
+	when
+		Customer.Spent > 1000
+	then
+		Customer.Discount = 10;
+		Retract("Discount");
+}`
+	// the keyword is case insensitive.
+	grandChild := `
+rule Priority EXTENDS Gold "priority for gold customers" {
+	when
+		true
+	then
+		Customer.Priority = true;
+		Retract("Priority");
+}`
+	testData := []struct {
+		name     string
+		customer *ExtendedCustomer
+		checked  bool
+		discount int64
+		gold     bool
+		priority bool
+	}{
+		{"the whole chain matches", &ExtendedCustomer{Active: true, Region: "EU", Spent: 6000}, true, 10, true, true},
+		{"the chain stops at the first child", &ExtendedCustomer{Active: true, Region: "EU", Spent: 2000}, true, 10, false, false},
+		{"the parent does not match, nor do its descendants", &ExtendedCustomer{Active: true, Blocked: true, Region: "EU", Spent: 6000}, false, 0, false, false},
+		{"the parent only matches", &ExtendedCustomer{Active: true, Region: "EU"}, true, 0, false, false},
+	}
+	for _, variant := range buildKnowledgeBaseVariants(t, parent, children, grandChild) {
+		kb := variant.New()
+		assert.Equal(t, "", kb.RuleEntries["EUCustomer"].Extends, variant.Name)
+		assert.Equal(t, "EUCustomer", kb.RuleEntries["Discount"].Extends, variant.Name)
+		assert.Equal(t, "Discount", kb.RuleEntries["Gold"].Extends, variant.Name)
+		assert.Equal(t, "Gold", kb.RuleEntries["Priority"].Extends, variant.Name)
+		// the lets and the conditions of all the ancestors are inherited.
+		assert.Len(t, kb.RuleEntries["Priority"].WhenScope.Lets, 1, variant.Name)
+		assert.Contains(t, kb.RuleEntries["Gold"].WhenScope.Expression.GrlText, "Customer.Region==\"EU\"", variant.Name)
+
+		for _, td := range testData {
+			customer := *td.customer
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Customer", &customer))
+			assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+			assert.Equal(t, td.checked, customer.Checked, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.discount, customer.Discount, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.gold, customer.Gold, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.priority, customer.Priority, "%s : %s", variant.Name, td.name)
+		}
+	}
+}
+
+func TestRuleExtends_Errors(t *testing.T) {
+	testData := []struct {
+		grl     string
+		message string
+	}{
+		{`rule Child extends Missing { when true then Retract("Child"); }`, "rule Child extends unknown rule Missing"},
+		{`rule Child extends Child { when true then Retract("Child"); }`, "cyclic inheritance Child extends Child"},
+		{`rule A extends B { when true then Retract("A"); } rule B extends C { when true then Retract("B"); } rule C extends A { when true then Retract("C"); }`, "cyclic inheritance A extends B extends C extends A"},
+		{`rule Child inherits Parent { when true then Retract("Child"); }`, "mismatched input 'inherits'"},
+	}
+	for _, td := range testData {
+		lib := ast.NewKnowledgeLibrary()
+		err := builder.NewRuleBuilder(lib).BuildRuleFromResource("Extends", "0.1.1", pkg.NewBytesResource([]byte(td.grl)))
+		assert.ErrorContains(t, err, td.message, td.grl)
+		// none of the rules of a resource failing to build is added.
+		assert.Empty(t, lib.GetKnowledgeBase("Extends", "0.1.1").RuleEntries, td.grl)
+	}
+}
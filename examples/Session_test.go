@@ -0,0 +1,162 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"context"
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type SessionOrder struct {
+	Status   string
+	Total    float64
+	Carrier  string
+	Welcomed int64
+	Checked  time.Time
+	Checks   int64
+}
+
+type SessionPayment struct {
+	Amount float64
+}
+
+type SessionShipment struct {
+	Carrier string
+}
+
+const sessionRules = `
+rule Welcome "greet new orders once" {
+	when
+		Order.Status == "new"
+	then
+		Order.Welcomed += 1;
+		Retract("Welcome");
+}
+
+rule Pay "the payment covers the order" {
+	when
+		Order.Status == "new" && Payment.Amount >= Order.Total
+	then
+		Order.Status = "paid";
+}
+
+rule Ship "ship the paid orders" {
+	when
+		Order.Status == "paid" && Shipment.Carrier != ""
+	then
+		Order.Status = "shipped";
+		Order.Carrier = Shipment.Carrier;
+}
+
+rule Check "check the order once per time" {
+	when
+		Order.Checked != Now()
+	then
+		Order.Checked = Now();
+		Order.Checks += 1;
+}`
+
+func TestSession_OrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	for _, variant := range buildKnowledgeBaseVariants(t, sessionRules) {
+		now := time.Date(2025, time.January, 1, 8, 0, 0, 0, time.UTC)
+		eng := engine.NewGruleEngine()
+		eng.Clock = ast.ClockFunc(func() time.Time {
+
+			return now
+		})
+		kb := variant.New()
+		session, err := eng.NewSession(kb)
+		assert.NoError(t, err, variant.Name)
+
+		// the rules reading a fact not inserted yet do not match, the others do.
+		order := &SessionOrder{Status: "new", Total: 100}
+		assert.NoError(t, session.Insert("Order", order), variant.Name)
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, int64(1), order.Welcomed, variant.Name)
+		assert.Equal(t, "new", order.Status, variant.Name)
+		assert.Equal(t, int64(1), order.Checks, variant.Name)
+
+		// the retracted rules stay retracted, and nothing fires again without a change.
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, int64(1), order.Welcomed, variant.Name)
+		assert.Equal(t, int64(1), order.Checks, variant.Name)
+		assert.True(t, kb.IsRuleRetracted("Welcome"), variant.Name)
+
+		payment := &SessionPayment{Amount: 50}
+		assert.NoError(t, session.Insert("Payment", payment), variant.Name)
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, "new", order.Status, variant.Name)
+
+		// only the expressions reading the updated fact are evaluated again, the compiled ones keep no value.
+		payment.Amount = 150
+		assert.NoError(t, session.Update("Payment"), variant.Name)
+		if !kb.IsCompiled() {
+			pay := kb.RuleEntries["Pay"].WhenScope.Expression
+			assert.False(t, kb.WorkingMemory.IsEvaluated(pay), variant.Name)
+			assert.True(t, kb.WorkingMemory.IsEvaluated(pay.LeftExpression), variant.Name)
+		}
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, "paid", order.Status, variant.Name)
+
+		assert.NoError(t, session.InsertJSON("Shipment", []byte(`{"Carrier": "JNE"}`)), variant.Name)
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, "shipped", order.Status, variant.Name)
+		assert.Equal(t, "JNE", order.Carrier, variant.Name)
+		assert.Equal(t, int64(1), order.Welcomed, variant.Name)
+
+		// the functions are called again by every FireAllRules.
+		now = now.Add(time.Hour)
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, int64(2), order.Checks, variant.Name)
+		assert.Equal(t, now, order.Checked, variant.Name)
+
+		// a new order replaces the previous one, Welcome is still retracted.
+		assert.NoError(t, session.Retract("Shipment"), variant.Name)
+		order = &SessionOrder{Status: "new", Total: 100, Checked: now}
+		assert.NoError(t, session.Insert("Order", order), variant.Name)
+		assert.NoError(t, session.FireAllRules(ctx), variant.Name)
+		assert.Equal(t, "paid", order.Status, variant.Name)
+		assert.Equal(t, int64(0), order.Welcomed, variant.Name)
+		assert.Equal(t, int64(0), order.Checks, variant.Name)
+	}
+}
+
+func TestSession_Errors(t *testing.T) {
+	for _, variant := range buildKnowledgeBaseVariants(t, sessionRules) {
+		session, err := engine.NewGruleEngine().NewSession(variant.New())
+		assert.NoError(t, err, variant.Name)
+
+		assert.ErrorContains(t, session.Insert("DEFUNC", &SessionOrder{}), "fact name DEFUNC is reserved", variant.Name)
+		var missing *ast.MissingFactError
+		assert.ErrorAs(t, session.Update("Order"), &missing, variant.Name)
+		assert.Equal(t, "Order", missing.FactName, variant.Name)
+		assert.ErrorAs(t, session.Retract("Order"), &missing, variant.Name)
+		assert.ErrorAs(t, session.Retract("DEFUNC"), &missing, variant.Name)
+		assert.Error(t, session.InsertJSON("Order", []byte(`{"Status":`)), variant.Name)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, session.Insert("Order", &SessionOrder{Status: "new"}), variant.Name)
+		assert.ErrorIs(t, session.FireAllRules(ctx), context.Canceled, variant.Name)
+	}
+
+	_, err := engine.NewGruleEngine().NewSession(nil)
+	assert.Error(t, err)
+}
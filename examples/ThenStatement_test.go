@@ -0,0 +1,195 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"context"
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type StatementItem struct {
+	Name     string
+	Price    float64
+	Discount float64
+	Taxed    bool
+}
+
+type StatementOrder struct {
+	Items    []*StatementItem
+	Stocks   map[string]int
+	Total    float64
+	Category string
+	Taxed    int64
+	Stock    int64
+	Checked  bool
+	Visited  int64
+
+	cancel context.CancelFunc
+}
+
+func (o *StatementOrder) Cancel() {
+	o.cancel()
+}
+
+func TestThenStatement_LoopAndBranches(t *testing.T) {
+	grl := `
+rule Check "check the order" {
+	when
+		!Order.Checked
+	then
+		Order.Checked = true;
+		for item in Order.Items {
+			if item.Taxed {
+				Order.Taxed += 1;
+				let discounted = item.Price * 0.9;
+				item.Discount = item.Price - discounted;
+				Order.Total += discounted;
+			} else {
+				Order.Total += item.Price;
+			}
+		}
+		if Order.Total > 1000 {
+			Order.Category = "big";
+		} else if Order.Total > 100 {
+			Order.Category = "medium";
+		} else if Order.Total > 10 {
+			Order.Category = "small";
+		} else {
+			Order.Category = "tiny";
+		}
+		for stock in Order.Stocks { Order.Stock += stock; }
+		if Order.Taxed == 0 {}
+		Retract("Check");
+}`
+	testData := []struct {
+		name     string
+		items    []*StatementItem
+		stocks   map[string]int
+		total    float64
+		taxed    int64
+		category string
+		stock    int64
+	}{
+		{"taxed and untaxed items", []*StatementItem{{Name: "book", Price: 20, Taxed: true}, {Name: "lamp", Price: 150, Taxed: true}, {Name: "bread", Price: 5}},
+			map[string]int{"book": 10, "lamp": 1, "bread": 5}, 158, 2, "medium", 16},
+		{"the first branch", []*StatementItem{{Name: "sofa", Price: 2000}}, nil, 2000, 0, "big", 0},
+		{"the last else if", []*StatementItem{{Name: "book", Price: 20, Taxed: true}}, nil, 18, 1, "small", 0},
+		{"no item runs no iteration, the else is taken", nil, map[string]int{}, 0, 0, "tiny", 0},
+	}
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		for _, td := range testData {
+			order := &StatementOrder{Items: td.items, Stocks: td.stocks}
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Order", order))
+			assert.NoError(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()))
+
+			assert.True(t, order.Checked, "%s : %s", variant.Name, td.name)
+			assert.InDelta(t, td.total, order.Total, 0.0001, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.taxed, order.Taxed, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.category, order.Category, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.stock, order.Stock, "%s : %s", variant.Name, td.name)
+			// the loop changes the items themselves, not copies of them.
+			for _, item := range td.items {
+				if item.Taxed {
+					assert.InDelta(t, item.Price*0.1, item.Discount, 0.0001, "%s : %s", variant.Name, td.name)
+				} else {
+					assert.Equal(t, 0.0, item.Discount, "%s : %s", variant.Name, td.name)
+				}
+			}
+		}
+	}
+}
+
+func TestThenStatement_ItemChanged(t *testing.T) {
+	grl := `
+rule Discount "discount the taxed items" salience 10 {
+	when
+		!Order.Checked
+	then
+		Order.Checked = true;
+		for item in Order.Items {
+			if item.Taxed { item.Discount = 1; }
+		}
+}
+
+rule Discounted "the first item is discounted" {
+	when
+		Order.Items[0].Discount > 0 && Order.Category == ""
+	then
+		Order.Category = "discounted";
+}`
+	// the fields changed through the item of a loop are seen by the other rules.
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		for _, disableIncremental := range []bool{false, true} {
+			order := &StatementOrder{Items: []*StatementItem{{Name: "book", Price: 20, Taxed: true}}}
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Order", order))
+			eng := engine.NewGruleEngine()
+			eng.DisableIncrementalMatching = disableIncremental
+			assert.NoError(t, eng.Execute(dataCtx, variant.New()))
+			assert.Equal(t, 1.0, order.Items[0].Discount, variant.Name)
+			assert.Equal(t, "discounted", order.Category, "%s incremental matching disabled : %v", variant.Name, disableIncremental)
+		}
+	}
+}
+
+func TestThenStatement_CancelInLoop(t *testing.T) {
+	grl := `
+rule Visit "visit the items" {
+	when
+		Order.Visited == 0
+	then
+		for item in Order.Items {
+			Order.Visited += 1;
+			if item.Name == "lamp" { Order.Cancel(); }
+		}
+}`
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		ctx, cancel := context.WithCancel(context.Background())
+		order := &StatementOrder{
+			Items:  []*StatementItem{{Name: "book"}, {Name: "lamp"}, {Name: "bread"}},
+			cancel: cancel,
+		}
+		dataCtx := ast.NewDataContext()
+		assert.NoError(t, dataCtx.Add("Order", order))
+		err := engine.NewGruleEngine().ExecuteWithContext(ctx, dataCtx, variant.New())
+		assert.ErrorIs(t, err, context.Canceled, variant.Name)
+		// the loop stops at the item cancelling the execution.
+		assert.Equal(t, int64(2), order.Visited, variant.Name)
+		cancel()
+	}
+}
+
+func TestThenStatement_Errors(t *testing.T) {
+	testData := []struct {
+		then    string
+		message string
+	}{
+		{`unless Order.Total > 1 { Retract("Invalid"); }`, "expecting if, got unless"},
+		{`if Order.Total > 1 { Retract("Invalid"); } otherwise { Retract("Invalid"); }`, "expecting else, got otherwise"},
+		{`each item in Order.Items { Retract("Invalid"); }`, "expecting for, got each"},
+		{`for item of Order.Items { Retract("Invalid"); }`, "expecting in after for item, got of"},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when true then ` + td.then + ` }`
+		err := builder.NewRuleBuilder(ast.NewKnowledgeLibrary()).BuildRuleFromResource("Statement", "0.1.1", pkg.NewBytesResource([]byte(grl)))
+		assert.ErrorContains(t, err, td.message, td.then)
+	}
+}
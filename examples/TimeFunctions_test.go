@@ -0,0 +1,116 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package examples
+
+import (
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+type TimedEvent struct {
+	Created     time.Time
+	Checked     bool
+	Now         time.Time
+	JakartaHour int64
+	Made        time.Time
+	Deadline    time.Time
+	Yesterday   time.Time
+	Reminder    time.Time
+	Age         time.Duration
+	Overdue     bool
+}
+
+func TestTimeFunctions(t *testing.T) {
+	grl := `
+rule Times "time functions" {
+	when
+		!Event.Checked
+	then
+		Event.Checked = true;
+		Event.Now = Now();
+		Event.JakartaHour = GetTimeHour(NowIn("Asia/Jakarta"));
+		Event.Made = MakeTimeIn(2025, 1, 31, 23, 0, 0, "Asia/Jakarta");
+		Event.Deadline = AddDays(Event.Created, 30);
+		Event.Yesterday = AddDays(Event.Created, -1);
+		Event.Reminder = AddDuration(Event.Created, ParseDuration("36h"));
+		Event.Age = DurationBetween(Event.Created, Now());
+		Event.Overdue = DurationBetween(Event.Created, Now()) > ParseDuration("720h");
+}`
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+	testData := []struct {
+		name        string
+		now         time.Time
+		created     time.Time
+		jakartaHour int64
+		deadline    time.Time
+		yesterday   time.Time
+		age         time.Duration
+		overdue     bool
+	}{
+		{"it is already the next day in Jakarta", time.Date(2025, time.January, 31, 20, 0, 0, 0, time.UTC), time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC),
+			3, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, time.November, 30, 0, 0, 0, 0, time.UTC), 61*24*time.Hour + 20*time.Hour, true},
+		{"the days cross a leap day", time.Date(2024, time.February, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			7, time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), 27 * 24 * time.Hour, false},
+		{"exactly 720h is not overdue", time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			7, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), 720 * time.Hour, false},
+		{"a creation in the future gives a negative age", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC),
+			7, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), -24 * time.Hour, false},
+	}
+	for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+		for _, td := range testData {
+			event := &TimedEvent{Created: td.created}
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Event", event))
+			eng := engine.NewGruleEngine()
+			eng.Clock = fixedClock(td.now)
+			assert.NoError(t, eng.Execute(dataCtx, variant.New()))
+
+			assert.True(t, event.Checked, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.now, event.Now, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.jakartaHour, event.JakartaHour, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, time.Date(2025, time.January, 31, 23, 0, 0, 0, jakarta), event.Made, "%s : %s", variant.Name, td.name)
+			assert.True(t, event.Made.Equal(time.Date(2025, time.January, 31, 16, 0, 0, 0, time.UTC)), "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.deadline, event.Deadline, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.yesterday, event.Yesterday, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.created.Add(36*time.Hour), event.Reminder, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.age, event.Age, "%s : %s", variant.Name, td.name)
+			assert.Equal(t, td.overdue, event.Overdue, "%s : %s", variant.Name, td.name)
+		}
+	}
+}
+
+func TestTimeFunctions_Errors(t *testing.T) {
+	testData := []struct {
+		then    string
+		message string
+	}{
+		{`Event.Now = NowIn("Mars/Olympus");`, "unknown time zone Mars/Olympus"},
+		{`Event.Made = MakeTimeIn(2025, 1, 1, 0, 0, 0, "Mars/Olympus");`, "unknown time zone Mars/Olympus"},
+		{`Event.Age = ParseDuration("a week");`, `invalid duration "a week"`},
+	}
+	for _, td := range testData {
+		grl := `rule Invalid "invalid" { when !Event.Checked then Event.Checked = true; ` + td.then + ` }`
+		for _, variant := range buildKnowledgeBaseVariants(t, grl) {
+			dataCtx := ast.NewDataContext()
+			assert.NoError(t, dataCtx.Add("Event", &TimedEvent{}))
+			assert.ErrorContains(t, engine.NewGruleEngine().Execute(dataCtx, variant.New()), td.message, "%s : %s", variant.Name, td.then)
+		}
+	}
+}
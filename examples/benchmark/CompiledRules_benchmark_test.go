@@ -0,0 +1,105 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package benchmark
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+/**
+  Benchmarking the compiled rule conditions against the interpreter.
+  800 rules are evaluated on every execution while only one of them matches the fact.
+*/
+
+type PricingFact struct {
+	Amount   float64
+	Quantity int
+	Category string
+	Member   bool
+	Discount float64
+}
+
+func makePricingRules(ruleCount int) string {
+	buff := &bytes.Buffer{}
+	for i := 0; i < ruleCount; i++ {
+		buff.WriteString(fmt.Sprintf(`
+rule Pricing%d "discount of category %d" {
+	when
+		Fact.Member && Fact.Discount == 0 && (Fact.Amount * 1.1 > %d.5 || Fact.Quantity + %d >= 10) && Fact.Category == "category-%d"
+	then
+		Fact.Discount = Fact.Amount / 10 + %d;
+}
+`, i, i, i, i, i, i))
+	}
+
+	return buff.String()
+}
+
+func Benchmark_Grule_Compiled_Rules(b *testing.B) {
+	// the two sides are built from their own blueprint, so they share no rule entry.
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	rules := makePricingRules(800)
+	for _, name := range []string{"interpreted_test", "compiled_test"} {
+		err := rb.BuildRuleFromResource(name, "0.1.1", pkg.NewBytesResource([]byte(rules)))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	lib.GetKnowledgeBase("compiled_test", "0.1.1").Compile()
+	interpreted, err := lib.NewKnowledgeBaseInstance("interpreted_test", "0.1.1")
+	if err != nil {
+		b.Fatal(err)
+	}
+	compiled, err := lib.NewKnowledgeBaseInstance("compiled_test", "0.1.1")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if interpreted.IsCompiled() || !compiled.IsCompiled() {
+		b.Fatal("the interpreted knowledge base must not be compiled, the compiled one must be")
+	}
+
+	modes := []struct {
+		name string
+		kb   *ast.KnowledgeBase
+	}{
+		{"interpreter", interpreted},
+		{"compiled", compiled},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fact := &PricingFact{Amount: 120, Quantity: 3, Category: "category-400", Member: true}
+				dataCtx := ast.NewDataContext()
+				err := dataCtx.Add("Fact", fact)
+				if err != nil {
+					b.Fatal(err)
+				}
+				e := engine.NewGruleEngine()
+				err = e.Execute(dataCtx, mode.kb)
+				if err != nil || fact.Discount != 412 {
+					b.Fatalf("unexpected result, discount %f, error %v", fact.Discount, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package benchmark
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"github.com/hyperjumptech/grule-rule-engine/builder"
+	"github.com/hyperjumptech/grule-rule-engine/engine"
+	"github.com/hyperjumptech/grule-rule-engine/pkg"
+)
+
+/**
+  Benchmarking the incremental matching against evaluating every rule in every cycle.
+  A single rule keeps incrementing a counter for 100 cycles while the other rules depend on a field that never change,
+  so the incremental matching only need to re-evaluate the counting rule in each cycle.
+*/
+
+type CounterFact struct {
+	Count    int
+	Category string
+}
+
+func makeCounterRules(ruleCount int) string {
+	buff := &bytes.Buffer{}
+	buff.WriteString(`
+rule CountUp "keep counting up to 100" {
+	when
+		Fact.Count < 100
+	then
+		Fact.Count = Fact.Count + 1;
+}
+`)
+	for i := 0; i < ruleCount; i++ {
+		buff.WriteString(fmt.Sprintf(`
+rule Category%d "match category %d" {
+	when
+		Fact.Category == "category-%d"
+	then
+		Fact.Count = 0;
+}
+`, i, i, i))
+	}
+
+	return buff.String()
+}
+
+func Benchmark_Grule_Incremental_Matching(b *testing.B) {
+	lib := ast.NewKnowledgeLibrary()
+	rb := builder.NewRuleBuilder(lib)
+	err := rb.BuildRuleFromResource("incremental_test", "0.1.1", pkg.NewBytesResource([]byte(makeCounterRules(500))))
+	if err != nil {
+		b.Fatal(err)
+	}
+	kb, err := lib.NewKnowledgeBaseInstance("incremental_test", "0.1.1")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	modes := []struct {
+		name    string
+		disable bool
+	}{
+		{"incremental", false},
+		{"full evaluation", true},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fact := &CounterFact{Category: "none"}
+				dataCtx := ast.NewDataContext()
+				err := dataCtx.Add("Fact", fact)
+				if err != nil {
+					b.Fatal(err)
+				}
+				e := engine.NewGruleEngine()
+				e.DisableIncrementalMatching = mode.disable
+				err = e.Execute(dataCtx, kb)
+				if err != nil || fact.Count != 100 {
+					b.Fatalf("unexpected result, count %d, error %v", fact.Count, err)
+				}
+			}
+		})
+	}
+}
@@ -25,11 +25,14 @@ import (
 
 // GruleJSON represents a rule in JSON format
 type GruleJSON struct {
-	Name        string        `json:"name"`
-	Description string        `json:"desc"`
-	Salience    int           `json:"salience"`
-	When        interface{}   `json:"when"`
-	Then        []interface{} `json:"then"`
+	Name         string        `json:"name"`
+	Description  string        `json:"desc"`
+	Salience     int           `json:"salience"`
+	AgendaGroup  string        `json:"agendaGroup"`
+	NoLoop       bool          `json:"noLoop"`
+	LockOnActive bool          `json:"lockOnActive"`
+	When         interface{}   `json:"when"`
+	Then         []interface{} `json:"then"`
 }
 
 // JSONResource will parse rules in JSON format from underlying resource provider.
@@ -192,6 +195,16 @@ func parseRule(rule *GruleJSON) (string, error) {
 	stringBuilder.WriteString(strconv.Quote(rule.Description))
 	stringBuilder.WriteString(" salience ")
 	stringBuilder.WriteString(strconv.Itoa(rule.Salience))
+	if len(rule.AgendaGroup) > 0 {
+		stringBuilder.WriteString(" agenda-group ")
+		stringBuilder.WriteString(strconv.Quote(rule.AgendaGroup))
+	}
+	if rule.NoLoop {
+		stringBuilder.WriteString(" no-loop")
+	}
+	if rule.LockOnActive {
+		stringBuilder.WriteString(" lock-on-active")
+	}
 	stringBuilder.WriteString(" {\n    when\n        ")
 	when, err := parseWhen(rule.When)
 	if err != nil {
@@ -262,3 +262,30 @@ func TestJSONBigIntConversion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedBigIntConversion, rs)
 }
+
+const jsonDataRuleAttributes = `{
+    "name": "SpeedUp",
+    "desc": "When testcar is speeding up we keep increase the speed.",
+    "salience": 10,
+    "agendaGroup": "driving",
+    "noLoop": true,
+    "lockOnActive": true,
+    "when": "TestCar.SpeedUp == true",
+    "then": [
+        "TestCar.Speed = TestCar.Speed + TestCar.SpeedIncrement"
+    ]
+}`
+
+const expectedRuleAttributes = `rule SpeedUp "When testcar is speeding up we keep increase the speed." salience 10 agenda-group "driving" no-loop lock-on-active {
+    when
+        TestCar.SpeedUp == true
+    then
+        TestCar.Speed = TestCar.Speed + TestCar.SpeedIncrement;
+}
+`
+
+func TestJSONRuleAttributes(t *testing.T) {
+	rs, err := ParseJSONRule([]byte(jsonDataRuleAttributes))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRuleAttributes, rs)
+}
@@ -0,0 +1,207 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// DiagnosticSeverity tells how serious the problem reported by a Diagnostic is.
+type DiagnosticSeverity int
+
+const (
+	// SeverityError is a problem that prevents the rules to be built.
+	SeverityError DiagnosticSeverity = iota
+	// SeverityWarning is a problem that does not prevent the rules to be built, but they may not behave as expected.
+	SeverityWarning
+)
+
+// String returns the name of the severity, as printed in the compiler-style output.
+func (s DiagnosticSeverity) String() string {
+	if s == SeverityWarning {
+
+		return "warning"
+	}
+
+	return "error"
+}
+
+// Codes of the Diagnostic, telling which stage of the build found the problem.
+const (
+	// CodeResourceError is a resource that can not be loaded.
+	CodeResourceError = "resource"
+	// CodeLexerError is a text that is not a GRL token.
+	CodeLexerError = "lexer"
+	// CodeSyntaxError is a GRL that does not follow the grammar.
+	CodeSyntaxError = "syntax"
+	// CodeSemanticError is a GRL that follows the grammar but can not be made into rules, eg. a duplicated rule name.
+	CodeSemanticError = "semantic"
+)
+
+// Diagnostic is a problem found while building rules from a resource, located in the GRL of the resource.
+// Lines start from 1 and columns start from 0, the end position is right after the last character of the problem.
+// The positions are all 0 if the problem is not located in the GRL.
+type Diagnostic struct {
+	Resource     string
+	StartLine    int
+	StartColumn  int
+	EndLine      int
+	EndColumn    int
+	Severity     DiagnosticSeverity
+	Code         string
+	Message      string
+	SuggestedFix string
+	// Err is the error the diagnostic is made of.
+	Err error
+}
+
+// Error returns the diagnostic in the compiler-style "resource:line:column: severity[code]: message" form.
+// The column is printed starting from 1, as editors do.
+func (d *Diagnostic) Error() string {
+	var sb strings.Builder
+	sb.WriteString(d.Resource)
+	if d.StartLine > 0 {
+		sb.WriteString(fmt.Sprintf(":%d:%d", d.StartLine, d.StartColumn+1))
+	}
+	sb.WriteString(fmt.Sprintf(": %s[%s]: %s", d.Severity.String(), d.Code, d.Message))
+	if len(d.SuggestedFix) > 0 {
+		sb.WriteString(fmt.Sprintf(" (%s)", d.SuggestedFix))
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns the error the diagnostic is made of.
+func (d *Diagnostic) Unwrap() error {
+
+	return d.Err
+}
+
+// Diagnostics is the list of problems found while building rules. It is returned as an error by
+// RuleBuilder.BuildRuleFromResources when one of them is an error, the warnings of a successful build are returned
+// by RuleBuilder.BuildRuleFromResourcesWithDiagnostics.
+type Diagnostics []*Diagnostic
+
+// HasError check if one of the diagnostics has the SeverityError.
+func (d Diagnostics) HasError() bool {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Warnings returns the diagnostics having the SeverityWarning.
+func (d Diagnostics) Warnings() Diagnostics {
+	warnings := make(Diagnostics, 0)
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityWarning {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+
+	return warnings
+}
+
+// Error returns all the diagnostics in the compiler-style form, one per line.
+func (d Diagnostics) Error() string {
+	lines := make([]string, len(d))
+	for i, diagnostic := range d {
+		lines[i] = diagnostic.Error()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the diagnostics, so errors.As and errors.Is can look into them.
+func (d Diagnostics) Unwrap() []error {
+	errs := make([]error, len(d))
+	for i, diagnostic := range d {
+		errs[i] = diagnostic
+	}
+
+	return errs
+}
+
+// DiagnosticsOf returns the diagnostics reported in err, or a single diagnostic of the resource if err is not
+// returned by the GRL parsing.
+func DiagnosticsOf(err error, resource string) Diagnostics {
+	var diagnostics Diagnostics
+	if errors.As(err, &diagnostics) {
+
+		return diagnostics
+	}
+	var reporter *GruleErrorReporter
+	if errors.As(err, &reporter) {
+
+		return reporter.Diagnostics
+	}
+
+	return Diagnostics{{
+		Resource: resource,
+		Severity: SeverityError,
+		Code:     CodeResourceError,
+		Message:  err.Error(),
+		Err:      err,
+	}}
+}
+
+// TokenEnd returns the position right after the last character of the token.
+func TokenEnd(token antlr.Token) (line, column int) {
+	text := token.GetText()
+	if lastNewLine := strings.LastIndex(text, "\n"); lastNewLine >= 0 {
+
+		return token.GetLine() + strings.Count(text, "\n"), len(text) - lastNewLine - 1
+	}
+
+	return token.GetLine(), token.GetColumn() + len(text)
+}
+
+var (
+	missingTokenMessage    = regexp.MustCompile(`^missing (.+) at (.+)$`)
+	extraneousInputMessage = regexp.MustCompile(`^extraneous input (.+) expecting (.+)$`)
+	mismatchedInputMessage = regexp.MustCompile(`^mismatched input (.+) expecting (.+)$`)
+	tokenRecognitionError  = regexp.MustCompile(`^token recognition error at: (.+)$`)
+)
+
+// suggestFix returns a fix for the syntax error message given by the parser, empty if there is none.
+func suggestFix(msg string) string {
+	if match := missingTokenMessage.FindStringSubmatch(msg); match != nil {
+
+		return fmt.Sprintf("insert %s before %s", match[1], match[2])
+	}
+	if match := extraneousInputMessage.FindStringSubmatch(msg); match != nil {
+
+		return fmt.Sprintf("remove %s", match[1])
+	}
+	if match := mismatchedInputMessage.FindStringSubmatch(msg); match != nil {
+
+		return fmt.Sprintf("replace %s with %s", match[1], match[2])
+	}
+	if match := tokenRecognitionError.FindStringSubmatch(msg); match != nil {
+
+		return fmt.Sprintf("remove %s", match[1])
+	}
+
+	return ""
+}
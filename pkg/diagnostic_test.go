@@ -0,0 +1,46 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestFix(t *testing.T) {
+	assert.Equal(t, "insert ';' before '}'", suggestFix("missing ';' at '}'"))
+	assert.Equal(t, "remove 'then'", suggestFix("extraneous input 'then' expecting {'(', 'true'}"))
+	assert.Equal(t, "replace 'x' with '{'", suggestFix("mismatched input 'x' expecting '{'"))
+	assert.Equal(t, "remove '#'", suggestFix("token recognition error at: '#'"))
+	assert.Equal(t, "", suggestFix("no viable alternative at input 'rule'"))
+}
+
+func TestDiagnostics(t *testing.T) {
+	diagnostics := Diagnostics{
+		{Resource: "rules.grl", StartLine: 2, StartColumn: 4, Severity: SeverityWarning, Code: "semantic", Message: "unused"},
+		{Resource: "other.grl", Severity: SeverityError, Code: CodeResourceError, Message: "not found"},
+	}
+	assert.True(t, diagnostics.HasError())
+	assert.False(t, diagnostics[:1].HasError())
+	assert.Equal(t, "rules.grl:2:5: warning[semantic]: unused\nother.grl: error[resource]: not found", diagnostics.Error())
+	assert.Equal(t, diagnostics[:1], diagnostics.Warnings())
+
+	// the warnings reported are not errors.
+	reporter := &GruleErrorReporter{Resource: "rules.grl"}
+	reporter.AddDiagnostic(&Diagnostic{Severity: SeverityWarning, Code: "semantic", Message: "unused"})
+	assert.False(t, reporter.HasError())
+	assert.Equal(t, "rules.grl", reporter.Diagnostics[0].Resource)
+}
@@ -19,20 +19,114 @@ import (
 	"github.com/antlr4-go/antlr/v4"
 )
 
+// GrlSyntaxError is an error found by the lexer or the parser at a position of a GRL resource.
+type GrlSyntaxError struct {
+	// Resource describes the resource the GRL is loaded from, empty if unknown.
+	Resource string
+	// Line is the line of the error, starting from 1.
+	Line int
+	// Column is the column of the error in its line, starting from 0.
+	Column int
+	// Message is the description of the error given by the parser.
+	Message string
+}
+
+// Error returns the position and the description of the syntax error.
+func (e *GrlSyntaxError) Error() string {
+
+	return fmt.Sprintf("grl error on %d:%d %s", e.Line, e.Column, e.Message)
+}
+
 // GruleErrorReporter is an implementation of ErrorListener interface by antlr. The purpose is to capture errors during lexer tokenization and parsing.
 type GruleErrorReporter struct {
 	*antlr.DefaultErrorListener // Embed default which ensures we fit the interface
 	Errors                      []error
+	// Resource describes the resource being parsed, it is set into the reported GrlSyntaxError and Diagnostic.
+	Resource string
+	// Diagnostics has a Diagnostic for each of the Errors, locating them in the resource when possible.
+	Diagnostics Diagnostics
 }
 
 // AddError simply add an error into this reporter
 func (c *GruleErrorReporter) AddError(err error) {
 	c.Errors = append(c.Errors, err)
+	c.Diagnostics = append(c.Diagnostics, &Diagnostic{
+		Resource: c.Resource,
+		Severity: SeverityError,
+		Code:     CodeSemanticError,
+		Message:  err.Error(),
+		Err:      err,
+	})
+}
+
+// AddErrorAt add an error found in the GRL from the start token to the stop token into this reporter.
+// The stop token may be nil if the error is located at the start token.
+func (c *GruleErrorReporter) AddErrorAt(err error, start, stop antlr.Token) {
+	if start == nil {
+		c.AddError(err)
+
+		return
+	}
+	if stop == nil || stop.GetTokenIndex() < start.GetTokenIndex() {
+		stop = start
+	}
+	endLine, endColumn := TokenEnd(stop)
+	c.Errors = append(c.Errors, err)
+	c.Diagnostics = append(c.Diagnostics, &Diagnostic{
+		Resource:    c.Resource,
+		StartLine:   start.GetLine(),
+		StartColumn: start.GetColumn(),
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+		Severity:    SeverityError,
+		Code:        CodeSemanticError,
+		Message:     err.Error(),
+		Err:         err,
+	})
+}
+
+// AddDiagnostic add the problem described by the diagnostic into this reporter. The diagnostic is located in
+// the reporter's resource if it has no resource of its own. Only the diagnostics of SeverityError are errors,
+// the warnings are kept in the Diagnostics.
+func (c *GruleErrorReporter) AddDiagnostic(diagnostic *Diagnostic) {
+	if len(diagnostic.Resource) == 0 {
+		diagnostic.Resource = c.Resource
+	}
+	if diagnostic.Severity == SeverityError {
+		c.Errors = append(c.Errors, diagnostic)
+	}
+	c.Diagnostics = append(c.Diagnostics, diagnostic)
 }
 
 // SyntaxError call back which will be called upon parsing error
 func (c *GruleErrorReporter) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
-	c.Errors = append(c.Errors, fmt.Errorf("grl error on %d:%d %s", line, column, msg))
+	syntaxErr := &GrlSyntaxError{
+		Resource: c.Resource,
+		Line:     line,
+		Column:   column,
+		Message:  msg,
+	}
+	c.Errors = append(c.Errors, syntaxErr)
+
+	diagnostic := &Diagnostic{
+		Resource:     c.Resource,
+		StartLine:    line,
+		StartColumn:  column,
+		EndLine:      line,
+		EndColumn:    column + 1,
+		Severity:     SeverityError,
+		Code:         CodeSyntaxError,
+		Message:      msg,
+		SuggestedFix: suggestFix(msg),
+		Err:          syntaxErr,
+	}
+	if _, isLexer := recognizer.(antlr.Lexer); isLexer {
+		diagnostic.Code = CodeLexerError
+	}
+	if token, ok := offendingSymbol.(antlr.Token); ok && token.GetTokenType() != antlr.TokenEOF {
+		diagnostic.EndLine, diagnostic.EndColumn = TokenEnd(token)
+	}
+	c.Diagnostics = append(c.Diagnostics, diagnostic)
 }
 
 // HasError check if this reporter has an error
@@ -45,8 +139,19 @@ func (c *GruleErrorReporter) HasError() bool {
 func (c *GruleErrorReporter) Error() string {
 	if c.HasError() {
 
-		return fmt.Sprintf("got %d error(s) in grl the script", len(c.Errors))
+		if len(c.Diagnostics) == 0 {
+
+			return fmt.Sprintf("got %d error(s) in grl the script", len(c.Errors))
+		}
+
+		return fmt.Sprintf("got %d error(s) in grl the script\n%s", len(c.Errors), c.Diagnostics.Error())
 	}
 
 	return fmt.Sprintf("no error in grl script")
 }
+
+// Unwrap returns the reported errors, so errors.As and errors.Is can look into them.
+func (c *GruleErrorReporter) Unwrap() []error {
+
+	return c.Errors
+}
@@ -20,6 +20,35 @@ import (
 	"time"
 )
 
+// TypeMismatchError is returned when an operation can not be applied to the data type of one of its operands.
+type TypeMismatchError struct {
+	// Operation is the operation that failed, eg. "addition" or "GT comparison".
+	Operation string
+	// Left is the data type of the left operand.
+	Left reflect.Kind
+	// Right is the data type of the right operand, reflect.Invalid if the operation has only one operand.
+	Right reflect.Kind
+	// Kind is the data type of the operand the operation can not be applied to.
+	Kind reflect.Kind
+}
+
+// Error returns the description of the mismatch.
+func (e *TypeMismatchError) Error() string {
+
+	return fmt.Sprintf("can not use data type of %s in %s", e.Kind.String(), e.Operation)
+}
+
+// newTypeMismatchError creates a TypeMismatchError for the operand of an operation.
+func newTypeMismatchError(operation string, left, right, operand reflect.Value) *TypeMismatchError {
+
+	return &TypeMismatchError{
+		Operation: operation,
+		Left:      left.Kind(),
+		Right:     right.Kind(),
+		Kind:      operand.Kind(),
+	}
+}
+
 // EvaluateMultiplication will evaluate multiplication operation over two value
 func EvaluateMultiplication(left, right reflect.Value) (reflect.Value, error) {
 	left, right = GetValueElem(left), GetValueElem(right)
@@ -41,7 +70,7 @@ func EvaluateMultiplication(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) * rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not multiply data type of %s", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("multiplication", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -60,7 +89,7 @@ func EvaluateMultiplication(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) * rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not multiply data type of %s", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("multiplication", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -79,11 +108,11 @@ func EvaluateMultiplication(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue * rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not multiply data type of %s", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("multiplication", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not multiply data type of %s", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("multiplication", left, right, left)
 	}
 }
 
@@ -108,7 +137,7 @@ func EvaluateDivision(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) / rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("division", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -127,7 +156,7 @@ func EvaluateDivision(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) / rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("division", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -146,11 +175,11 @@ func EvaluateDivision(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue / rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("division", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("division", left, right, left)
 	}
 }
 
@@ -171,7 +200,7 @@ func EvaluateModulo(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue % int64(rightValue)), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in modulo", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("modulo", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -186,11 +215,11 @@ func EvaluateModulo(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(int64(leftValue) % int64(rightValue)), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in modulo", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("modulo", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in modulo", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("modulo", left, right, left)
 	}
 }
 
@@ -228,7 +257,7 @@ func EvaluateAddition(left, right reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(fmt.Sprintf("%s%s", leftValue, rightValue.Format(time.RFC3339))), nil
 			}
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in addition", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("addition", left, right, right)
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		leftValue := left.Int()
@@ -251,7 +280,7 @@ func EvaluateAddition(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) + rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in addition", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("addition", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -274,7 +303,7 @@ func EvaluateAddition(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) + rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("addition", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -297,11 +326,11 @@ func EvaluateAddition(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue + rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("addition", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in division", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("addition", left, right, left)
 	}
 }
 
@@ -326,7 +355,7 @@ func EvaluateSubtraction(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) - rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in subtraction", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("subtraction", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -345,7 +374,7 @@ func EvaluateSubtraction(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) - rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in subtraction", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("subtraction", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -364,11 +393,11 @@ func EvaluateSubtraction(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue - rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in subtraction", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("subtraction", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in subtraction", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("subtraction", left, right, left)
 	}
 }
 
@@ -389,7 +418,7 @@ func EvaluateBitAnd(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue & int64(rightValue)), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in bitwise AND operation", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("bitwise AND operation", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -404,11 +433,11 @@ func EvaluateBitAnd(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue & rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in bitwise AND operation", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("bitwise AND operation", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in bitwise AND operation", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("bitwise AND operation", left, right, left)
 	}
 }
 
@@ -429,7 +458,7 @@ func EvaluateBitOr(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue | int64(rightValue)), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in bitwise OR operation", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("bitwise OR operation", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -444,11 +473,11 @@ func EvaluateBitOr(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue | rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in bitwise OR operation", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("bitwise OR operation", left, right, right)
 		}
 	default:
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in bitwise OR operation", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("bitwise OR operation", left, right, left)
 	}
 }
 
@@ -465,7 +494,7 @@ func EvaluateGreaterThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue > rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not compare data type of string to %s in GT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GT comparison", left, right, right)
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		leftValue := left.Int()
@@ -484,7 +513,7 @@ func EvaluateGreaterThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) > rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GT comparison", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -503,7 +532,7 @@ func EvaluateGreaterThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) > rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GT comparison", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -522,7 +551,7 @@ func EvaluateGreaterThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue > rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GT comparison", left, right, right)
 		}
 	default:
 		if left.Type().String() == "time.Time" && right.Type().String() == "time.Time" {
@@ -532,7 +561,7 @@ func EvaluateGreaterThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue.After(rightValue)), nil
 		}
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GT comparison", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("GT comparison", left, right, left)
 	}
 }
 
@@ -549,7 +578,7 @@ func EvaluateLesserThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue < rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not compare data type of string to %s in LT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LT comparison", left, right, right)
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		leftValue := left.Int()
@@ -568,7 +597,7 @@ func EvaluateLesserThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) < rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LT comparison", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -587,7 +616,7 @@ func EvaluateLesserThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) < rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LT comparison", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -606,7 +635,7 @@ func EvaluateLesserThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue < rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LT comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LT comparison", left, right, right)
 		}
 	default:
 		if left.Type().String() == "time.Time" && right.Type().String() == "time.Time" {
@@ -616,7 +645,7 @@ func EvaluateLesserThan(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue.Before(rightValue)), nil
 		}
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LT comparison", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("LT comparison", left, right, left)
 	}
 }
 
@@ -633,7 +662,7 @@ func EvaluateGreaterThanEqual(left, right reflect.Value) (reflect.Value, error)
 			return reflect.ValueOf(leftValue >= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not compare data type of string to %s in GTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GTE comparison", left, right, right)
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		leftValue := left.Int()
@@ -652,7 +681,7 @@ func EvaluateGreaterThanEqual(left, right reflect.Value) (reflect.Value, error)
 			return reflect.ValueOf(float64(leftValue) >= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GTE comparison", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -671,7 +700,7 @@ func EvaluateGreaterThanEqual(left, right reflect.Value) (reflect.Value, error)
 			return reflect.ValueOf(float64(leftValue) >= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GTE comparison", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -690,7 +719,7 @@ func EvaluateGreaterThanEqual(left, right reflect.Value) (reflect.Value, error)
 			return reflect.ValueOf(leftValue >= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("GTE comparison", left, right, right)
 		}
 	default:
 		if left.Type().String() == "time.Time" && right.Type().String() == "time.Time" {
@@ -700,7 +729,7 @@ func EvaluateGreaterThanEqual(left, right reflect.Value) (reflect.Value, error)
 			return reflect.ValueOf(leftValue.After(rightValue) || leftValue == rightValue), nil
 		}
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in GTE comparison", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("GTE comparison", left, right, left)
 	}
 }
 
@@ -717,7 +746,7 @@ func EvaluateLesserThanEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue <= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not compare data type of string to %s in LTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LTE comparison", left, right, right)
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		leftValue := left.Int()
@@ -736,7 +765,7 @@ func EvaluateLesserThanEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) <= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LTE comparison", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -755,7 +784,7 @@ func EvaluateLesserThanEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) <= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LTE comparison", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -774,7 +803,7 @@ func EvaluateLesserThanEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue <= rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LTE comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("LTE comparison", left, right, right)
 		}
 	default:
 		if left.Type().String() == "time.Time" && right.Type().String() == "time.Time" {
@@ -784,7 +813,7 @@ func EvaluateLesserThanEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue.Before(rightValue) || leftValue == rightValue), nil
 		}
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in LTE comparison", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("LTE comparison", left, right, left)
 	}
 }
 
@@ -827,7 +856,7 @@ func EvaluateEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) == rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -846,7 +875,7 @@ func EvaluateEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) == rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -865,7 +894,7 @@ func EvaluateEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue == rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, right)
 		}
 	default:
 		if left.Type().String() == "time.Time" && right.Type().String() == "time.Time" {
@@ -875,7 +904,7 @@ func EvaluateEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue == rightValue), nil
 		}
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, left)
 	}
 }
 
@@ -918,7 +947,7 @@ func EvaluateNotEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) != rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, right)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		leftValue := left.Uint()
@@ -937,7 +966,7 @@ func EvaluateNotEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(float64(leftValue) != rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, right)
 		}
 	case reflect.Float32, reflect.Float64:
 		leftValue := left.Float()
@@ -956,7 +985,7 @@ func EvaluateNotEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue != rightValue), nil
 		default:
 
-			return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", right.Kind().String())
+			return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, right)
 		}
 	default:
 		if left.Type().String() == "time.Time" && right.Type().String() == "time.Time" {
@@ -966,7 +995,7 @@ func EvaluateNotEqual(left, right reflect.Value) (reflect.Value, error) {
 			return reflect.ValueOf(leftValue != rightValue), nil
 		}
 
-		return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in EQ comparison", left.Kind().String())
+		return reflect.ValueOf(nil), newTypeMismatchError("EQ comparison", left, right, left)
 	}
 }
 
@@ -980,7 +1009,7 @@ func EvaluateLogicAnd(left, right reflect.Value) (reflect.Value, error) {
 		return reflect.ValueOf(leftValue && rightValue), nil
 	}
 
-	return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in Logical AND comparison", left.Kind().String())
+	return reflect.ValueOf(nil), newTypeMismatchError("Logical AND comparison", left, right, left)
 }
 
 // EvaluateLogicOr will evaluate LogicalOr operation over two value
@@ -993,7 +1022,7 @@ func EvaluateLogicOr(left, right reflect.Value) (reflect.Value, error) {
 		return reflect.ValueOf(leftValue || rightValue), nil
 	}
 
-	return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in Logical OR comparison", left.Kind().String())
+	return reflect.ValueOf(nil), newTypeMismatchError("Logical OR comparison", left, right, left)
 }
 
 // EvaluateLogicSingle will evaluate single expression value
@@ -1005,5 +1034,5 @@ func EvaluateLogicSingle(left reflect.Value) (reflect.Value, error) {
 		return reflect.ValueOf(leftValue), nil
 	}
 
-	return reflect.ValueOf(nil), fmt.Errorf("can not use data type of %s in Logical AND comparison", left.Kind().String())
+	return reflect.ValueOf(nil), newTypeMismatchError("Logical AND comparison", left, reflect.Value{}, left)
 }
@@ -567,3 +567,23 @@ func TestEvaluateNotEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeMismatchError(t *testing.T) {
+	_, err := EvaluateMultiplication(intVal, reflect.ValueOf("text"))
+	typeErr, ok := err.(*TypeMismatchError)
+	if !ok {
+		t.Fatalf("expected TypeMismatchError, got %v", err)
+	}
+	if typeErr.Operation != "multiplication" || typeErr.Left != reflect.Int || typeErr.Right != reflect.String || typeErr.Kind != reflect.String {
+		t.Errorf("unexpected TypeMismatchError %+v", typeErr)
+	}
+	if typeErr.Error() != "can not use data type of string in multiplication" {
+		t.Errorf("unexpected message %s", typeErr.Error())
+	}
+
+	_, err = EvaluateLogicSingle(intVal)
+	typeErr, ok = err.(*TypeMismatchError)
+	if !ok || typeErr.Right != reflect.Invalid || typeErr.Kind != reflect.Int {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,341 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/hyperjumptech/grule-rule-engine/logger"
+)
+
+const (
+	// DefaultWatchDebounce is the time a WatchingFileResourceBundle waits for the changes to settle by default.
+	DefaultWatchDebounce = 100 * time.Millisecond
+	// DefaultWatchPollInterval is the time between two scans of a WatchingFileResourceBundle by default.
+	DefaultWatchPollInterval = time.Second
+)
+
+// ResourceChangeKind tells how a resource is changed.
+type ResourceChangeKind int
+
+const (
+	// ResourceAdded is the change of a resource that did not exist.
+	ResourceAdded ResourceChangeKind = iota
+	// ResourceModified is the change of the content of a resource.
+	ResourceModified
+	// ResourceDeleted is the change of a resource that does not exist anymore.
+	ResourceDeleted
+)
+
+// String will state the kind of change.
+func (kind ResourceChangeKind) String() string {
+	switch kind {
+	case ResourceAdded:
+
+		return "added"
+	case ResourceModified:
+
+		return "modified"
+	case ResourceDeleted:
+
+		return "deleted"
+	}
+
+	return fmt.Sprintf("ResourceChangeKind(%d)", int(kind))
+}
+
+// ResourceChange is the change of a file resource, identified by its absolute path.
+type ResourceChange struct {
+	Kind ResourceChangeKind
+	Path string
+}
+
+// String will state the change.
+func (change ResourceChange) String() string {
+
+	return fmt.Sprintf("%s %s", change.Path, change.Kind)
+}
+
+// NewWatchingFileResourceBundle creates new instance of WatchingFileResourceBundle struct,
+// basePath and pathPattern are the same as the ones of NewFileResourceBundle.
+func NewWatchingFileResourceBundle(basePath string, pathPattern ...string) *WatchingFileResourceBundle {
+
+	return &WatchingFileResourceBundle{
+		FileResourceBundle: FileResourceBundle{
+			BasePath:    basePath,
+			PathPattern: pathPattern,
+		},
+	}
+}
+
+// WatchingFileResourceBundle is a FileResourceBundle that can be watched for the changes of its files.
+// The operating system tells the changes when it can, e.g. with inotify on Linux, and the files are also scanned
+// regularly, which catches the changes it does not tell, e.g. on the file systems it can not watch.
+type WatchingFileResourceBundle struct {
+	FileResourceBundle
+	// Debounce is the time without any change the bundle waits for before telling the changes, so a burst of edits
+	// is told at once. DefaultWatchDebounce is used when zero.
+	Debounce time.Duration
+	// PollInterval is the time between two scans of the files. DefaultWatchPollInterval is used when zero.
+	PollInterval time.Duration
+}
+
+// fileState is the state of a file resource, as seen by the last scan.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	sum     [sha256.Size]byte
+}
+
+// Watch will scan the files of the bundle, then send the changes of the files matching the PathPattern
+// to the returned channel, until the context is done. A file is modified when its content is changed,
+// touching it is not a change. The channel is closed once the context is done.
+func (bundle *WatchingFileResourceBundle) Watch(ctx context.Context) (<-chan []ResourceChange, error) {
+	files, dirs, err := bundle.scan(nil)
+	if err != nil {
+
+		return nil, err
+	}
+	notifier, err := newNotifier()
+	if err != nil {
+		logger.Log.Warnf("Watching %s with polling only, file system notifications are not available. got %s", bundle.BasePath, err.Error())
+		notifier = &pollingNotifier{}
+	}
+	watchDirs(notifier, dirs)
+
+	changes := make(chan []ResourceChange)
+	go bundle.watch(ctx, notifier, files, changes)
+
+	return changes, nil
+}
+
+func (bundle *WatchingFileResourceBundle) watch(ctx context.Context, notifier notifier, files map[string]fileState, changes chan<- []ResourceChange) {
+	defer close(changes)
+	defer func() {
+		_ = notifier.close()
+	}()
+	debounce := bundle.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	pollInterval := bundle.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultWatchPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	settle := time.NewTimer(debounce)
+	settle.Stop()
+	defer settle.Stop()
+	// pending tells whether settle is running, the changes found by polling do not delay it.
+	pending := false
+
+	for {
+		select {
+		case <-ctx.Done():
+
+			return
+		case <-notifier.changed():
+			settle.Reset(debounce)
+			pending = true
+		case <-ticker.C:
+			if pending {
+
+				continue
+			}
+			scanned, _, err := bundle.scan(files)
+			if err == nil && len(diffFileStates(files, scanned)) > 0 {
+				settle.Reset(debounce)
+				pending = true
+			}
+		case <-settle.C:
+			pending = false
+			scanned, dirs, err := bundle.scan(files)
+			if err != nil {
+				logger.Log.Errorf("Scanning %s failed. got %s", bundle.BasePath, err.Error())
+
+				continue
+			}
+			watchDirs(notifier, dirs)
+			diff := diffFileStates(files, scanned)
+			files = scanned
+			if len(diff) == 0 {
+
+				continue
+			}
+			logger.Log.Debugf("Resources changed under %s : %v", bundle.BasePath, diff)
+			select {
+			case changes <- diff:
+			case <-ctx.Done():
+
+				return
+			}
+		}
+	}
+}
+
+// scan will get the state of the files matching the PathPattern, and the directories under the BasePath.
+// The content of the files whose modification time and size are the same as in the previous scan is not read again.
+func (bundle *WatchingFileResourceBundle) scan(previous map[string]fileState) (map[string]fileState, []string, error) {
+	files := make(map[string]fileState)
+	basePath, err := filepath.Abs(bundle.BasePath)
+	if err != nil {
+
+		return nil, nil, err
+	}
+	dirs := []string{basePath}
+	for i := 0; i < len(dirs); i++ {
+		finfos, err := os.ReadDir(dirs[i])
+		if err != nil {
+
+			return nil, nil, err
+		}
+		for _, finfo := range finfos {
+			fulPath, _ := filepath.Abs(fmt.Sprintf("%s/%s", dirs[i], finfo.Name()))
+			if finfo.IsDir() {
+				dirs = append(dirs, fulPath)
+
+				continue
+			}
+			matched, err := bundle.matches(fulPath)
+			if err != nil {
+
+				return nil, nil, err
+			}
+			if !matched {
+
+				continue
+			}
+			state, ok, err := fileStateOf(fulPath, previous)
+			if err != nil {
+
+				return nil, nil, err
+			}
+			if ok {
+				files[fulPath] = state
+			}
+		}
+	}
+
+	return files, dirs, nil
+}
+
+func (bundle *WatchingFileResourceBundle) matches(path string) (bool, error) {
+	for _, pattern := range bundle.PathPattern {
+		matched, err := doublestar.PathMatch(pattern, path)
+		if err != nil || matched {
+
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+// fileStateOf will get the state of the file, false if it does not exist anymore.
+func fileStateOf(path string, previous map[string]fileState) (fileState, bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+
+		return fileState{}, false, nil
+	}
+	if err != nil {
+
+		return fileState{}, false, err
+	}
+	state := fileState{modTime: info.ModTime(), size: info.Size()}
+	if old, ok := previous[path]; ok && old.modTime.Equal(state.modTime) && old.size == state.size {
+
+		return old, true, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+
+		return fileState{}, false, nil
+	}
+	if err != nil {
+
+		return fileState{}, false, err
+	}
+	state.sum = sha256.Sum256(data)
+
+	return state, true, nil
+}
+
+// diffFileStates compares two scans, the changes are sorted by path.
+func diffFileStates(previous, current map[string]fileState) []ResourceChange {
+	changes := make([]ResourceChange, 0)
+	for path, state := range current {
+		old, ok := previous[path]
+		if !ok {
+			changes = append(changes, ResourceChange{Kind: ResourceAdded, Path: path})
+		} else if old.sum != state.sum {
+			changes = append(changes, ResourceChange{Kind: ResourceModified, Path: path})
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changes = append(changes, ResourceChange{Kind: ResourceDeleted, Path: path})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// notifier tells when the files of the watched directories may have changed.
+type notifier interface {
+	// add starts watching the directory, adding a directory already watched does nothing.
+	add(dir string) error
+	changed() <-chan struct{}
+	close() error
+}
+
+func watchDirs(notifier notifier, dirs []string) {
+	for _, dir := range dirs {
+		err := notifier.add(dir)
+		if err != nil {
+			logger.Log.Warnf("Watching directory %s failed, its changes are found by polling. got %s", dir, err.Error())
+		}
+	}
+}
+
+// pollingNotifier never tells any change, they are all found by polling.
+type pollingNotifier struct{}
+
+func (n *pollingNotifier) add(dir string) error {
+
+	return nil
+}
+
+func (n *pollingNotifier) changed() <-chan struct{} {
+
+	return nil
+}
+
+func (n *pollingNotifier) close() error {
+
+	return nil
+}
@@ -0,0 +1,82 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+//go:build linux
+
+package pkg
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE | unix.IN_ATTRIB |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF
+
+// newNotifier creates a notifier using inotify. The events are not read one by one, any of them means the
+// watched directories may have changed.
+func newNotifier() (notifier, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+
+		return nil, err
+	}
+	n := &inotifyNotifier{
+		fd:      fd,
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		changes: make(chan struct{}, 1),
+	}
+	go n.read()
+
+	return n, nil
+}
+
+type inotifyNotifier struct {
+	fd int
+	// file makes the reads of the non-blocking descriptor wait in the runtime poller, so closing it ends read.
+	file    *os.File
+	changes chan struct{}
+}
+
+func (n *inotifyNotifier) read() {
+	buffer := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		_, err := n.file.Read(buffer)
+		if err != nil {
+
+			return
+		}
+		select {
+		case n.changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (n *inotifyNotifier) add(dir string) error {
+	_, err := unix.InotifyAddWatch(n.fd, dir, inotifyMask)
+
+	return err
+}
+
+func (n *inotifyNotifier) changed() <-chan struct{} {
+
+	return n.changes
+}
+
+func (n *inotifyNotifier) close() error {
+
+	return n.file.Close()
+}
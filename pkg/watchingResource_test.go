@@ -0,0 +1,117 @@
+//  Copyright hyperjumptech/grule-rule-engine Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeWatchedFile(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func receiveChanges(t *testing.T, changes <-chan []ResourceChange) []ResourceChange {
+	select {
+	case changed := <-changes:
+
+		return changed
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change received")
+	}
+
+	return nil
+}
+
+func newTestWatchingBundle(t *testing.T) (*WatchingFileResourceBundle, string) {
+	dir, err := filepath.Abs(t.TempDir())
+	assert.NoError(t, err)
+	writeWatchedFile(t, filepath.Join(dir, "a.grl"), `rule A "a" { when true then Retract("A"); }`)
+	writeWatchedFile(t, filepath.Join(dir, "b.json"), `[]`)
+	writeWatchedFile(t, filepath.Join(dir, "c.txt"), `not a rule`)
+	writeWatchedFile(t, filepath.Join(dir, "sub", "d.grl"), `rule D "d" { when true then Retract("D"); }`)
+	bundle := NewWatchingFileResourceBundle(dir, dir+"/**/*.grl", dir+"/**/*.json")
+	bundle.Debounce = 20 * time.Millisecond
+
+	return bundle, dir
+}
+
+func TestWatchingFileResourceBundle(t *testing.T) {
+	bundle, dir := newTestWatchingBundle(t)
+	bundle.Debounce = 100 * time.Millisecond
+	// the changes are told by inotify on linux, and found by polling elsewhere.
+	bundle.PollInterval = time.Hour
+	if runtime.GOOS != "linux" {
+		bundle.PollInterval = 10 * time.Millisecond
+	}
+	resources, err := bundle.Load()
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := bundle.Watch(ctx)
+	assert.NoError(t, err)
+
+	// a burst of edits is told at once, the files not matching the patterns and the untouched contents are ignored.
+	writeWatchedFile(t, filepath.Join(dir, "a.grl"), `rule A "a changed" { when true then Retract("A"); }`)
+	writeWatchedFile(t, filepath.Join(dir, "sub", "e.grl"), `rule E "e" { when true then Retract("E"); }`)
+	assert.NoError(t, os.Remove(filepath.Join(dir, "b.json")))
+	writeWatchedFile(t, filepath.Join(dir, "c.txt"), `still not a rule`)
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(filepath.Join(dir, "sub", "d.grl"), later, later))
+	assert.Equal(t, []ResourceChange{
+		{Kind: ResourceModified, Path: filepath.Join(dir, "a.grl")},
+		{Kind: ResourceDeleted, Path: filepath.Join(dir, "b.json")},
+		{Kind: ResourceAdded, Path: filepath.Join(dir, "sub", "e.grl")},
+	}, receiveChanges(t, changes))
+
+	// the directories created while watching are watched too.
+	writeWatchedFile(t, filepath.Join(dir, "new", "f.json"), `[]`)
+	changed := receiveChanges(t, changes)
+	assert.Equal(t, []ResourceChange{{Kind: ResourceAdded, Path: filepath.Join(dir, "new", "f.json")}}, changed)
+	assert.Equal(t, filepath.Join(dir, "new", "f.json")+" added", changed[0].String())
+
+	cancel()
+	_, open := <-changes
+	assert.False(t, open)
+}
+
+func TestWatchingFileResourceBundle_Polling(t *testing.T) {
+	bundle, dir := newTestWatchingBundle(t)
+	bundle.PollInterval = 10 * time.Millisecond
+	files, _, err := bundle.scan(nil)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan []ResourceChange)
+	go bundle.watch(ctx, &pollingNotifier{}, files, changes)
+
+	assert.NoError(t, os.Remove(filepath.Join(dir, "sub", "d.grl")))
+	assert.Equal(t, []ResourceChange{{Kind: ResourceDeleted, Path: filepath.Join(dir, "sub", "d.grl")}}, receiveChanges(t, changes))
+}
+
+func TestWatchingFileResourceBundle_Errors(t *testing.T) {
+	bundle := NewWatchingFileResourceBundle(filepath.Join(t.TempDir(), "missing"), "**/*.grl")
+	_, err := bundle.Watch(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "ResourceChangeKind(7)", ResourceChangeKind(7).String())
+}